@@ -0,0 +1,10 @@
+package v1
+
+const (
+	// ConditionReady indicates that the resource is reconciled and the Keycloak object matches the spec.
+	ConditionReady = "Ready"
+	// ConditionSynced indicates that the last reconciliation succeeded.
+	ConditionSynced = "Synced"
+	// ConditionDegraded indicates that the last reconciliation failed and is retried.
+	ConditionDegraded = "Degraded"
+)
@@ -0,0 +1,11 @@
+package v1
+
+const (
+	// DeletionPolicyDelete deletes Keycloak object when custom resource is deleted.
+	DeletionPolicyDelete = "Delete"
+	// DeletionPolicyRetain keeps Keycloak object when custom resource is deleted.
+	DeletionPolicyRetain = "Retain"
+
+	// DeletionPolicyAnnotation overrides spec.deletionPolicy of custom resource.
+	DeletionPolicyAnnotation = "v1.edp.epam.com/deletion-policy"
+)
@@ -10,17 +10,127 @@ type KeycloakSpec struct {
 	Url string `json:"url"`
 
 	// Secret is a secret name which contains admin credentials.
+	// Keys username and password are used for user and serviceAccount admin types.
+	// Keys username and privateKey are used for privateKeyJWT admin type.
 	Secret string `json:"secret"`
 
-	// AdminType can be user or serviceAccount, if serviceAccount was specified, then client_credentials grant type should be used for getting admin realm token.
+	// AdminType can be user, serviceAccount or privateKeyJWT.
+	// If serviceAccount was specified, then client_credentials grant type should be used for getting admin realm token.
+	// If privateKeyJWT was specified, then client_credentials grant type with JWT client assertion signed
+	// by the PEM encoded RSA or EC private key is used.
 	// +optional
-	// +kubebuilder:validation:Enum=serviceAccount;user
+	// +kubebuilder:validation:Enum=serviceAccount;user;privateKeyJWT
 	AdminType string `json:"adminType,omitempty"`
+
+	// AdminRealm is a realm where admin user or service account is located.
+	// +optional
+	// +kubebuilder:default=master
+	AdminRealm string `json:"adminRealm,omitempty"`
+
+	// PersistToken enables storing of the admin token in kc-token-* secret in the resource namespace.
+	// By default, the token is kept only in the operator memory.
+	// +optional
+	PersistToken bool `json:"persistToken,omitempty"`
+
+	// TLS specifies TLS settings for the connection to Keycloak.
+	// +optional
+	TLS *KeycloakTLS `json:"tls,omitempty"`
+
+	// Retry specifies retry policy for failed requests to Keycloak.
+	// Only GET, PUT and DELETE requests are retried on connection errors, 429 and 5xx responses.
+	// +optional
+	Retry *KeycloakRetry `json:"retry,omitempty"`
+
+	// RateLimit specifies client-side limits for requests to Keycloak.
+	// The limits are shared by all resources which use this instance.
+	// +optional
+	RateLimit *KeycloakRateLimit `json:"rateLimit,omitempty"`
+}
+
+// KeycloakRetry defines retry policy for requests to Keycloak.
+type KeycloakRetry struct {
+	// MaxRetries is a maximum number of retries of a failed request. Zero disables retries.
+	// Defaults to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	MaxRetries *int `json:"maxRetries,omitempty"`
+
+	// WaitTime is an initial wait time between retries. It grows exponentially with random jitter.
+	// Defaults to 500ms.
+	// +optional
+	WaitTime *metav1.Duration `json:"waitTime,omitempty"`
+
+	// MaxWaitTime is a maximum wait time between retries.
+	// It also limits the wait time requested by Keycloak with Retry-After header.
+	// Defaults to 10s.
+	// +optional
+	MaxWaitTime *metav1.Duration `json:"maxWaitTime,omitempty"`
+}
+
+// KeycloakRateLimit defines client-side limits for requests to Keycloak.
+type KeycloakRateLimit struct {
+	// RequestsPerSecond is a maximum average number of requests per second. Zero means no limit.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	RequestsPerSecond int `json:"requestsPerSecond,omitempty"`
+
+	// Burst is a maximum number of requests which can be sent at once above the average rate.
+	// Defaults to RequestsPerSecond.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Burst int `json:"burst,omitempty"`
+
+	// MaxConcurrentRequests is a maximum number of in-flight requests. Zero means no limit.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxConcurrentRequests int `json:"maxConcurrentRequests,omitempty"`
+}
+
+// KeycloakTLS defines TLS settings for the connection to Keycloak.
+type KeycloakTLS struct {
+	// CACert is a reference to the CA bundle used to verify Keycloak server certificate.
+	// Certificates from the bundle are added to the system root CAs.
+	// +optional
+	CACert *CACertSource `json:"caCert,omitempty"`
+
+	// ClientCertSecret is a name of kubernetes.io/tls secret with client certificate and private key
+	// which are presented to Keycloak. Keys tls.crt and tls.key are used.
+	// +optional
+	ClientCertSecret string `json:"clientCertSecret,omitempty"`
+
+	// InsecureSkipVerify disables verification of Keycloak server certificate.
+	// It should be used only for testing purposes.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// CACertSource is a reference to the CA bundle. Only one of ConfigMapKeyRef or SecretKeyRef should be specified.
+type CACertSource struct {
+	// ConfigMapKeyRef selects a key of ConfigMap which contains PEM encoded CA bundle.
+	// +optional
+	ConfigMapKeyRef *KeySelector `json:"configMapKeyRef,omitempty"`
+
+	// SecretKeyRef selects a key of Secret which contains PEM encoded CA bundle.
+	// +optional
+	SecretKeyRef *KeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// KeySelector selects a key of ConfigMap or Secret.
+type KeySelector struct {
+	// Name of the resource.
+	Name string `json:"name"`
+
+	// Key of the resource data.
+	Key string `json:"key"`
 }
 
 const (
 	KeycloakAdminTypeUser           = "user"
 	KeycloakAdminTypeServiceAccount = "serviceAccount"
+	KeycloakAdminTypePrivateKeyJWT  = "privateKeyJWT"
+
+	KeycloakDefaultAdminRealm = "master"
 )
 
 func (in *Keycloak) GetAdminType() string {
@@ -31,16 +141,70 @@ func (in *Keycloak) GetAdminType() string {
 	return in.Spec.AdminType
 }
 
+func (in *Keycloak) GetAdminRealm() string {
+	if in.Spec.AdminRealm == "" {
+		return KeycloakDefaultAdminRealm
+	}
+
+	return in.Spec.AdminRealm
+}
+
+const (
+	// KeycloakConditionConnected indicates that the operator is able to login to Keycloak.
+	KeycloakConditionConnected = "Connected"
+	// KeycloakConditionServerInfoAvailable indicates that Keycloak server info was retrieved.
+	KeycloakConditionServerInfoAvailable = "ServerInfoAvailable"
+)
+
 // KeycloakStatus defines the observed state of Keycloak.
 type KeycloakStatus struct {
 	// Connected shows if keycloak service is up and running.
 	Connected bool `json:"connected"`
+
+	// Version is a version of the Keycloak server.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// BasePath is a path where Keycloak is served as reported by the server, e.g. /auth for legacy Keycloak distributions.
+	// The operator uses it to choose the API path instead of probing both paths on each login.
+	// +optional
+	BasePath string `json:"basePath,omitempty"`
+
+	// Features is a list of enabled Keycloak features.
+	// Keycloak versions before 22 report only enabled preview and experimental features.
+	// +nullable
+	// +optional
+	Features []string `json:"features,omitempty"`
+
+	// CredentialsVersion is a resource version of the admin credentials secret used for the last connection.
+	// It is used to detect rotation of the admin credentials.
+	// +optional
+	CredentialsVersion string `json:"credentialsVersion,omitempty"`
+
+	// LastConnected is a time of the last successful connection to Keycloak.
+	// +optional
+	LastConnected *metav1.Time `json:"lastConnected,omitempty"`
+
+	// Conditions represent the latest available observations of the Keycloak connection.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the generation of the resource observed by the last reconciliation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Connected",type="boolean",JSONPath=".status.connected",description="Is connected to keycloak"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version",description="Keycloak server version"
+// +kubebuilder:printcolumn:name="Last Connected",type="date",JSONPath=".status.lastConnected",description="Time of the last successful connection",priority=1
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Is the resource ready"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="Is the last reconciliation successful"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",description="Reason of the Ready condition",priority=1
 
 // Keycloak is the Schema for the keycloaks API.
 type Keycloak struct {
@@ -51,6 +215,18 @@ type Keycloak struct {
 	Status KeycloakStatus `json:"status,omitempty"`
 }
 
+func (in *Keycloak) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+func (in *Keycloak) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func (in *Keycloak) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
+
 // +kubebuilder:object:root=true
 
 // KeycloakList contains a list of Keycloak.
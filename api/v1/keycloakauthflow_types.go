@@ -5,7 +5,13 @@ import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 // KeycloakAuthFlowSpec defines the desired state of KeycloakAuthFlow.
 type KeycloakAuthFlowSpec struct {
 	// Realm is name of KeycloakRealm custom resource.
-	Realm string `json:"realm"`
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// ClusterRealmRef is name of ClusterKeycloakRealm custom resource.
+	// If set, it takes precedence over Realm.
+	// +optional
+	ClusterRealmRef string `json:"clusterRealmRef,omitempty"`
 
 	// Alias is display name for authentication flow.
 	Alias string `json:"alias"`
@@ -35,6 +41,13 @@ type KeycloakAuthFlowSpec struct {
 	// ChildType is type for auth flow if it has a parent, available options: basic-flow, form-flow
 	// +optional
 	ChildType string `json:"childType,omitempty"`
+
+	// DeletionPolicy defines whether keycloak auth flow is deleted when the custom resource is deleted.
+	// Delete removes keycloak auth flow, Retain keeps it. Can be overridden by v1.edp.epam.com/deletion-policy annotation.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
 }
 
 // AuthenticationExecution defines keycloak authentication execution.
@@ -83,11 +96,24 @@ type KeycloakAuthFlowStatus struct {
 
 	// +optional
 	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// ObservedGeneration is the generation of the resource observed by the last reconciliation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the resource state: Ready, Synced and Degraded.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Is the resource ready"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="Is the last reconciliation successful"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",description="Reason of the Ready condition",priority=1
 
 // KeycloakAuthFlow is the Schema for the keycloak authentication flow API.
 type KeycloakAuthFlow struct {
@@ -102,6 +128,10 @@ func (in *KeycloakAuthFlow) K8SParentRealmName() (string, error) {
 	return in.Spec.Realm, nil
 }
 
+func (in *KeycloakAuthFlow) K8SParentClusterRealmName() string {
+	return in.Spec.ClusterRealmRef
+}
+
 func (in *KeycloakAuthFlow) GetFailureCount() int64 {
 	return in.Status.FailureCount
 }
@@ -118,6 +148,26 @@ func (in *KeycloakAuthFlow) SetStatus(value string) {
 	in.Status.Value = value
 }
 
+func (in *KeycloakAuthFlow) GetDeletionPolicy() string {
+	if in.Spec.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+
+	return in.Spec.DeletionPolicy
+}
+
+func (in *KeycloakAuthFlow) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+func (in *KeycloakAuthFlow) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func (in *KeycloakAuthFlow) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
+
 // +kubebuilder:object:root=true
 
 // KeycloakAuthFlowList contains a list of KeycloakAuthFlow.
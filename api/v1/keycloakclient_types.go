@@ -20,6 +20,11 @@ type KeycloakClientSpec struct {
 	// +optional
 	TargetRealm string `json:"targetRealm,omitempty"`
 
+	// ClusterRealmRef is name of ClusterKeycloakRealm custom resource.
+	// If set, it takes precedence over TargetRealm.
+	// +optional
+	ClusterRealmRef string `json:"clusterRealmRef,omitempty"`
+
 	// Secret is a client secret used for authentication. If not provided, it will be generated.
 	// +optional
 	Secret string `json:"secret,omitempty"`
@@ -83,6 +88,13 @@ type KeycloakClientSpec struct {
 	// +nullable
 	// +optional
 	DefaultClientScopes []string `json:"defaultClientScopes,omitempty"`
+
+	// DeletionPolicy defines whether keycloak client is deleted when the custom resource is deleted.
+	// Delete removes keycloak client, Retain keeps it. Can be overridden by v1.edp.epam.com/deletion-policy annotation.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
 }
 
 type ServiceAccount struct {
@@ -157,11 +169,24 @@ type KeycloakClientStatus struct {
 
 	// +optional
 	ClientSecretName string `json:"clientSecretName,omitempty"`
+
+	// ObservedGeneration is the generation of the resource observed by the last reconciliation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the resource state: Ready, Synced and Degraded.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Is the resource ready"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="Is the last reconciliation successful"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",description="Reason of the Ready condition",priority=1
 
 // KeycloakClient is the Schema for the keycloak clients API.
 type KeycloakClient struct {
@@ -196,6 +221,26 @@ func (in *KeycloakClient) GetReconciliationStrategy() string {
 	return in.Spec.ReconciliationStrategy
 }
 
+func (in *KeycloakClient) GetDeletionPolicy() string {
+	if in.Spec.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+
+	return in.Spec.DeletionPolicy
+}
+
+func (in *KeycloakClient) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+func (in *KeycloakClient) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func (in *KeycloakClient) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
+
 // +kubebuilder:object:root=true
 
 // KeycloakClientList contains a list of KeycloakClient.
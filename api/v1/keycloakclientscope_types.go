@@ -8,7 +8,13 @@ type KeycloakClientScopeSpec struct {
 	Name string `json:"name"`
 
 	// Realm is name of KeycloakRealm custom resource.
-	Realm string `json:"realm"`
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// ClusterRealmRef is name of ClusterKeycloakRealm custom resource.
+	// If set, it takes precedence over Realm.
+	// +optional
+	ClusterRealmRef string `json:"clusterRealmRef,omitempty"`
 
 	// Protocol is SSO protocol configuration which is being supplied by this client scope.
 	Protocol string `json:"protocol"`
@@ -30,6 +36,13 @@ type KeycloakClientScopeSpec struct {
 	// +nullable
 	// +optional
 	ProtocolMappers []ProtocolMapper `json:"protocolMappers,omitempty"`
+
+	// DeletionPolicy defines whether keycloak client scope is deleted when the custom resource is deleted.
+	// Delete removes keycloak client scope, Retain keeps it. Can be overridden by v1.edp.epam.com/deletion-policy annotation.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
 }
 
 // KeycloakClientScopeStatus defines the observed state of KeycloakClientScope.
@@ -42,11 +55,24 @@ type KeycloakClientScopeStatus struct {
 
 	// +optional
 	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// ObservedGeneration is the generation of the resource observed by the last reconciliation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the resource state: Ready, Synced and Degraded.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Is the resource ready"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="Is the last reconciliation successful"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",description="Reason of the Ready condition",priority=1
 
 // KeycloakClientScope is the Schema for the keycloakclientscopes API.
 type KeycloakClientScope struct {
@@ -61,6 +87,10 @@ func (in *KeycloakClientScope) K8SParentRealmName() (string, error) {
 	return in.Spec.Realm, nil
 }
 
+func (in *KeycloakClientScope) K8SParentClusterRealmName() string {
+	return in.Spec.ClusterRealmRef
+}
+
 func (in *KeycloakClientScope) GetFailureCount() int64 {
 	return in.Status.FailureCount
 }
@@ -77,6 +107,26 @@ func (in *KeycloakClientScope) SetStatus(value string) {
 	in.Status.Value = value
 }
 
+func (in *KeycloakClientScope) GetDeletionPolicy() string {
+	if in.Spec.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+
+	return in.Spec.DeletionPolicy
+}
+
+func (in *KeycloakClientScope) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+func (in *KeycloakClientScope) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func (in *KeycloakClientScope) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
+
 // +kubebuilder:object:root=true
 
 // KeycloakClientScopeList contains a list of KeycloakClientScope.
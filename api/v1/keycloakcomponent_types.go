@@ -8,7 +8,13 @@ type KeycloakComponentSpec struct {
 	Name string `json:"name"`
 
 	// Realm is name of KeycloakRealm custom resource.
-	Realm string `json:"realm"`
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// ClusterRealmRef is name of ClusterKeycloakRealm custom resource.
+	// If set, it takes precedence over Realm.
+	// +optional
+	ClusterRealmRef string `json:"clusterRealmRef,omitempty"`
 
 	// ProviderID is a provider ID of component.
 	ProviderID string `json:"providerId"`
@@ -19,9 +25,17 @@ type KeycloakComponentSpec struct {
 	Parent string `json:"parent,omitempty"`
 
 	// Config is a map of component configuration.
+	// Value in the format $secretName:secretKey is taken from the Secret in the same namespace.
 	// +nullable
 	// +optional
 	Config map[string][]string `json:"config,omitempty"`
+
+	// DeletionPolicy defines whether keycloak component is deleted when the custom resource is deleted.
+	// Delete removes keycloak component, Retain keeps it. Can be overridden by v1.edp.epam.com/deletion-policy annotation.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
 }
 
 // KeycloakComponentStatus defines the observed state of KeycloakRealmComponent.
@@ -31,11 +45,24 @@ type KeycloakComponentStatus struct {
 
 	// +optional
 	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// ObservedGeneration is the generation of the resource observed by the last reconciliation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the resource state: Ready, Synced and Degraded.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Is the resource ready"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="Is the last reconciliation successful"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",description="Reason of the Ready condition",priority=1
 
 // KeycloakRealmComponent is the Schema for the keycloak component API.
 type KeycloakRealmComponent struct {
@@ -66,10 +93,34 @@ func (in *KeycloakRealmComponent) K8SParentRealmName() (string, error) {
 	return in.Spec.Realm, nil
 }
 
+func (in *KeycloakRealmComponent) K8SParentClusterRealmName() string {
+	return in.Spec.ClusterRealmRef
+}
+
 func (in *KeycloakRealmComponent) K8SParentComponentName() (string, error) {
 	return in.Spec.Parent, nil
 }
 
+func (in *KeycloakRealmComponent) GetDeletionPolicy() string {
+	if in.Spec.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+
+	return in.Spec.DeletionPolicy
+}
+
+func (in *KeycloakRealmComponent) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+func (in *KeycloakRealmComponent) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func (in *KeycloakRealmComponent) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
+
 // +kubebuilder:object:root=true
 
 // KeycloakRealmComponentList contains a list of KeycloakRealmComponent.
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -14,6 +15,11 @@ type KeycloakRealmSpec struct {
 	// +optional
 	KeycloakOwner string `json:"keycloakOwner,omitempty"`
 
+	// ClusterKeycloakRef specifies the name of the ClusterKeycloak instance that owns the realm.
+	// If set, it takes precedence over KeycloakOwner.
+	// +optional
+	ClusterKeycloakRef string `json:"clusterKeycloakRef,omitempty"`
+
 	// SsoRealmName specifies the name of the SSO realm used by the realm.
 	// +optional
 	SsoRealmName string `json:"ssoRealmName,omitempty"`
@@ -75,6 +81,99 @@ type KeycloakRealmSpec struct {
 	// FrontendURL Set the frontend URL for the realm. Use in combination with the default hostname provider to override the base URL for frontend requests for a specific realm.
 	// +optional
 	FrontendURL string `json:"frontendUrl,omitempty"`
+
+	// TokenSettings is the configuration for tokens in the realm.
+	// +nullable
+	// +optional
+	TokenSettings *TokenSettings `json:"tokenSettings,omitempty"`
+
+	// Sessions is the configuration for SSO, offline and client sessions in the realm.
+	// +nullable
+	// +optional
+	Sessions *RealmSessions `json:"sessions,omitempty"`
+
+	// Login is the configuration for login and registration in the realm.
+	// +nullable
+	// +optional
+	Login *RealmLogin `json:"login,omitempty"`
+
+	// SMTP is the configuration of the SMTP server used to send realm emails.
+	// +nullable
+	// +optional
+	SMTP *RealmSMTP `json:"smtp,omitempty"`
+
+	// BruteForceProtection is the configuration of brute force detection in the realm.
+	// +nullable
+	// +optional
+	BruteForceProtection *BruteForceProtection `json:"bruteForceProtection,omitempty"`
+
+	// OTPPolicy is the configuration of one-time password policy in the realm.
+	// +nullable
+	// +optional
+	OTPPolicy *OTPPolicy `json:"otpPolicy,omitempty"`
+
+	// WebAuthnPolicy is the configuration of WebAuthn policy in the realm.
+	// +nullable
+	// +optional
+	WebAuthnPolicy *WebAuthnPolicy `json:"webAuthnPolicy,omitempty"`
+
+	// Localization is the configuration of supported locales and localization texts of the realm.
+	// +nullable
+	// +optional
+	Localization *RealmLocalization `json:"localization,omitempty"`
+
+	// RequiredActions is a list of required actions to configure in the realm.
+	// Actions provided by SPIs which are not registered in the realm yet are registered.
+	// Required actions which are not in the list are left unchanged.
+	// +nullable
+	// +optional
+	RequiredActions []RequiredAction `json:"requiredActions,omitempty"`
+
+	// UserProfile is the declarative user profile configuration of the realm.
+	// It is supported by Keycloak 22+, the realm reconciliation fails on older versions.
+	// On Keycloak 22 and 23 the DECLARATIVE_USER_PROFILE feature must be enabled on the server.
+	// +nullable
+	// +optional
+	UserProfile *UserProfile `json:"userProfile,omitempty"`
+
+	// ClientProfiles is a list of realm client profiles. A profile is a set of executors applied to clients, e.g. pkce-enforcer.
+	// Profiles are matched by name, realm profiles which are not in the list are kept.
+	// +nullable
+	// +optional
+	ClientProfiles []ClientProfile `json:"clientProfiles,omitempty"`
+
+	// ClientPolicies is a list of realm client policies. A policy applies profiles to clients which match its conditions.
+	// Policies are matched by name, realm policies which are not in the list are kept.
+	// +nullable
+	// +optional
+	ClientPolicies []ClientPolicy `json:"clientPolicies,omitempty"`
+
+	// DefaultGroups is a list of names of top-level groups new users are added to.
+	// +nullable
+	// +optional
+	DefaultGroups []string `json:"defaultGroups,omitempty"`
+
+	// DefaultRoles is a list of roles assigned to new users. They are composites of the realm default role default-roles-<realm>.
+	// +nullable
+	// +optional
+	DefaultRoles *RealmDefaultRoles `json:"defaultRoles,omitempty"`
+
+	// DefaultsReconciliationStrategy is a strategy to reconcile DefaultGroups and DefaultRoles.
+	// If set to full, default groups and roles which are not in the spec are removed. It is applied only to the specified fields.
+	// Built-in default roles offline_access, uma_authorization, account/view-profile and account/manage-account
+	// and roles made default by KeycloakRealmRole or KeycloakRealmRoleBatch with isDefault are not removed.
+	// If set to addOnly, default groups and roles are only added.
+	// Default value: full.
+	// +kubebuilder:validation:Enum=full;addOnly
+	// +optional
+	DefaultsReconciliationStrategy string `json:"defaultsReconciliationStrategy,omitempty"`
+
+	// DeletionPolicy defines whether keycloak realm is deleted when the custom resource is deleted.
+	// Delete removes keycloak realm, Retain keeps it. Can be overridden by v1.edp.epam.com/deletion-policy annotation.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Retain
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
 }
 
 type User struct {
@@ -147,6 +246,658 @@ type RealmThemes struct {
 	InternationalizationEnabled *bool `json:"internationalizationEnabled"`
 }
 
+// TokenSettings defines lifespans of tokens and codes in the realm. All durations are in seconds.
+// Unset fields are left unchanged in Keycloak.
+type TokenSettings struct {
+	// AccessTokenLifespan is the maximum time before an access token is expired.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	AccessTokenLifespan *int `json:"accessTokenLifespan,omitempty"`
+
+	// AccessTokenLifespanForImplicitFlow is the maximum time before an access token issued during OpenID Connect Implicit Flow is expired.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	AccessTokenLifespanForImplicitFlow *int `json:"accessTokenLifespanForImplicitFlow,omitempty"`
+
+	// AccessCodeLifespan is the maximum time a client has to finish the access token protocol.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	AccessCodeLifespan *int `json:"accessCodeLifespan,omitempty"`
+
+	// AccessCodeLifespanLogin is the maximum time a user has to complete a login.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	AccessCodeLifespanLogin *int `json:"accessCodeLifespanLogin,omitempty"`
+
+	// AccessCodeLifespanUserAction is the maximum time a user has to complete login related actions like update password.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	AccessCodeLifespanUserAction *int `json:"accessCodeLifespanUserAction,omitempty"`
+
+	// ActionTokenGeneratedByUserLifespan is the maximum time before an action permit sent by a user is expired.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ActionTokenGeneratedByUserLifespan *int `json:"actionTokenGeneratedByUserLifespan,omitempty"`
+
+	// ActionTokenGeneratedByAdminLifespan is the maximum time before an action permit sent to a user by administrator is expired.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ActionTokenGeneratedByAdminLifespan *int `json:"actionTokenGeneratedByAdminLifespan,omitempty"`
+
+	// RevokeRefreshToken indicates whether refresh tokens can be used only once.
+	// +optional
+	RevokeRefreshToken *bool `json:"revokeRefreshToken,omitempty"`
+
+	// RefreshTokenMaxReuse is the maximum number of times a refresh token can be reused.
+	// It is used only if RevokeRefreshToken is enabled.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	RefreshTokenMaxReuse *int `json:"refreshTokenMaxReuse,omitempty"`
+}
+
+// RealmSessions defines session timeouts in the realm. All durations are in seconds.
+// Unset fields are left unchanged in Keycloak.
+type RealmSessions struct {
+	// SSOSessionIdleTimeout is the time a session is allowed to be idle before it expires.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	SSOSessionIdleTimeout *int `json:"ssoSessionIdleTimeout,omitempty"`
+
+	// SSOSessionMaxLifespan is the maximum time before a session is expired.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	SSOSessionMaxLifespan *int `json:"ssoSessionMaxLifespan,omitempty"`
+
+	// SSOSessionIdleTimeoutRememberMe is the idle timeout of a session with remember me enabled.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	SSOSessionIdleTimeoutRememberMe *int `json:"ssoSessionIdleTimeoutRememberMe,omitempty"`
+
+	// SSOSessionMaxLifespanRememberMe is the maximum lifespan of a session with remember me enabled.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	SSOSessionMaxLifespanRememberMe *int `json:"ssoSessionMaxLifespanRememberMe,omitempty"`
+
+	// OfflineSessionIdleTimeout is the time an offline session is allowed to be idle before it expires.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	OfflineSessionIdleTimeout *int `json:"offlineSessionIdleTimeout,omitempty"`
+
+	// OfflineSessionMaxLifespanEnabled enables the maximum lifespan of offline sessions.
+	// +optional
+	OfflineSessionMaxLifespanEnabled *bool `json:"offlineSessionMaxLifespanEnabled,omitempty"`
+
+	// OfflineSessionMaxLifespan is the maximum time before an offline session is expired.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	OfflineSessionMaxLifespan *int `json:"offlineSessionMaxLifespan,omitempty"`
+
+	// ClientSessionIdleTimeout is the time a client session is allowed to be idle before it expires.
+	// Zero means that SSOSessionIdleTimeout is used.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ClientSessionIdleTimeout *int `json:"clientSessionIdleTimeout,omitempty"`
+
+	// ClientSessionMaxLifespan is the maximum time before a client session is expired.
+	// Zero means that SSOSessionMaxLifespan is used.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ClientSessionMaxLifespan *int `json:"clientSessionMaxLifespan,omitempty"`
+
+	// ClientOfflineSessionIdleTimeout is the time a client offline session is allowed to be idle before it expires.
+	// Zero means that OfflineSessionIdleTimeout is used.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ClientOfflineSessionIdleTimeout *int `json:"clientOfflineSessionIdleTimeout,omitempty"`
+
+	// ClientOfflineSessionMaxLifespan is the maximum time before a client offline session is expired.
+	// Zero means that OfflineSessionMaxLifespan is used.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ClientOfflineSessionMaxLifespan *int `json:"clientOfflineSessionMaxLifespan,omitempty"`
+}
+
+// RealmLogin defines login and registration settings of the realm.
+// Unset fields are left unchanged in Keycloak.
+type RealmLogin struct {
+	// RegistrationAllowed enables the registration page on the login screen.
+	// +nullable
+	// +optional
+	RegistrationAllowed *bool `json:"registrationAllowed,omitempty"`
+
+	// RegistrationEmailAsUsername indicates whether email is used as username during registration.
+	// +nullable
+	// +optional
+	RegistrationEmailAsUsername *bool `json:"registrationEmailAsUsername,omitempty"`
+
+	// RememberMe shows the remember me checkbox on the login page.
+	// +nullable
+	// +optional
+	RememberMe *bool `json:"rememberMe,omitempty"`
+
+	// VerifyEmail requires users to verify their email address after the initial login or after address changes.
+	// +nullable
+	// +optional
+	VerifyEmail *bool `json:"verifyEmail,omitempty"`
+
+	// LoginWithEmailAllowed allows users to log in with their email address.
+	// +nullable
+	// +optional
+	LoginWithEmailAllowed *bool `json:"loginWithEmailAllowed,omitempty"`
+
+	// DuplicateEmailsAllowed allows multiple users to have the same email address.
+	// +nullable
+	// +optional
+	DuplicateEmailsAllowed *bool `json:"duplicateEmailsAllowed,omitempty"`
+
+	// ResetPasswordAllowed shows a link on the login page for users who have forgotten their credentials.
+	// +nullable
+	// +optional
+	ResetPasswordAllowed *bool `json:"resetPasswordAllowed,omitempty"`
+
+	// EditUsernameAllowed allows users to change their username.
+	// +nullable
+	// +optional
+	EditUsernameAllowed *bool `json:"editUsernameAllowed,omitempty"`
+}
+
+// RealmSMTP defines SMTP server settings of the realm.
+type RealmSMTP struct {
+	// Host is the SMTP server host.
+	Host string `json:"host"`
+
+	// Port is the SMTP server port. If not set, the default port is used.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int `json:"port,omitempty"`
+
+	// From is the sender email address.
+	From string `json:"from"`
+
+	// FromDisplayName is the user-friendly name of the sender.
+	// +optional
+	FromDisplayName string `json:"fromDisplayName,omitempty"`
+
+	// ReplyTo is the email address used for replies.
+	// +optional
+	ReplyTo string `json:"replyTo,omitempty"`
+
+	// EnvelopeFrom is the bounce address.
+	// +optional
+	EnvelopeFrom string `json:"envelopeFrom,omitempty"`
+
+	// SSL enables SSL/TLS connection to the SMTP server.
+	// +optional
+	SSL bool `json:"ssl,omitempty"`
+
+	// StartTLS enables StartTLS for the connection to the SMTP server.
+	// +optional
+	StartTLS bool `json:"startTls,omitempty"`
+
+	// User is the SMTP server user. If set, authentication is enabled.
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// PasswordSecret defines Kubernetes secret Name and Key, which holds the SMTP user password.
+	// The secret should be in the same namespace as the realm.
+	// +nullable
+	// +optional
+	PasswordSecret *PasswordSecret `json:"passwordSecret,omitempty"`
+}
+
+// BruteForceProtection defines brute force detection settings of the realm.
+// Unset fields are left unchanged in Keycloak.
+type BruteForceProtection struct {
+	// Enabled enables brute force detection.
+	// +nullable
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// PermanentLockout locks the user permanently when the user exceeds the maximum login failures.
+	// +nullable
+	// +optional
+	PermanentLockout *bool `json:"permanentLockout,omitempty"`
+
+	// MaxLoginFailures is the number of login failures before a wait is triggered.
+	// +nullable
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxLoginFailures *int `json:"maxLoginFailures,omitempty"`
+
+	// WaitIncrementSeconds is the time a user is locked out when the failure threshold has been met.
+	// +nullable
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	WaitIncrementSeconds *int `json:"waitIncrementSeconds,omitempty"`
+
+	// MaxFailureWaitSeconds is the maximum time a user is locked out.
+	// +nullable
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxFailureWaitSeconds *int `json:"maxFailureWaitSeconds,omitempty"`
+
+	// MaxDeltaTimeSeconds is the time after which the failure count is reset.
+	// +nullable
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxDeltaTimeSeconds *int `json:"maxDeltaTimeSeconds,omitempty"`
+
+	// QuickLoginCheckMilliSeconds is the minimum time between login failures.
+	// If failures happen more frequently, the user is locked out for MinimumQuickLoginWaitSeconds.
+	// +nullable
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	QuickLoginCheckMilliSeconds *int64 `json:"quickLoginCheckMilliSeconds,omitempty"`
+
+	// MinimumQuickLoginWaitSeconds is the time a user is locked out after quick login failures.
+	// +nullable
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinimumQuickLoginWaitSeconds *int `json:"minimumQuickLoginWaitSeconds,omitempty"`
+}
+
+// OTPPolicy defines one-time password policy of the realm.
+// Unset fields are left unchanged in Keycloak.
+type OTPPolicy struct {
+	// Type is the OTP type. totp is time-based, hotp is counter-based.
+	// +nullable
+	// +optional
+	// +kubebuilder:validation:Enum=totp;hotp
+	Type *string `json:"type,omitempty"`
+
+	// Algorithm is the hash algorithm used to generate OTP.
+	// +nullable
+	// +optional
+	// +kubebuilder:validation:Enum=HmacSHA1;HmacSHA256;HmacSHA512
+	Algorithm *string `json:"algorithm,omitempty"`
+
+	// Digits is the number of OTP digits.
+	// +nullable
+	// +optional
+	// +kubebuilder:validation:Enum=6;8
+	Digits *int `json:"digits,omitempty"`
+
+	// Period is the number of seconds a totp token is valid.
+	// +nullable
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Period *int `json:"period,omitempty"`
+
+	// LookAheadWindow is the number of intervals the server checks around the current one to tolerate clock skew.
+	// +nullable
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	LookAheadWindow *int `json:"lookAheadWindow,omitempty"`
+
+	// InitialCounter is the initial counter value for hotp.
+	// +nullable
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	InitialCounter *int `json:"initialCounter,omitempty"`
+}
+
+// WebAuthnPolicy defines WebAuthn policy of the realm.
+// Unset fields are left unchanged in Keycloak.
+type WebAuthnPolicy struct {
+	// RpEntityName is the human-readable relying party name.
+	// +nullable
+	// +optional
+	RpEntityName *string `json:"rpEntityName,omitempty"`
+
+	// RpID is the relying party ID. It should be a domain of the Keycloak server.
+	// +nullable
+	// +optional
+	RpID *string `json:"rpId,omitempty"`
+
+	// SignatureAlgorithms is a list of acceptable signature algorithms, e.g. ES256, RS256.
+	// +nullable
+	// +optional
+	SignatureAlgorithms []string `json:"signatureAlgorithms,omitempty"`
+
+	// AttestationConveyancePreference is the preference for attestation conveyance.
+	// +nullable
+	// +optional
+	// +kubebuilder:validation:Enum=not specified;none;indirect;direct
+	AttestationConveyancePreference *string `json:"attestationConveyancePreference,omitempty"`
+
+	// AuthenticatorAttachment is the acceptable attachment pattern of the authenticator.
+	// +nullable
+	// +optional
+	// +kubebuilder:validation:Enum=not specified;platform;cross-platform
+	AuthenticatorAttachment *string `json:"authenticatorAttachment,omitempty"`
+
+	// RequireResidentKey indicates whether the authenticator must create a client-side discoverable credential.
+	// +nullable
+	// +optional
+	// +kubebuilder:validation:Enum=not specified;Yes;No
+	RequireResidentKey *string `json:"requireResidentKey,omitempty"`
+
+	// UserVerificationRequirement is the requirement for the user verification by the authenticator.
+	// +nullable
+	// +optional
+	// +kubebuilder:validation:Enum=not specified;required;preferred;discouraged
+	UserVerificationRequirement *string `json:"userVerificationRequirement,omitempty"`
+
+	// CreateTimeout is the timeout of the authenticator registration in seconds. Zero means no timeout.
+	// +nullable
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	CreateTimeout *int `json:"createTimeout,omitempty"`
+
+	// AvoidSameAuthenticatorRegister prevents registering the same authenticator twice.
+	// +nullable
+	// +optional
+	AvoidSameAuthenticatorRegister *bool `json:"avoidSameAuthenticatorRegister,omitempty"`
+
+	// AcceptableAaguids is a list of AAGUIDs of authenticators which can be registered.
+	// +nullable
+	// +optional
+	AcceptableAaguids []string `json:"acceptableAaguids,omitempty"`
+}
+
+// RealmLocalization defines supported locales and localization texts of the realm.
+type RealmLocalization struct {
+	// SupportedLocales is a list of locales supported by the realm, e.g. en, de, uk.
+	// Internationalization is enabled in the realm if the list is not empty.
+	// +nullable
+	// +optional
+	SupportedLocales []string `json:"supportedLocales,omitempty"`
+
+	// DefaultLocale is the locale used by default. It should be one of SupportedLocales.
+	// +optional
+	DefaultLocale string `json:"defaultLocale,omitempty"`
+
+	// Texts is a list of ConfigMaps with localization texts. Each ConfigMap holds texts of one locale,
+	// where the key is a message key and the value is a translated text.
+	// ConfigMaps should be in the same namespace as the realm.
+	// +nullable
+	// +optional
+	Texts []LocalizationTexts `json:"texts,omitempty"`
+
+	// ReconciliationStrategy is a strategy to reconcile localization texts.
+	// If set to full, texts of the locale which are not present in the ConfigMap are removed from the realm,
+	// as well as texts of locales which are not in Texts.
+	// If set to addOnly, texts are only added or updated.
+	// Default value: full.
+	// +kubebuilder:validation:Enum=full;addOnly
+	// +optional
+	ReconciliationStrategy string `json:"reconciliationStrategy,omitempty"`
+}
+
+// LocalizationTexts defines ConfigMap with localization texts of the locale.
+type LocalizationTexts struct {
+	// Locale is the locale of the texts, e.g. en.
+	Locale string `json:"locale"`
+
+	// ConfigMapName is the name of the ConfigMap with the texts.
+	ConfigMapName string `json:"configMapName"`
+}
+
+// GetReconciliationStrategy returns reconciliation strategy of localization texts. Defaults to full.
+func (in *RealmLocalization) GetReconciliationStrategy() string {
+	if in.ReconciliationStrategy == "" {
+		return ReconciliationStrategyFull
+	}
+
+	return in.ReconciliationStrategy
+}
+
+// RequiredAction defines required action of the realm.
+type RequiredAction struct {
+	// Alias is the alias of the required action, e.g. CONFIGURE_TOTP, UPDATE_PASSWORD, TERMS_AND_CONDITIONS.
+	Alias string `json:"alias"`
+
+	// Name is the display name of the required action.
+	// If not set, the name of the existing action or the provider is kept.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// ProviderID is the ID of the required action provider. It is used to register the action.
+	// Keycloak registers the action with alias equal to the provider ID,
+	// so the registered action is found either by Alias or by ProviderID.
+	// Defaults to Alias.
+	// +optional
+	ProviderID string `json:"providerId,omitempty"`
+
+	// Enabled indicates whether the required action is enabled.
+	// +kubebuilder:default=true
+	// +optional
+	Enabled bool `json:"enabled"`
+
+	// DefaultAction indicates whether the required action is assigned to new users.
+	// +optional
+	DefaultAction bool `json:"defaultAction,omitempty"`
+
+	// Priority is the order of the required action. Actions with lower priority are executed first.
+	// If not set, the current priority is kept.
+	// +nullable
+	// +optional
+	Priority *int `json:"priority,omitempty"`
+
+	// Config is the configuration of the required action.
+	// +nullable
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// UserProfile defines declarative user profile of the realm.
+// Either Attributes and Groups or ConfigMapKeyRef should be specified.
+type UserProfile struct {
+	// Attributes is a list of user profile attributes.
+	// Attributes are matched by name, attributes of the current profile which are not in the list are kept.
+	// +nullable
+	// +optional
+	Attributes []UserProfileAttribute `json:"attributes,omitempty"`
+
+	// Groups is a list of user profile attribute groups.
+	// Groups are matched by name, groups of the current profile which are not in the list are kept.
+	// +nullable
+	// +optional
+	Groups []UserProfileGroup `json:"groups,omitempty"`
+
+	// UnmanagedAttributePolicy is the policy for attributes which are not defined in the user profile.
+	// It is supported by Keycloak 24+.
+	// +kubebuilder:validation:Enum=ENABLED;ADMIN_VIEW;ADMIN_EDIT
+	// +optional
+	UnmanagedAttributePolicy string `json:"unmanagedAttributePolicy,omitempty"`
+
+	// ConfigMapKeyRef selects a key of ConfigMap which contains the whole user profile configuration in JSON format.
+	// The ConfigMap should be in the same namespace as the realm.
+	// If set, Attributes, Groups and UnmanagedAttributePolicy are ignored and the profile is replaced.
+	// +nullable
+	// +optional
+	ConfigMapKeyRef *KeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// UserProfileAttribute defines user profile attribute.
+type UserProfileAttribute struct {
+	// Name is the name of the attribute.
+	Name string `json:"name"`
+
+	// DisplayName is the display name of the attribute. It can be a localization key, e.g. ${profile.attributes.firstName}.
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Group is the name of the attribute group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Multivalued indicates whether the attribute can have multiple values. It is supported by Keycloak 24+.
+	// +optional
+	Multivalued bool `json:"multivalued,omitempty"`
+
+	// Validations is a map of validators, where the key is the validator name, e.g. length, pattern, email,
+	// and the value is the validator configuration.
+	// +nullable
+	// +optional
+	Validations map[string]map[string]apiextensionsv1.JSON `json:"validations,omitempty"`
+
+	// Annotations is a map of attribute annotations, e.g. inputType.
+	// +nullable
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Required defines when the attribute is required. The attribute is optional if not set.
+	// +nullable
+	// +optional
+	Required *UserProfileAttributeRequired `json:"required,omitempty"`
+
+	// Permissions defines who can view and edit the attribute.
+	// +nullable
+	// +optional
+	Permissions *UserProfileAttributePermissions `json:"permissions,omitempty"`
+
+	// Selector defines scopes when the attribute is available.
+	// +nullable
+	// +optional
+	Selector *UserProfileAttributeSelector `json:"selector,omitempty"`
+}
+
+// UserProfileAttributeRequired defines when the user profile attribute is required.
+type UserProfileAttributeRequired struct {
+	// Roles is a list of roles, user or admin, for which the attribute is required.
+	// +nullable
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+
+	// Scopes is a list of client scopes for which the attribute is required.
+	// +nullable
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// UserProfileAttributePermissions defines who can view and edit the user profile attribute.
+type UserProfileAttributePermissions struct {
+	// View is a list of roles, user or admin, which can view the attribute.
+	// +nullable
+	// +optional
+	View []string `json:"view,omitempty"`
+
+	// Edit is a list of roles, user or admin, which can edit the attribute.
+	// +nullable
+	// +optional
+	Edit []string `json:"edit,omitempty"`
+}
+
+// UserProfileAttributeSelector defines scopes when the user profile attribute is available.
+type UserProfileAttributeSelector struct {
+	// Scopes is a list of client scopes.
+	// +nullable
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// UserProfileGroup defines user profile attribute group.
+type UserProfileGroup struct {
+	// Name is the name of the group.
+	Name string `json:"name"`
+
+	// DisplayHeader is the header of the group shown in forms.
+	// +optional
+	DisplayHeader string `json:"displayHeader,omitempty"`
+
+	// DisplayDescription is the description of the group shown in forms.
+	// +optional
+	DisplayDescription string `json:"displayDescription,omitempty"`
+
+	// Annotations is a map of group annotations.
+	// +nullable
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ClientProfile defines client profile of the realm.
+type ClientProfile struct {
+	// Name is the name of the profile.
+	Name string `json:"name"`
+
+	// Description is the description of the profile.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Executors is a list of executors of the profile.
+	// +nullable
+	// +optional
+	Executors []ClientProfileExecutor `json:"executors,omitempty"`
+}
+
+// ClientProfileExecutor defines executor of the client profile.
+type ClientProfileExecutor struct {
+	// Executor is the provider ID of the executor, e.g. pkce-enforcer, secure-client-authenticator.
+	Executor string `json:"executor"`
+
+	// Configuration is the configuration of the executor, e.g. auto-configure: true.
+	// +nullable
+	// +optional
+	Configuration map[string]apiextensionsv1.JSON `json:"configuration,omitempty"`
+}
+
+// ClientPolicy defines client policy of the realm.
+type ClientPolicy struct {
+	// Name is the name of the policy.
+	Name string `json:"name"`
+
+	// Description is the description of the policy.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Enabled indicates whether the policy is enabled.
+	// +kubebuilder:default=true
+	// +optional
+	Enabled bool `json:"enabled"`
+
+	// Conditions is a list of conditions which select clients the policy is applied to.
+	// +nullable
+	// +optional
+	Conditions []ClientPolicyCondition `json:"conditions,omitempty"`
+
+	// Profiles is a list of client profile names applied to the selected clients.
+	// Both realm and global profiles, e.g. fapi-1-advanced, can be used.
+	// +nullable
+	// +optional
+	Profiles []string `json:"profiles,omitempty"`
+}
+
+// ClientPolicyCondition defines condition of the client policy.
+type ClientPolicyCondition struct {
+	// Condition is the provider ID of the condition, e.g. client-access-type, client-scopes, any-client.
+	Condition string `json:"condition"`
+
+	// Configuration is the configuration of the condition, e.g. type: [confidential].
+	// +nullable
+	// +optional
+	Configuration map[string]apiextensionsv1.JSON `json:"configuration,omitempty"`
+}
+
+// RealmDefaultRoles defines default roles of the realm.
+// Roles can also be made default by isDefault of KeycloakRealmRole, such roles don't need to be listed here.
+type RealmDefaultRoles struct {
+	// RealmRoles is a list of realm roles.
+	// +nullable
+	// +optional
+	RealmRoles []string `json:"realmRoles,omitempty"`
+
+	// ClientRoles is a list of client roles.
+	// +nullable
+	// +optional
+	ClientRoles []ClientRole `json:"clientRoles,omitempty"`
+}
+
+// GetDefaultsReconciliationStrategy returns reconciliation strategy of default groups and roles. Defaults to full.
+func (in *KeycloakRealmSpec) GetDefaultsReconciliationStrategy() string {
+	if in.DefaultsReconciliationStrategy == "" {
+		return ReconciliationStrategyFull
+	}
+
+	return in.DefaultsReconciliationStrategy
+}
+
+// HasSecurityPolicies returns true if brute force protection, OTP or WebAuthn policy is specified.
+func (in *KeycloakRealmSpec) HasSecurityPolicies() bool {
+	return in.BruteForceProtection != nil || in.OTPPolicy != nil || in.WebAuthnPolicy != nil
+}
+
 func (in *KeycloakRealmSpec) SSOEnabled() bool {
 	return in.SsoRealmEnabled != nil && *in.SsoRealmEnabled
 }
@@ -180,6 +931,16 @@ type KeycloakRealmStatus struct {
 
 	// +optional
 	Value string `json:"value,omitempty"`
+
+	// ObservedGeneration is the generation of the resource observed by the last reconciliation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the resource state: Ready, Synced and Degraded.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 func (in *KeycloakRealm) GetFailureCount() int64 {
@@ -194,6 +955,9 @@ func (in *KeycloakRealm) SetFailureCount(count int64) {
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Available",type="boolean",JSONPath=".status.available",description="Is the resource available"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Is the resource ready"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="Is the last reconciliation successful"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",description="Reason of the Ready condition",priority=1
 
 // KeycloakRealm is the Schema for the keycloak realms API.
 type KeycloakRealm struct {
@@ -204,6 +968,34 @@ type KeycloakRealm struct {
 	Status KeycloakRealmStatus `json:"status,omitempty"`
 }
 
+func (in *KeycloakRealm) GetDeletionPolicy() string {
+	if in.Spec.DeletionPolicy == "" {
+		return DeletionPolicyRetain
+	}
+
+	return in.Spec.DeletionPolicy
+}
+
+func (in *KeycloakRealm) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *KeycloakRealm) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+func (in *KeycloakRealm) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+func (in *KeycloakRealm) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func (in *KeycloakRealm) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
+
 // +kubebuilder:object:root=true
 
 // KeycloakRealmList contains a list of KeycloakRealm.
@@ -0,0 +1,160 @@
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+const (
+	RealmBackupTargetSecret    = "Secret"
+	RealmBackupTargetConfigMap = "ConfigMap"
+)
+
+// KeycloakRealmBackupSpec defines the desired state of KeycloakRealmBackup.
+type KeycloakRealmBackupSpec struct {
+	// Realm is name of KeycloakRealm custom resource.
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// ClusterRealmRef is name of ClusterKeycloakRealm custom resource.
+	// If set, it takes precedence over Realm.
+	// +optional
+	ClusterRealmRef string `json:"clusterRealmRef,omitempty"`
+
+	// Schedule is a backup schedule in Cron format.
+	// +kubebuilder:example="0 2 * * *"
+	Schedule string `json:"schedule"`
+
+	// IncludeClients defines whether clients are included into the backup.
+	// +optional
+	IncludeClients bool `json:"includeClients,omitempty"`
+
+	// IncludeGroupsAndRoles defines whether groups and roles are included into the backup.
+	// +optional
+	IncludeGroupsAndRoles bool `json:"includeGroupsAndRoles,omitempty"`
+
+	// RedactClientSecrets removes client secrets from the backup.
+	// Keycloak exports masked secrets, so restoring them would break confidential clients.
+	// +optional
+	RedactClientSecrets bool `json:"redactClientSecrets,omitempty"`
+
+	// Target defines where backups are stored.
+	// Backups are owned by the KeycloakRealmBackup and deleted together with it.
+	// +optional
+	Target RealmBackupTarget `json:"target,omitempty"`
+
+	// Generations is a number of backups to keep. Older backups are deleted.
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Generations int `json:"generations,omitempty"`
+}
+
+// RealmBackupTarget defines where backups are stored.
+type RealmBackupTarget struct {
+	// Kind of the resource which stores gzipped realm representation.
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	// +kubebuilder:default=Secret
+	// +optional
+	Kind string `json:"kind,omitempty"`
+}
+
+// KeycloakRealmBackupStatus defines the observed state of KeycloakRealmBackup.
+type KeycloakRealmBackupStatus struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// +optional
+	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// LastScheduleTime is the time of the last successful backup.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// LastBackup is a name of Secret or ConfigMap with the last backup.
+	// +optional
+	LastBackup string `json:"lastBackup,omitempty"`
+
+	// LastBackupSize is a size of the last gzipped backup in bytes.
+	// +optional
+	LastBackupSize int `json:"lastBackupSize,omitempty"`
+
+	// NextScheduleTime is the time of the next backup.
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// ObservedGeneration is the generation of the resource observed by the last reconciliation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the resource state: Ready, Synced and Degraded.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Is the resource ready"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="Is the last reconciliation successful"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",description="Reason of the Ready condition",priority=1
+
+// KeycloakRealmBackup is the Schema for the keycloak realm backup API.
+// It periodically exports realm into Secrets or ConfigMaps.
+// Backups are kept when KeycloakRealmBackup is deleted.
+type KeycloakRealmBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeycloakRealmBackupSpec   `json:"spec,omitempty"`
+	Status KeycloakRealmBackupStatus `json:"status,omitempty"`
+}
+
+func (in *KeycloakRealmBackup) GetFailureCount() int64 {
+	return in.Status.FailureCount
+}
+
+func (in *KeycloakRealmBackup) SetFailureCount(count int64) {
+	in.Status.FailureCount = count
+}
+
+func (in *KeycloakRealmBackup) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *KeycloakRealmBackup) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+func (in *KeycloakRealmBackup) K8SParentRealmName() (string, error) {
+	return in.Spec.Realm, nil
+}
+
+func (in *KeycloakRealmBackup) K8SParentClusterRealmName() string {
+	return in.Spec.ClusterRealmRef
+}
+
+func (in *KeycloakRealmBackup) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+func (in *KeycloakRealmBackup) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func (in *KeycloakRealmBackup) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
+
+// +kubebuilder:object:root=true
+
+// KeycloakRealmBackupList contains a list of KeycloakRealmBackup.
+type KeycloakRealmBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KeycloakRealmBackup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeycloakRealmBackup{}, &KeycloakRealmBackupList{})
+}
@@ -8,7 +8,13 @@ type KeycloakRealmGroupSpec struct {
 	Name string `json:"name"`
 
 	// Realm is name of KeycloakRealm custom resource.
-	Realm string `json:"realm"`
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// ClusterRealmRef is name of ClusterKeycloakRealm custom resource.
+	// If set, it takes precedence over Realm.
+	// +optional
+	ClusterRealmRef string `json:"clusterRealmRef,omitempty"`
 
 	// Path is a group path.
 	// +optional
@@ -38,6 +44,13 @@ type KeycloakRealmGroupSpec struct {
 	// +nullable
 	// +optional
 	ClientRoles []ClientRole `json:"clientRoles,omitempty"`
+
+	// DeletionPolicy defines whether keycloak group is deleted when the custom resource is deleted.
+	// Delete removes keycloak group, Retain keeps it. Can be overridden by v1.edp.epam.com/deletion-policy annotation.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
 }
 
 // KeycloakRealmGroupStatus defines the observed state of KeycloakRealmGroup.
@@ -51,6 +64,16 @@ type KeycloakRealmGroupStatus struct {
 
 	// +optional
 	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// ObservedGeneration is the generation of the resource observed by the last reconciliation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the resource state: Ready, Synced and Degraded.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 func (in *KeycloakRealmGroup) GetFailureCount() int64 {
@@ -73,9 +96,16 @@ func (in *KeycloakRealmGroup) K8SParentRealmName() (string, error) {
 	return in.Spec.Realm, nil
 }
 
+func (in *KeycloakRealmGroup) K8SParentClusterRealmName() string {
+	return in.Spec.ClusterRealmRef
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Is the resource ready"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="Is the last reconciliation successful"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",description="Reason of the Ready condition",priority=1
 
 // KeycloakRealmGroup is the Schema for the keycloak group API.
 type KeycloakRealmGroup struct {
@@ -86,6 +116,26 @@ type KeycloakRealmGroup struct {
 	Status KeycloakRealmGroupStatus `json:"status,omitempty"`
 }
 
+func (in *KeycloakRealmGroup) GetDeletionPolicy() string {
+	if in.Spec.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+
+	return in.Spec.DeletionPolicy
+}
+
+func (in *KeycloakRealmGroup) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+func (in *KeycloakRealmGroup) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func (in *KeycloakRealmGroup) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
+
 // +kubebuilder:object:root=true
 
 // KeycloakRealmGroupList contains a list of KeycloakRealmGroup.
@@ -5,7 +5,13 @@ import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 // KeycloakRealmIdentityProviderSpec defines the desired state of KeycloakRealmIdentityProvider.
 type KeycloakRealmIdentityProviderSpec struct {
 	// Realm is name of KeycloakRealm custom resource.
-	Realm string `json:"realm"`
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// ClusterRealmRef is name of ClusterKeycloakRealm custom resource.
+	// If set, it takes precedence over Realm.
+	// +optional
+	ClusterRealmRef string `json:"clusterRealmRef,omitempty"`
 
 	// ProviderID is a provider ID of identity provider.
 	ProviderID string `json:"providerId"`
@@ -14,6 +20,7 @@ type KeycloakRealmIdentityProviderSpec struct {
 	Alias string `json:"alias"`
 
 	// Config is a map of identity provider configuration.
+	// Value in the format $secretName:secretKey is taken from the Secret in the same namespace.
 	Config map[string]string `json:"config"`
 
 	// Enabled is a flag to enable/disable identity provider.
@@ -51,6 +58,13 @@ type KeycloakRealmIdentityProviderSpec struct {
 	// +nullable
 	// +optional
 	Mappers []IdentityProviderMapper `json:"mappers,omitempty"`
+
+	// DeletionPolicy defines whether keycloak identity provider is deleted when the custom resource is deleted.
+	// Delete removes keycloak identity provider, Retain keeps it. Can be overridden by v1.edp.epam.com/deletion-policy annotation.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
 }
 
 type IdentityProviderMapper struct {
@@ -79,11 +93,24 @@ type KeycloakRealmIdentityProviderStatus struct {
 
 	// +optional
 	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// ObservedGeneration is the generation of the resource observed by the last reconciliation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the resource state: Ready, Synced and Degraded.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Is the resource ready"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="Is the last reconciliation successful"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",description="Reason of the Ready condition",priority=1
 
 // KeycloakRealmIdentityProvider is the Schema for the keycloak realm identity provider API.
 type KeycloakRealmIdentityProvider struct {
@@ -114,6 +141,30 @@ func (in *KeycloakRealmIdentityProvider) K8SParentRealmName() (string, error) {
 	return in.Spec.Realm, nil
 }
 
+func (in *KeycloakRealmIdentityProvider) K8SParentClusterRealmName() string {
+	return in.Spec.ClusterRealmRef
+}
+
+func (in *KeycloakRealmIdentityProvider) GetDeletionPolicy() string {
+	if in.Spec.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+
+	return in.Spec.DeletionPolicy
+}
+
+func (in *KeycloakRealmIdentityProvider) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+func (in *KeycloakRealmIdentityProvider) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func (in *KeycloakRealmIdentityProvider) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
+
 // +kubebuilder:object:root=true
 
 // KeycloakRealmIdentityProviderList contains a list of KeycloakRealmIdentityProvider.
@@ -0,0 +1,158 @@
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// KeycloakRealmImportSpec defines the desired state of KeycloakRealmImport.
+type KeycloakRealmImportSpec struct {
+	// Realm is name of KeycloakRealm custom resource.
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// ClusterRealmRef is name of ClusterKeycloakRealm custom resource.
+	// If set, it takes precedence over Realm.
+	// +optional
+	ClusterRealmRef string `json:"clusterRealmRef,omitempty"`
+
+	// Source is a reference to the realm representation in JSON format, e.g. realm export.
+	Source RealmImportSource `json:"source"`
+
+	// IfResourceExists defines what to do with resources which already exist in the realm.
+	// FAIL aborts the whole import, SKIP keeps existing resources, OVERWRITE replaces them.
+	// +kubebuilder:validation:Enum=FAIL;SKIP;OVERWRITE
+	// +kubebuilder:default=FAIL
+	// +optional
+	IfResourceExists string `json:"ifResourceExists,omitempty"`
+}
+
+// RealmImportSource is a reference to the realm representation. Only one of ConfigMapKeyRef or SecretKeyRef should be specified.
+type RealmImportSource struct {
+	// ConfigMapKeyRef selects a key of ConfigMap which contains realm representation.
+	// +optional
+	ConfigMapKeyRef *KeySelector `json:"configMapKeyRef,omitempty"`
+
+	// SecretKeyRef selects a key of Secret which contains realm representation.
+	// +optional
+	SecretKeyRef *KeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// KeycloakRealmImportStatus defines the observed state of KeycloakRealmImport.
+type KeycloakRealmImportStatus struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// +optional
+	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// ImportedHash is a hash of the imported representation and import policy.
+	// Import is not repeated until the representation or the policy is changed.
+	// +optional
+	ImportedHash string `json:"importedHash,omitempty"`
+
+	// Added is a number of resources added by the last import.
+	// +optional
+	Added int `json:"added,omitempty"`
+
+	// Skipped is a number of resources skipped by the last import.
+	// +optional
+	Skipped int `json:"skipped,omitempty"`
+
+	// Overwritten is a number of resources overwritten by the last import.
+	// +optional
+	Overwritten int `json:"overwritten,omitempty"`
+
+	// Resources contains outcome of the last import per resource type.
+	// +optional
+	Resources []RealmImportResourceStatus `json:"resources,omitempty"`
+
+	// ObservedGeneration is the generation of the resource observed by the last reconciliation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the resource state: Ready, Synced and Degraded.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// RealmImportResourceStatus is an outcome of the import for a resource type, e.g. CLIENT or REALM_ROLE.
+type RealmImportResourceStatus struct {
+	// ResourceType is a type of imported resources.
+	ResourceType string `json:"resourceType"`
+
+	// +optional
+	Added int `json:"added,omitempty"`
+
+	// +optional
+	Skipped int `json:"skipped,omitempty"`
+
+	// +optional
+	Overwritten int `json:"overwritten,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Is the resource ready"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="Is the last reconciliation successful"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",description="Reason of the Ready condition",priority=1
+
+// KeycloakRealmImport is the Schema for the keycloak realm import API.
+// It imports resources from realm representation using partial import.
+type KeycloakRealmImport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeycloakRealmImportSpec   `json:"spec,omitempty"`
+	Status KeycloakRealmImportStatus `json:"status,omitempty"`
+}
+
+func (in *KeycloakRealmImport) GetFailureCount() int64 {
+	return in.Status.FailureCount
+}
+
+func (in *KeycloakRealmImport) SetFailureCount(count int64) {
+	in.Status.FailureCount = count
+}
+
+func (in *KeycloakRealmImport) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *KeycloakRealmImport) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+func (in *KeycloakRealmImport) K8SParentRealmName() (string, error) {
+	return in.Spec.Realm, nil
+}
+
+func (in *KeycloakRealmImport) K8SParentClusterRealmName() string {
+	return in.Spec.ClusterRealmRef
+}
+
+func (in *KeycloakRealmImport) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+func (in *KeycloakRealmImport) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func (in *KeycloakRealmImport) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
+
+// +kubebuilder:object:root=true
+
+// KeycloakRealmImportList contains a list of KeycloakRealmImport.
+type KeycloakRealmImportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KeycloakRealmImport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeycloakRealmImport{}, &KeycloakRealmImportList{})
+}
@@ -0,0 +1,164 @@
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// KeycloakRealmKeySpec defines the desired state of KeycloakRealmKey.
+type KeycloakRealmKeySpec struct {
+	// Name of keycloak key provider component.
+	Name string `json:"name"`
+
+	// Realm is name of KeycloakRealm custom resource.
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// ClusterRealmRef is name of ClusterKeycloakRealm custom resource.
+	// If set, it takes precedence over Realm.
+	// +optional
+	ClusterRealmRef string `json:"clusterRealmRef,omitempty"`
+
+	// SecretName is a name of kubernetes.io/tls Secret in the same namespace.
+	// The Secret must contain RSA private key and certificate in tls.key and tls.crt.
+	// +kubebuilder:example=realm-signing-tls
+	SecretName string `json:"secretName"`
+
+	// Priority of the key. Keycloak uses active key with the highest priority for signing.
+	// +kubebuilder:default=100
+	// +optional
+	Priority int64 `json:"priority,omitempty"`
+
+	// Algorithm intended to be used with the key.
+	// +kubebuilder:validation:Enum=RS256;RS384;RS512;PS256;PS384;PS512
+	// +kubebuilder:default=RS256
+	// +optional
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// Active defines whether the key can be used for signing.
+	// +kubebuilder:default=true
+	// +optional
+	Active bool `json:"active"`
+
+	// Enabled defines whether the key is enabled.
+	// +kubebuilder:default=true
+	// +optional
+	Enabled bool `json:"enabled"`
+
+	// OverlapPeriod is a period during which the previous key is kept as passive after the certificate is renewed.
+	// It allows verifying tokens signed with the previous key. If not set, the previous key is replaced immediately.
+	// +kubebuilder:example="24h"
+	// +optional
+	OverlapPeriod *metav1.Duration `json:"overlapPeriod,omitempty"`
+
+	// DeletionPolicy defines whether keycloak key provider component is deleted when the custom resource is deleted.
+	// Delete removes keycloak key provider component, Retain keeps it. Can be overridden by v1.edp.epam.com/deletion-policy annotation.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+// KeycloakRealmKeyStatus defines the observed state of KeycloakRealmKey.
+type KeycloakRealmKeyStatus struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// +optional
+	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// CertificateFingerprint is SHA-256 fingerprint of the certificate used by the active key.
+	// +optional
+	CertificateFingerprint string `json:"certificateFingerprint,omitempty"`
+
+	// PreviousKeyExpiration is the time when the previous passive key is removed.
+	// +optional
+	PreviousKeyExpiration *metav1.Time `json:"previousKeyExpiration,omitempty"`
+
+	// ObservedGeneration is the generation of the resource observed by the last reconciliation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the resource state: Ready, Synced and Degraded.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Is the resource ready"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="Is the last reconciliation successful"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",description="Reason of the Ready condition",priority=1
+
+// KeycloakRealmKey is the Schema for the keycloak realm key API.
+// It manages rsa key provider component built from kubernetes.io/tls Secret.
+type KeycloakRealmKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeycloakRealmKeySpec   `json:"spec,omitempty"`
+	Status KeycloakRealmKeyStatus `json:"status,omitempty"`
+}
+
+func (in *KeycloakRealmKey) GetFailureCount() int64 {
+	return in.Status.FailureCount
+}
+
+func (in *KeycloakRealmKey) SetFailureCount(count int64) {
+	in.Status.FailureCount = count
+}
+
+func (in *KeycloakRealmKey) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *KeycloakRealmKey) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+func (in *KeycloakRealmKey) K8SParentRealmName() (string, error) {
+	return in.Spec.Realm, nil
+}
+
+func (in *KeycloakRealmKey) K8SParentClusterRealmName() string {
+	return in.Spec.ClusterRealmRef
+}
+
+// PreviousKeyName returns name of the passive component which keeps the previous key during the overlap period.
+func (in *KeycloakRealmKey) PreviousKeyName() string {
+	return in.Spec.Name + "-previous"
+}
+
+func (in *KeycloakRealmKey) GetDeletionPolicy() string {
+	if in.Spec.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+
+	return in.Spec.DeletionPolicy
+}
+
+func (in *KeycloakRealmKey) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+func (in *KeycloakRealmKey) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func (in *KeycloakRealmKey) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
+
+// +kubebuilder:object:root=true
+
+// KeycloakRealmKeyList contains a list of KeycloakRealmKey.
+type KeycloakRealmKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KeycloakRealmKey `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeycloakRealmKey{}, &KeycloakRealmKeyList{})
+}
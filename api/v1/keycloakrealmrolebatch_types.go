@@ -9,10 +9,23 @@ import (
 // KeycloakRealmRoleBatchSpec defines the desired state of KeycloakRealmRoleBatch.
 type KeycloakRealmRoleBatchSpec struct {
 	// Realm is name of KeycloakRealm custom resource.
-	Realm string `json:"realm"`
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// ClusterRealmRef is name of ClusterKeycloakRealm custom resource.
+	// If set, it takes precedence over Realm.
+	// +optional
+	ClusterRealmRef string `json:"clusterRealmRef,omitempty"`
 
 	// Roles is a list of roles to be created.
 	Roles []BatchRole `json:"roles"`
+
+	// DeletionPolicy defines whether keycloak roles of the batch are deleted when the custom resource is deleted.
+	// Delete removes keycloak roles of the batch, Retain keeps them. Can be overridden by v1.edp.epam.com/deletion-policy annotation.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
 }
 
 type BatchRole struct {
@@ -49,11 +62,24 @@ type KeycloakRealmRoleBatchStatus struct {
 
 	// +optional
 	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// ObservedGeneration is the generation of the resource observed by the last reconciliation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the resource state: Ready, Synced and Degraded.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Is the resource ready"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="Is the last reconciliation successful"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",description="Reason of the Ready condition",priority=1
 
 // KeycloakRealmRoleBatch is the Schema for the keycloak roles API.
 type KeycloakRealmRoleBatch struct {
@@ -84,10 +110,34 @@ func (in *KeycloakRealmRoleBatch) K8SParentRealmName() (string, error) {
 	return in.Spec.Realm, nil
 }
 
+func (in *KeycloakRealmRoleBatch) K8SParentClusterRealmName() string {
+	return in.Spec.ClusterRealmRef
+}
+
 func (in *KeycloakRealmRoleBatch) FormattedRoleName(baseRoleName string) string {
 	return fmt.Sprintf("%s-%s", in.Name, baseRoleName)
 }
 
+func (in *KeycloakRealmRoleBatch) GetDeletionPolicy() string {
+	if in.Spec.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+
+	return in.Spec.DeletionPolicy
+}
+
+func (in *KeycloakRealmRoleBatch) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+func (in *KeycloakRealmRoleBatch) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func (in *KeycloakRealmRoleBatch) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
+
 // +kubebuilder:object:root=true
 
 // KeycloakRealmRoleBatchList contains a list of KeycloakRealmRoleBatch.
@@ -5,7 +5,13 @@ import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 // KeycloakRealmUserSpec defines the desired state of KeycloakRealmUser.
 type KeycloakRealmUserSpec struct {
 	// Realm is name of KeycloakRealm custom resource.
-	Realm string `json:"realm"`
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// ClusterRealmRef is name of ClusterKeycloakRealm custom resource.
+	// If set, it takes precedence over Realm.
+	// +optional
+	ClusterRealmRef string `json:"clusterRealmRef,omitempty"`
 
 	// Username is a username in keycloak.
 	Username string `json:"username"`
@@ -60,7 +66,9 @@ type KeycloakRealmUserSpec struct {
 	// +optional
 	Password string `json:"password,omitempty"`
 
-	// KeepResource is a flag if resource should be kept after deletion. If set to true, user will not be deleted from keycloak.
+	// KeepResource is a flag if the custom resource should be kept after the user is synced.
+	// If set to false, the custom resource deletes itself after the sync and the user is kept in Keycloak.
+	// If set to true, the custom resource is kept and the user is handled according to DeletionPolicy on its deletion.
 	// +optional
 	KeepResource bool `json:"keepResource,omitempty"`
 
@@ -68,6 +76,14 @@ type KeycloakRealmUserSpec struct {
 	// +nullable
 	// +optional
 	PasswordSecret PasswordSecret `json:"passwordSecret,omitempty"`
+
+	// DeletionPolicy defines whether keycloak user is deleted when the custom resource is deleted.
+	// Delete removes keycloak user, Retain keeps it. Can be overridden by v1.edp.epam.com/deletion-policy annotation.
+	// It has effect only if KeepResource is true, otherwise the user is always kept in Keycloak.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
 }
 
 // PasswordSecret defines struct which contains reference to secret name and key.
@@ -86,11 +102,24 @@ type KeycloakRealmUserStatus struct {
 
 	// +optional
 	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// ObservedGeneration is the generation of the resource observed by the last reconciliation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the resource state: Ready, Synced and Degraded.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Is the resource ready"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="Is the last reconciliation successful"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",description="Reason of the Ready condition",priority=1
 
 // KeycloakRealmUser is the Schema for the keycloak user API.
 type KeycloakRealmUser struct {
@@ -113,6 +142,10 @@ func (in *KeycloakRealmUser) K8SParentRealmName() (string, error) {
 	return in.Spec.Realm, nil
 }
 
+func (in *KeycloakRealmUser) K8SParentClusterRealmName() string {
+	return in.Spec.ClusterRealmRef
+}
+
 func (in *KeycloakRealmUser) GetFailureCount() int64 {
 	return in.Status.FailureCount
 }
@@ -129,6 +162,26 @@ func (in *KeycloakRealmUser) SetStatus(value string) {
 	in.Status.Value = value
 }
 
+func (in *KeycloakRealmUser) GetDeletionPolicy() string {
+	if in.Spec.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+
+	return in.Spec.DeletionPolicy
+}
+
+func (in *KeycloakRealmUser) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+func (in *KeycloakRealmUser) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func (in *KeycloakRealmUser) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
+
 // +kubebuilder:object:root=true
 
 // KeycloakRealmUserList contains a list of KeycloakRealmUser.
@@ -6,6 +6,8 @@
 package v1
 
 import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -86,6 +88,179 @@ func (in *BatchRole) DeepCopy() *BatchRole {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BruteForceProtection) DeepCopyInto(out *BruteForceProtection) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PermanentLockout != nil {
+		in, out := &in.PermanentLockout, &out.PermanentLockout
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxLoginFailures != nil {
+		in, out := &in.MaxLoginFailures, &out.MaxLoginFailures
+		*out = new(int)
+		**out = **in
+	}
+	if in.WaitIncrementSeconds != nil {
+		in, out := &in.WaitIncrementSeconds, &out.WaitIncrementSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxFailureWaitSeconds != nil {
+		in, out := &in.MaxFailureWaitSeconds, &out.MaxFailureWaitSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxDeltaTimeSeconds != nil {
+		in, out := &in.MaxDeltaTimeSeconds, &out.MaxDeltaTimeSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.QuickLoginCheckMilliSeconds != nil {
+		in, out := &in.QuickLoginCheckMilliSeconds, &out.QuickLoginCheckMilliSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MinimumQuickLoginWaitSeconds != nil {
+		in, out := &in.MinimumQuickLoginWaitSeconds, &out.MinimumQuickLoginWaitSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BruteForceProtection.
+func (in *BruteForceProtection) DeepCopy() *BruteForceProtection {
+	if in == nil {
+		return nil
+	}
+	out := new(BruteForceProtection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CACertSource) DeepCopyInto(out *CACertSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(KeySelector)
+		**out = **in
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(KeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CACertSource.
+func (in *CACertSource) DeepCopy() *CACertSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CACertSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientPolicy) DeepCopyInto(out *ClientPolicy) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClientPolicyCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientPolicy.
+func (in *ClientPolicy) DeepCopy() *ClientPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientPolicyCondition) DeepCopyInto(out *ClientPolicyCondition) {
+	*out = *in
+	if in.Configuration != nil {
+		in, out := &in.Configuration, &out.Configuration
+		*out = make(map[string]apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientPolicyCondition.
+func (in *ClientPolicyCondition) DeepCopy() *ClientPolicyCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientPolicyCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientProfile) DeepCopyInto(out *ClientProfile) {
+	*out = *in
+	if in.Executors != nil {
+		in, out := &in.Executors, &out.Executors
+		*out = make([]ClientProfileExecutor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientProfile.
+func (in *ClientProfile) DeepCopy() *ClientProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientProfileExecutor) DeepCopyInto(out *ClientProfileExecutor) {
+	*out = *in
+	if in.Configuration != nil {
+		in, out := &in.Configuration, &out.Configuration
+		*out = make(map[string]apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientProfileExecutor.
+func (in *ClientProfileExecutor) DeepCopy() *ClientProfileExecutor {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientProfileExecutor)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClientRole) DeepCopyInto(out *ClientRole) {
 	*out = *in
@@ -143,13 +318,28 @@ func (in *IdentityProviderMapper) DeepCopy() *IdentityProviderMapper {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeySelector) DeepCopyInto(out *KeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeySelector.
+func (in *KeySelector) DeepCopy() *KeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(KeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Keycloak) DeepCopyInto(out *Keycloak) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Keycloak.
@@ -176,7 +366,7 @@ func (in *KeycloakAuthFlow) DeepCopyInto(out *KeycloakAuthFlow) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAuthFlow.
@@ -254,6 +444,13 @@ func (in *KeycloakAuthFlowSpec) DeepCopy() *KeycloakAuthFlowSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakAuthFlowStatus) DeepCopyInto(out *KeycloakAuthFlowStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAuthFlowStatus.
@@ -272,7 +469,7 @@ func (in *KeycloakClient) DeepCopyInto(out *KeycloakClient) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClient.
@@ -331,7 +528,7 @@ func (in *KeycloakClientScope) DeepCopyInto(out *KeycloakClientScope) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientScope.
@@ -416,6 +613,13 @@ func (in *KeycloakClientScopeSpec) DeepCopy() *KeycloakClientScopeSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakClientScopeStatus) DeepCopyInto(out *KeycloakClientScopeStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientScopeStatus.
@@ -493,6 +697,13 @@ func (in *KeycloakClientSpec) DeepCopy() *KeycloakClientSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakClientStatus) DeepCopyInto(out *KeycloakClientStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientStatus.
@@ -538,6 +749,13 @@ func (in *KeycloakComponentSpec) DeepCopy() *KeycloakComponentSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakComponentStatus) DeepCopyInto(out *KeycloakComponentStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakComponentStatus.
@@ -582,13 +800,28 @@ func (in *KeycloakList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRateLimit) DeepCopyInto(out *KeycloakRateLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRateLimit.
+func (in *KeycloakRateLimit) DeepCopy() *KeycloakRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakRealm) DeepCopyInto(out *KeycloakRealm) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealm.
@@ -609,13 +842,118 @@ func (in *KeycloakRealm) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmBackup) DeepCopyInto(out *KeycloakRealmBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmBackup.
+func (in *KeycloakRealmBackup) DeepCopy() *KeycloakRealmBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmBackupList) DeepCopyInto(out *KeycloakRealmBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealmBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmBackupList.
+func (in *KeycloakRealmBackupList) DeepCopy() *KeycloakRealmBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmBackupSpec) DeepCopyInto(out *KeycloakRealmBackupSpec) {
+	*out = *in
+	out.Target = in.Target
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmBackupSpec.
+func (in *KeycloakRealmBackupSpec) DeepCopy() *KeycloakRealmBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmBackupStatus) DeepCopyInto(out *KeycloakRealmBackupStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmBackupStatus.
+func (in *KeycloakRealmBackupStatus) DeepCopy() *KeycloakRealmBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakRealmComponent) DeepCopyInto(out *KeycloakRealmComponent) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmComponent.
@@ -674,7 +1012,7 @@ func (in *KeycloakRealmGroup) DeepCopyInto(out *KeycloakRealmGroup) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmGroup.
@@ -784,6 +1122,13 @@ func (in *KeycloakRealmGroupSpec) DeepCopy() *KeycloakRealmGroupSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakRealmGroupStatus) DeepCopyInto(out *KeycloakRealmGroupStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmGroupStatus.
@@ -802,7 +1147,7 @@ func (in *KeycloakRealmIdentityProvider) DeepCopyInto(out *KeycloakRealmIdentity
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmIdentityProvider.
@@ -887,6 +1232,13 @@ func (in *KeycloakRealmIdentityProviderSpec) DeepCopy() *KeycloakRealmIdentityPr
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakRealmIdentityProviderStatus) DeepCopyInto(out *KeycloakRealmIdentityProviderStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmIdentityProviderStatus.
@@ -900,31 +1252,26 @@ func (in *KeycloakRealmIdentityProviderStatus) DeepCopy() *KeycloakRealmIdentity
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmList) DeepCopyInto(out *KeycloakRealmList) {
+func (in *KeycloakRealmImport) DeepCopyInto(out *KeycloakRealmImport) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]KeycloakRealm, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmList.
-func (in *KeycloakRealmList) DeepCopy() *KeycloakRealmList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmImport.
+func (in *KeycloakRealmImport) DeepCopy() *KeycloakRealmImport {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmList)
+	out := new(KeycloakRealmImport)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmList) DeepCopyObject() runtime.Object {
+func (in *KeycloakRealmImport) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -932,20 +1279,232 @@ func (in *KeycloakRealmList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmRole) DeepCopyInto(out *KeycloakRealmRole) {
+func (in *KeycloakRealmImportList) DeepCopyInto(out *KeycloakRealmImportList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealmImport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRole.
-func (in *KeycloakRealmRole) DeepCopy() *KeycloakRealmRole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmImportList.
+func (in *KeycloakRealmImportList) DeepCopy() *KeycloakRealmImportList {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmRole)
+	out := new(KeycloakRealmImportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmImportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmImportSpec) DeepCopyInto(out *KeycloakRealmImportSpec) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmImportSpec.
+func (in *KeycloakRealmImportSpec) DeepCopy() *KeycloakRealmImportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmImportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmImportStatus) DeepCopyInto(out *KeycloakRealmImportStatus) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]RealmImportResourceStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmImportStatus.
+func (in *KeycloakRealmImportStatus) DeepCopy() *KeycloakRealmImportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmImportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmKey) DeepCopyInto(out *KeycloakRealmKey) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmKey.
+func (in *KeycloakRealmKey) DeepCopy() *KeycloakRealmKey {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmKey) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmKeyList) DeepCopyInto(out *KeycloakRealmKeyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealmKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmKeyList.
+func (in *KeycloakRealmKeyList) DeepCopy() *KeycloakRealmKeyList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmKeyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmKeyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmKeySpec) DeepCopyInto(out *KeycloakRealmKeySpec) {
+	*out = *in
+	if in.OverlapPeriod != nil {
+		in, out := &in.OverlapPeriod, &out.OverlapPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmKeySpec.
+func (in *KeycloakRealmKeySpec) DeepCopy() *KeycloakRealmKeySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmKeySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmKeyStatus) DeepCopyInto(out *KeycloakRealmKeyStatus) {
+	*out = *in
+	if in.PreviousKeyExpiration != nil {
+		in, out := &in.PreviousKeyExpiration, &out.PreviousKeyExpiration
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmKeyStatus.
+func (in *KeycloakRealmKeyStatus) DeepCopy() *KeycloakRealmKeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmKeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmList) DeepCopyInto(out *KeycloakRealmList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmList.
+func (in *KeycloakRealmList) DeepCopy() *KeycloakRealmList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmRole) DeepCopyInto(out *KeycloakRealmRole) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRole.
+func (in *KeycloakRealmRole) DeepCopy() *KeycloakRealmRole {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmRole)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -964,7 +1523,7 @@ func (in *KeycloakRealmRoleBatch) DeepCopyInto(out *KeycloakRealmRoleBatch) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleBatch.
@@ -1042,6 +1601,13 @@ func (in *KeycloakRealmRoleBatchSpec) DeepCopy() *KeycloakRealmRoleBatchSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakRealmRoleBatchStatus) DeepCopyInto(out *KeycloakRealmRoleBatchStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleBatchStatus.
@@ -1124,6 +1690,13 @@ func (in *KeycloakRealmRoleSpec) DeepCopy() *KeycloakRealmRoleSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakRealmRoleStatus) DeepCopyInto(out *KeycloakRealmRoleStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleStatus.
@@ -1203,6 +1776,82 @@ func (in *KeycloakRealmSpec) DeepCopyInto(out *KeycloakRealmSpec) {
 		*out = make([]PasswordPolicy, len(*in))
 		copy(*out, *in)
 	}
+	if in.TokenSettings != nil {
+		in, out := &in.TokenSettings, &out.TokenSettings
+		*out = new(TokenSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sessions != nil {
+		in, out := &in.Sessions, &out.Sessions
+		*out = new(RealmSessions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Login != nil {
+		in, out := &in.Login, &out.Login
+		*out = new(RealmLogin)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SMTP != nil {
+		in, out := &in.SMTP, &out.SMTP
+		*out = new(RealmSMTP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BruteForceProtection != nil {
+		in, out := &in.BruteForceProtection, &out.BruteForceProtection
+		*out = new(BruteForceProtection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OTPPolicy != nil {
+		in, out := &in.OTPPolicy, &out.OTPPolicy
+		*out = new(OTPPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WebAuthnPolicy != nil {
+		in, out := &in.WebAuthnPolicy, &out.WebAuthnPolicy
+		*out = new(WebAuthnPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Localization != nil {
+		in, out := &in.Localization, &out.Localization
+		*out = new(RealmLocalization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequiredActions != nil {
+		in, out := &in.RequiredActions, &out.RequiredActions
+		*out = make([]RequiredAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UserProfile != nil {
+		in, out := &in.UserProfile, &out.UserProfile
+		*out = new(UserProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientProfiles != nil {
+		in, out := &in.ClientProfiles, &out.ClientProfiles
+		*out = make([]ClientProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClientPolicies != nil {
+		in, out := &in.ClientPolicies, &out.ClientPolicies
+		*out = make([]ClientPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultGroups != nil {
+		in, out := &in.DefaultGroups, &out.DefaultGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultRoles != nil {
+		in, out := &in.DefaultRoles, &out.DefaultRoles
+		*out = new(RealmDefaultRoles)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmSpec.
@@ -1218,6 +1867,13 @@ func (in *KeycloakRealmSpec) DeepCopy() *KeycloakRealmSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakRealmStatus) DeepCopyInto(out *KeycloakRealmStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmStatus.
@@ -1236,7 +1892,7 @@ func (in *KeycloakRealmUser) DeepCopyInto(out *KeycloakRealmUser) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUser.
@@ -1330,6 +1986,13 @@ func (in *KeycloakRealmUserSpec) DeepCopy() *KeycloakRealmUserSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakRealmUserStatus) DeepCopyInto(out *KeycloakRealmUserStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserStatus.
@@ -1342,9 +2005,54 @@ func (in *KeycloakRealmUserStatus) DeepCopy() *KeycloakRealmUserStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRetry) DeepCopyInto(out *KeycloakRetry) {
+	*out = *in
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int)
+		**out = **in
+	}
+	if in.WaitTime != nil {
+		in, out := &in.WaitTime, &out.WaitTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxWaitTime != nil {
+		in, out := &in.MaxWaitTime, &out.MaxWaitTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRetry.
+func (in *KeycloakRetry) DeepCopy() *KeycloakRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakSpec) DeepCopyInto(out *KeycloakSpec) {
 	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(KeycloakTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(KeycloakRetry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(KeycloakRateLimit)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakSpec.
@@ -1360,6 +2068,22 @@ func (in *KeycloakSpec) DeepCopy() *KeycloakSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakStatus) DeepCopyInto(out *KeycloakStatus) {
 	*out = *in
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastConnected != nil {
+		in, out := &in.LastConnected, &out.LastConnected
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakStatus.
@@ -1373,40 +2097,120 @@ func (in *KeycloakStatus) DeepCopy() *KeycloakStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PasswordPolicy) DeepCopyInto(out *PasswordPolicy) {
+func (in *KeycloakTLS) DeepCopyInto(out *KeycloakTLS) {
 	*out = *in
+	if in.CACert != nil {
+		in, out := &in.CACert, &out.CACert
+		*out = new(CACertSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordPolicy.
-func (in *PasswordPolicy) DeepCopy() *PasswordPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakTLS.
+func (in *KeycloakTLS) DeepCopy() *KeycloakTLS {
 	if in == nil {
 		return nil
 	}
-	out := new(PasswordPolicy)
+	out := new(KeycloakTLS)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PasswordSecret) DeepCopyInto(out *PasswordSecret) {
+func (in *LocalizationTexts) DeepCopyInto(out *LocalizationTexts) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordSecret.
-func (in *PasswordSecret) DeepCopy() *PasswordSecret {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalizationTexts.
+func (in *LocalizationTexts) DeepCopy() *LocalizationTexts {
 	if in == nil {
 		return nil
 	}
-	out := new(PasswordSecret)
+	out := new(LocalizationTexts)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProtocolMapper) DeepCopyInto(out *ProtocolMapper) {
+func (in *OTPPolicy) DeepCopyInto(out *OTPPolicy) {
 	*out = *in
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+	if in.Algorithm != nil {
+		in, out := &in.Algorithm, &out.Algorithm
+		*out = new(string)
+		**out = **in
+	}
+	if in.Digits != nil {
+		in, out := &in.Digits, &out.Digits
+		*out = new(int)
+		**out = **in
+	}
+	if in.Period != nil {
+		in, out := &in.Period, &out.Period
+		*out = new(int)
+		**out = **in
+	}
+	if in.LookAheadWindow != nil {
+		in, out := &in.LookAheadWindow, &out.LookAheadWindow
+		*out = new(int)
+		**out = **in
+	}
+	if in.InitialCounter != nil {
+		in, out := &in.InitialCounter, &out.InitialCounter
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OTPPolicy.
+func (in *OTPPolicy) DeepCopy() *OTPPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OTPPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordPolicy) DeepCopyInto(out *PasswordPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordPolicy.
+func (in *PasswordPolicy) DeepCopy() *PasswordPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordSecret) DeepCopyInto(out *PasswordSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordSecret.
+func (in *PasswordSecret) DeepCopy() *PasswordSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProtocolMapper) DeepCopyInto(out *ProtocolMapper) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
@@ -1424,6 +2228,48 @@ func (in *ProtocolMapper) DeepCopy() *ProtocolMapper {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmBackupTarget) DeepCopyInto(out *RealmBackupTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmBackupTarget.
+func (in *RealmBackupTarget) DeepCopy() *RealmBackupTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmBackupTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmDefaultRoles) DeepCopyInto(out *RealmDefaultRoles) {
+	*out = *in
+	if in.RealmRoles != nil {
+		in, out := &in.RealmRoles, &out.RealmRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClientRoles != nil {
+		in, out := &in.ClientRoles, &out.ClientRoles
+		*out = make([]ClientRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmDefaultRoles.
+func (in *RealmDefaultRoles) DeepCopy() *RealmDefaultRoles {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmDefaultRoles)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RealmEventConfig) DeepCopyInto(out *RealmEventConfig) {
 	*out = *in
@@ -1449,6 +2295,126 @@ func (in *RealmEventConfig) DeepCopy() *RealmEventConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmImportResourceStatus) DeepCopyInto(out *RealmImportResourceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmImportResourceStatus.
+func (in *RealmImportResourceStatus) DeepCopy() *RealmImportResourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmImportResourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmImportSource) DeepCopyInto(out *RealmImportSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(KeySelector)
+		**out = **in
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(KeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmImportSource.
+func (in *RealmImportSource) DeepCopy() *RealmImportSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmImportSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmLocalization) DeepCopyInto(out *RealmLocalization) {
+	*out = *in
+	if in.SupportedLocales != nil {
+		in, out := &in.SupportedLocales, &out.SupportedLocales
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Texts != nil {
+		in, out := &in.Texts, &out.Texts
+		*out = make([]LocalizationTexts, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmLocalization.
+func (in *RealmLocalization) DeepCopy() *RealmLocalization {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmLocalization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmLogin) DeepCopyInto(out *RealmLogin) {
+	*out = *in
+	if in.RegistrationAllowed != nil {
+		in, out := &in.RegistrationAllowed, &out.RegistrationAllowed
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RegistrationEmailAsUsername != nil {
+		in, out := &in.RegistrationEmailAsUsername, &out.RegistrationEmailAsUsername
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RememberMe != nil {
+		in, out := &in.RememberMe, &out.RememberMe
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VerifyEmail != nil {
+		in, out := &in.VerifyEmail, &out.VerifyEmail
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LoginWithEmailAllowed != nil {
+		in, out := &in.LoginWithEmailAllowed, &out.LoginWithEmailAllowed
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DuplicateEmailsAllowed != nil {
+		in, out := &in.DuplicateEmailsAllowed, &out.DuplicateEmailsAllowed
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ResetPasswordAllowed != nil {
+		in, out := &in.ResetPasswordAllowed, &out.ResetPasswordAllowed
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EditUsernameAllowed != nil {
+		in, out := &in.EditUsernameAllowed, &out.EditUsernameAllowed
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmLogin.
+func (in *RealmLogin) DeepCopy() *RealmLogin {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmLogin)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RealmRole) DeepCopyInto(out *RealmRole) {
 	*out = *in
@@ -1464,6 +2430,96 @@ func (in *RealmRole) DeepCopy() *RealmRole {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmSMTP) DeepCopyInto(out *RealmSMTP) {
+	*out = *in
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
+		*out = new(PasswordSecret)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmSMTP.
+func (in *RealmSMTP) DeepCopy() *RealmSMTP {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmSMTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmSessions) DeepCopyInto(out *RealmSessions) {
+	*out = *in
+	if in.SSOSessionIdleTimeout != nil {
+		in, out := &in.SSOSessionIdleTimeout, &out.SSOSessionIdleTimeout
+		*out = new(int)
+		**out = **in
+	}
+	if in.SSOSessionMaxLifespan != nil {
+		in, out := &in.SSOSessionMaxLifespan, &out.SSOSessionMaxLifespan
+		*out = new(int)
+		**out = **in
+	}
+	if in.SSOSessionIdleTimeoutRememberMe != nil {
+		in, out := &in.SSOSessionIdleTimeoutRememberMe, &out.SSOSessionIdleTimeoutRememberMe
+		*out = new(int)
+		**out = **in
+	}
+	if in.SSOSessionMaxLifespanRememberMe != nil {
+		in, out := &in.SSOSessionMaxLifespanRememberMe, &out.SSOSessionMaxLifespanRememberMe
+		*out = new(int)
+		**out = **in
+	}
+	if in.OfflineSessionIdleTimeout != nil {
+		in, out := &in.OfflineSessionIdleTimeout, &out.OfflineSessionIdleTimeout
+		*out = new(int)
+		**out = **in
+	}
+	if in.OfflineSessionMaxLifespanEnabled != nil {
+		in, out := &in.OfflineSessionMaxLifespanEnabled, &out.OfflineSessionMaxLifespanEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OfflineSessionMaxLifespan != nil {
+		in, out := &in.OfflineSessionMaxLifespan, &out.OfflineSessionMaxLifespan
+		*out = new(int)
+		**out = **in
+	}
+	if in.ClientSessionIdleTimeout != nil {
+		in, out := &in.ClientSessionIdleTimeout, &out.ClientSessionIdleTimeout
+		*out = new(int)
+		**out = **in
+	}
+	if in.ClientSessionMaxLifespan != nil {
+		in, out := &in.ClientSessionMaxLifespan, &out.ClientSessionMaxLifespan
+		*out = new(int)
+		**out = **in
+	}
+	if in.ClientOfflineSessionIdleTimeout != nil {
+		in, out := &in.ClientOfflineSessionIdleTimeout, &out.ClientOfflineSessionIdleTimeout
+		*out = new(int)
+		**out = **in
+	}
+	if in.ClientOfflineSessionMaxLifespan != nil {
+		in, out := &in.ClientOfflineSessionMaxLifespan, &out.ClientOfflineSessionMaxLifespan
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmSessions.
+func (in *RealmSessions) DeepCopy() *RealmSessions {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmSessions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RealmThemes) DeepCopyInto(out *RealmThemes) {
 	*out = *in
@@ -1504,6 +2560,33 @@ func (in *RealmThemes) DeepCopy() *RealmThemes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequiredAction) DeepCopyInto(out *RequiredAction) {
+	*out = *in
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int)
+		**out = **in
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequiredAction.
+func (in *RequiredAction) DeepCopy() *RequiredAction {
+	if in == nil {
+		return nil
+	}
+	out := new(RequiredAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SSORealmMapper) DeepCopyInto(out *SSORealmMapper) {
 	*out = *in
@@ -1560,6 +2643,66 @@ func (in *ServiceAccount) DeepCopy() *ServiceAccount {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenSettings) DeepCopyInto(out *TokenSettings) {
+	*out = *in
+	if in.AccessTokenLifespan != nil {
+		in, out := &in.AccessTokenLifespan, &out.AccessTokenLifespan
+		*out = new(int)
+		**out = **in
+	}
+	if in.AccessTokenLifespanForImplicitFlow != nil {
+		in, out := &in.AccessTokenLifespanForImplicitFlow, &out.AccessTokenLifespanForImplicitFlow
+		*out = new(int)
+		**out = **in
+	}
+	if in.AccessCodeLifespan != nil {
+		in, out := &in.AccessCodeLifespan, &out.AccessCodeLifespan
+		*out = new(int)
+		**out = **in
+	}
+	if in.AccessCodeLifespanLogin != nil {
+		in, out := &in.AccessCodeLifespanLogin, &out.AccessCodeLifespanLogin
+		*out = new(int)
+		**out = **in
+	}
+	if in.AccessCodeLifespanUserAction != nil {
+		in, out := &in.AccessCodeLifespanUserAction, &out.AccessCodeLifespanUserAction
+		*out = new(int)
+		**out = **in
+	}
+	if in.ActionTokenGeneratedByUserLifespan != nil {
+		in, out := &in.ActionTokenGeneratedByUserLifespan, &out.ActionTokenGeneratedByUserLifespan
+		*out = new(int)
+		**out = **in
+	}
+	if in.ActionTokenGeneratedByAdminLifespan != nil {
+		in, out := &in.ActionTokenGeneratedByAdminLifespan, &out.ActionTokenGeneratedByAdminLifespan
+		*out = new(int)
+		**out = **in
+	}
+	if in.RevokeRefreshToken != nil {
+		in, out := &in.RevokeRefreshToken, &out.RevokeRefreshToken
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RefreshTokenMaxReuse != nil {
+		in, out := &in.RefreshTokenMaxReuse, &out.RefreshTokenMaxReuse
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenSettings.
+func (in *TokenSettings) DeepCopy() *TokenSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *User) DeepCopyInto(out *User) {
 	*out = *in
@@ -1579,3 +2722,248 @@ func (in *User) DeepCopy() *User {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserProfile) DeepCopyInto(out *UserProfile) {
+	*out = *in
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make([]UserProfileAttribute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]UserProfileGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(KeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProfile.
+func (in *UserProfile) DeepCopy() *UserProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(UserProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserProfileAttribute) DeepCopyInto(out *UserProfileAttribute) {
+	*out = *in
+	if in.Validations != nil {
+		in, out := &in.Validations, &out.Validations
+		*out = make(map[string]map[string]apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			var outVal map[string]apiextensionsv1.JSON
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(map[string]apiextensionsv1.JSON, len(*in))
+				for key, val := range *in {
+					(*out)[key] = *val.DeepCopy()
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Required != nil {
+		in, out := &in.Required, &out.Required
+		*out = new(UserProfileAttributeRequired)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = new(UserProfileAttributePermissions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(UserProfileAttributeSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProfileAttribute.
+func (in *UserProfileAttribute) DeepCopy() *UserProfileAttribute {
+	if in == nil {
+		return nil
+	}
+	out := new(UserProfileAttribute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserProfileAttributePermissions) DeepCopyInto(out *UserProfileAttributePermissions) {
+	*out = *in
+	if in.View != nil {
+		in, out := &in.View, &out.View
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Edit != nil {
+		in, out := &in.Edit, &out.Edit
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProfileAttributePermissions.
+func (in *UserProfileAttributePermissions) DeepCopy() *UserProfileAttributePermissions {
+	if in == nil {
+		return nil
+	}
+	out := new(UserProfileAttributePermissions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserProfileAttributeRequired) DeepCopyInto(out *UserProfileAttributeRequired) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProfileAttributeRequired.
+func (in *UserProfileAttributeRequired) DeepCopy() *UserProfileAttributeRequired {
+	if in == nil {
+		return nil
+	}
+	out := new(UserProfileAttributeRequired)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserProfileAttributeSelector) DeepCopyInto(out *UserProfileAttributeSelector) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProfileAttributeSelector.
+func (in *UserProfileAttributeSelector) DeepCopy() *UserProfileAttributeSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(UserProfileAttributeSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserProfileGroup) DeepCopyInto(out *UserProfileGroup) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProfileGroup.
+func (in *UserProfileGroup) DeepCopy() *UserProfileGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(UserProfileGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebAuthnPolicy) DeepCopyInto(out *WebAuthnPolicy) {
+	*out = *in
+	if in.RpEntityName != nil {
+		in, out := &in.RpEntityName, &out.RpEntityName
+		*out = new(string)
+		**out = **in
+	}
+	if in.RpID != nil {
+		in, out := &in.RpID, &out.RpID
+		*out = new(string)
+		**out = **in
+	}
+	if in.SignatureAlgorithms != nil {
+		in, out := &in.SignatureAlgorithms, &out.SignatureAlgorithms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AttestationConveyancePreference != nil {
+		in, out := &in.AttestationConveyancePreference, &out.AttestationConveyancePreference
+		*out = new(string)
+		**out = **in
+	}
+	if in.AuthenticatorAttachment != nil {
+		in, out := &in.AuthenticatorAttachment, &out.AuthenticatorAttachment
+		*out = new(string)
+		**out = **in
+	}
+	if in.RequireResidentKey != nil {
+		in, out := &in.RequireResidentKey, &out.RequireResidentKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.UserVerificationRequirement != nil {
+		in, out := &in.UserVerificationRequirement, &out.UserVerificationRequirement
+		*out = new(string)
+		**out = **in
+	}
+	if in.CreateTimeout != nil {
+		in, out := &in.CreateTimeout, &out.CreateTimeout
+		*out = new(int)
+		**out = **in
+	}
+	if in.AvoidSameAuthenticatorRegister != nil {
+		in, out := &in.AvoidSameAuthenticatorRegister, &out.AvoidSameAuthenticatorRegister
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AcceptableAaguids != nil {
+		in, out := &in.AcceptableAaguids, &out.AcceptableAaguids
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebAuthnPolicy.
+func (in *WebAuthnPolicy) DeepCopy() *WebAuthnPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(WebAuthnPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,114 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	DeletionPolicyDelete = "Delete"
+	DeletionPolicyRetain = "Retain"
+)
+
+// ClusterKeycloakRealmSpec defines the desired state of ClusterKeycloakRealm.
+type ClusterKeycloakRealmSpec struct {
+	// ClusterKeycloakRef is a name of the ClusterKeycloak instance that owns the realm.
+	// +required
+	ClusterKeycloakRef string `json:"clusterKeycloakRef"`
+
+	// RealmName specifies the name of the realm.
+	// +required
+	RealmName string `json:"realmName"`
+
+	// FrontendURL Set the frontend URL for the realm. Use in combination with the default hostname provider to override the base URL for frontend requests for a specific realm.
+	// +optional
+	FrontendURL string `json:"frontendUrl,omitempty"`
+
+	// BrowserFlow specifies the authentication flow to use for the realm's browser clients.
+	// +nullable
+	// +optional
+	BrowserFlow *string `json:"browserFlow,omitempty"`
+
+	// Themes is a map of themes to apply to the realm.
+	// +nullable
+	// +optional
+	Themes *RealmThemes `json:"themes,omitempty"`
+
+	// BrowserSecurityHeaders is a map of security headers to apply to HTTP responses from the realm's browser clients.
+	// +nullable
+	// +optional
+	BrowserSecurityHeaders *map[string]string `json:"browserSecurityHeaders,omitempty"`
+
+	// RealmEventConfig is the configuration for events in the realm.
+	// +nullable
+	// +optional
+	RealmEventConfig *RealmEventConfig `json:"realmEventConfig,omitempty"`
+
+	// PasswordPolicies is a list of password policies to apply to the realm.
+	// +nullable
+	// +optional
+	PasswordPolicies []PasswordPolicy `json:"passwordPolicy,omitempty"`
+
+	// DeletionPolicy defines whether keycloak realm is deleted when the custom resource is deleted.
+	// Delete removes keycloak realm, Retain keeps it. Can be overridden by v1.edp.epam.com/deletion-policy annotation.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Retain
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+// ClusterKeycloakRealmStatus defines the observed state of ClusterKeycloakRealm.
+type ClusterKeycloakRealmStatus struct {
+	// +optional
+	Available bool `json:"available,omitempty"`
+
+	// +optional
+	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+func (in *ClusterKeycloakRealm) GetFailureCount() int64 {
+	return in.Status.FailureCount
+}
+
+func (in *ClusterKeycloakRealm) SetFailureCount(count int64) {
+	in.Status.FailureCount = count
+}
+
+func (in *ClusterKeycloakRealm) GetDeletionPolicy() string {
+	if in.Spec.DeletionPolicy == "" {
+		return DeletionPolicyRetain
+	}
+
+	return in.Spec.DeletionPolicy
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Available",type="boolean",JSONPath=".status.available",description="Is the resource available"
+
+// ClusterKeycloakRealm is the Schema for the clusterkeycloakrealms API.
+type ClusterKeycloakRealm struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterKeycloakRealmSpec   `json:"spec,omitempty"`
+	Status ClusterKeycloakRealmStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterKeycloakRealmList contains a list of ClusterKeycloakRealm.
+type ClusterKeycloakRealmList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterKeycloakRealm `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterKeycloakRealm{}, &ClusterKeycloakRealmList{})
+}
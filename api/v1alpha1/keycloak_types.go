@@ -39,6 +39,9 @@ type KeycloakSpec struct {
 const (
 	KeycloakAdminTypeUser           = "user"
 	KeycloakAdminTypeServiceAccount = "serviceAccount"
+	KeycloakAdminTypePrivateKeyJWT  = "privateKeyJWT"
+
+	KeycloakDefaultAdminRealm = "master"
 )
 
 func (in *Keycloak) GetAdminType() string {
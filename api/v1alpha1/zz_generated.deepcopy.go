@@ -6,6 +6,7 @@
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -86,6 +87,31 @@ func (in *BatchRole) DeepCopy() *BatchRole {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CACertSource) DeepCopyInto(out *CACertSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(KeySelector)
+		**out = **in
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(KeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CACertSource.
+func (in *CACertSource) DeepCopy() *CACertSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CACertSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClientRole) DeepCopyInto(out *ClientRole) {
 	*out = *in
@@ -111,8 +137,8 @@ func (in *ClusterKeycloak) DeepCopyInto(out *ClusterKeycloak) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKeycloak.
@@ -165,9 +191,144 @@ func (in *ClusterKeycloakList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKeycloakRealm) DeepCopyInto(out *ClusterKeycloakRealm) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKeycloakRealm.
+func (in *ClusterKeycloakRealm) DeepCopy() *ClusterKeycloakRealm {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKeycloakRealm)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterKeycloakRealm) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKeycloakRealmList) DeepCopyInto(out *ClusterKeycloakRealmList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterKeycloakRealm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKeycloakRealmList.
+func (in *ClusterKeycloakRealmList) DeepCopy() *ClusterKeycloakRealmList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKeycloakRealmList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterKeycloakRealmList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKeycloakRealmSpec) DeepCopyInto(out *ClusterKeycloakRealmSpec) {
+	*out = *in
+	if in.BrowserFlow != nil {
+		in, out := &in.BrowserFlow, &out.BrowserFlow
+		*out = new(string)
+		**out = **in
+	}
+	if in.Themes != nil {
+		in, out := &in.Themes, &out.Themes
+		*out = new(RealmThemes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BrowserSecurityHeaders != nil {
+		in, out := &in.BrowserSecurityHeaders, &out.BrowserSecurityHeaders
+		*out = new(map[string]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make(map[string]string, len(*in))
+			for key, val := range *in {
+				(*out)[key] = val
+			}
+		}
+	}
+	if in.RealmEventConfig != nil {
+		in, out := &in.RealmEventConfig, &out.RealmEventConfig
+		*out = new(RealmEventConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordPolicies != nil {
+		in, out := &in.PasswordPolicies, &out.PasswordPolicies
+		*out = make([]PasswordPolicy, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKeycloakRealmSpec.
+func (in *ClusterKeycloakRealmSpec) DeepCopy() *ClusterKeycloakRealmSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKeycloakRealmSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKeycloakRealmStatus) DeepCopyInto(out *ClusterKeycloakRealmStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKeycloakRealmStatus.
+func (in *ClusterKeycloakRealmStatus) DeepCopy() *ClusterKeycloakRealmStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKeycloakRealmStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterKeycloakSpec) DeepCopyInto(out *ClusterKeycloakSpec) {
 	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(KeycloakTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(KeycloakRetry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(KeycloakRateLimit)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKeycloakSpec.
@@ -183,6 +344,18 @@ func (in *ClusterKeycloakSpec) DeepCopy() *ClusterKeycloakSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterKeycloakStatus) DeepCopyInto(out *ClusterKeycloakStatus) {
 	*out = *in
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKeycloakStatus.
@@ -232,6 +405,21 @@ func (in *IdentityProviderMapper) DeepCopy() *IdentityProviderMapper {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeySelector) DeepCopyInto(out *KeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeySelector.
+func (in *KeySelector) DeepCopy() *KeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(KeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Keycloak) DeepCopyInto(out *Keycloak) {
 	*out = *in
@@ -671,6 +859,21 @@ func (in *KeycloakList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRateLimit) DeepCopyInto(out *KeycloakRateLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRateLimit.
+func (in *KeycloakRateLimit) DeepCopy() *KeycloakRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakRealm) DeepCopyInto(out *KeycloakRealm) {
 	*out = *in
@@ -1430,6 +1633,36 @@ func (in *KeycloakRealmUserStatus) DeepCopy() *KeycloakRealmUserStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRetry) DeepCopyInto(out *KeycloakRetry) {
+	*out = *in
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int)
+		**out = **in
+	}
+	if in.WaitTime != nil {
+		in, out := &in.WaitTime, &out.WaitTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxWaitTime != nil {
+		in, out := &in.MaxWaitTime, &out.MaxWaitTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRetry.
+func (in *KeycloakRetry) DeepCopy() *KeycloakRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakSpec) DeepCopyInto(out *KeycloakSpec) {
 	*out = *in
@@ -1472,6 +1705,26 @@ func (in *KeycloakStatus) DeepCopy() *KeycloakStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakTLS) DeepCopyInto(out *KeycloakTLS) {
+	*out = *in
+	if in.CACert != nil {
+		in, out := &in.CACert, &out.CACert
+		*out = new(CACertSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakTLS.
+func (in *KeycloakTLS) DeepCopy() *KeycloakTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PasswordPolicy) DeepCopyInto(out *PasswordPolicy) {
 	*out = *in
@@ -0,0 +1,134 @@
+// export-crs reads an existing Keycloak realm and prints custom resources which describe it.
+// It is used to bring realms created outside of the operator under its management.
+//
+// Usage:
+//
+//	export-crs --url https://keycloak.example.com --realm foo --keycloak keycloak --namespace security > foo.yaml
+//
+// Admin credentials are taken from flags or KEYCLOAK_USER/KEYCLOAK_PASSWORD
+// or KEYCLOAK_CLIENT_ID/KEYCLOAK_CLIENT_SECRET environment variables.
+// Secrets are not exported, resources reference Secrets which should be created before applying the output.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/realmexport"
+)
+
+type config struct {
+	url                string
+	realm              string
+	adminRealm         string
+	user               string
+	password           string
+	clientID           string
+	clientSecret       string
+	keycloakRef        string
+	clusterKeycloakRef string
+	namespace          string
+	output             string
+	timeout            time.Duration
+}
+
+func main() {
+	cfg := config{}
+
+	flag.StringVar(&cfg.url, "url", os.Getenv("KEYCLOAK_URL"), "Keycloak URL.")
+	flag.StringVar(&cfg.realm, "realm", "", "Name of the realm to export.")
+	flag.StringVar(&cfg.adminRealm, "admin-realm", "master", "Realm used for authentication.")
+	flag.StringVar(&cfg.user, "user", os.Getenv("KEYCLOAK_USER"), "Admin username.")
+	flag.StringVar(&cfg.password, "password", os.Getenv("KEYCLOAK_PASSWORD"), "Admin password.")
+	flag.StringVar(&cfg.clientID, "client-id", os.Getenv("KEYCLOAK_CLIENT_ID"),
+		"Client ID of service account. Used instead of user and password.")
+	flag.StringVar(&cfg.clientSecret, "client-secret", os.Getenv("KEYCLOAK_CLIENT_SECRET"), "Client secret of service account.")
+	flag.StringVar(&cfg.keycloakRef, "keycloak", "", "Name of Keycloak custom resource which owns the realm.")
+	flag.StringVar(&cfg.clusterKeycloakRef, "cluster-keycloak", "", "Name of ClusterKeycloak custom resource which owns the realm.")
+	flag.StringVar(&cfg.namespace, "namespace", "", "Namespace of generated custom resources.")
+	flag.StringVar(&cfg.output, "output", "", "Output file. Defaults to stdout.")
+	flag.DurationVar(&cfg.timeout, "timeout", time.Minute, "Timeout for Keycloak requests.")
+	flag.Parse()
+
+	if err := run(&cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "export-crs: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(cfg *config) error {
+	if cfg.url == "" || cfg.realm == "" {
+		return errors.New("url and realm are required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.timeout)
+	defer cancel()
+
+	kClient, err := makeClient(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	representation, err := kClient.PartialExport(ctx, cfg.realm, adapter.PartialExportOptions{
+		ExportClients:        true,
+		ExportGroupsAndRoles: true,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to export realm %s: %w", cfg.realm, err)
+	}
+
+	manifests, err := realmexport.Convert(representation, realmexport.Options{
+		Namespace:          cfg.namespace,
+		KeycloakRef:        cfg.keycloakRef,
+		ClusterKeycloakRef: cfg.clusterKeycloakRef,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to convert realm %s: %w", cfg.realm, err)
+	}
+
+	var out io.Writer = os.Stdout
+
+	if cfg.output != "" {
+		f, err := os.Create(cfg.output)
+		if err != nil {
+			return fmt.Errorf("unable to create output file: %w", err)
+		}
+
+		defer f.Close()
+
+		out = f
+	}
+
+	return manifests.Write(out)
+}
+
+func makeClient(ctx context.Context, cfg *config) (*adapter.GoCloakAdapter, error) {
+	if cfg.clientID != "" {
+		kClient, err := adapter.MakeFromServiceAccount(ctx, cfg.url, "", cfg.clientID, cfg.clientSecret,
+			cfg.adminRealm, logr.Discard(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("unable to login with service account: %w", err)
+		}
+
+		return kClient, nil
+	}
+
+	if cfg.user == "" {
+		return nil, errors.New("either user and password or client-id and client-secret are required")
+	}
+
+	kClient, err := adapter.Make(ctx, cfg.url, "", cfg.user, cfg.password, cfg.adminRealm, logr.Discard(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to login with admin credentials: %w", err)
+	}
+
+	return kClient, nil
+}
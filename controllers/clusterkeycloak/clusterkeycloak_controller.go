@@ -3,50 +3,72 @@ package clusterkeycloak
 import (
 	"context"
 	"fmt"
-	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
+	pkgErrors "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1alpha1"
 	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
 )
 
 type Helper interface {
-	TokenSecretLock() *sync.Mutex
+	CreateKeycloakClientFromClusterKeycloak(ctx context.Context, kc *keycloakApi.ClusterKeycloak) (keycloak.Client, error)
+	InvalidateClusterKeycloakClientTokenSecret(ctx context.Context, clusterKeycloakName string) error
+	ForgetKeycloakClient(uid types.UID)
 }
 
-func NewReconcile(client client.Client, scheme *runtime.Scheme, log logr.Logger, helper Helper) *ClusterKeycloakReconciler {
+func NewReconcile(client client.Client, scheme *runtime.Scheme, log logr.Logger, helper Helper, operatorNamespace string) *ClusterKeycloakReconciler {
 	return &ClusterKeycloakReconciler{
-		client: client,
-		scheme: scheme,
-		log:    log.WithName("clusterkeycloak"),
-		helper: helper,
+		client:            client,
+		scheme:            scheme,
+		log:               log.WithName("clusterkeycloak"),
+		helper:            helper,
+		operatorNamespace: operatorNamespace,
 	}
 }
 
-// ReconcileKeycloak reconciles a Keycloak object.
+// ClusterKeycloakReconciler reconciles a ClusterKeycloak object.
 type ClusterKeycloakReconciler struct {
 	client                  client.Client
 	scheme                  *runtime.Scheme
 	log                     logr.Logger
 	helper                  Helper
 	successReconcileTimeout time.Duration
+	operatorNamespace       string
 }
 
 //+kubebuilder:rbac:groups=v1.edp.epam.com,resources=clusterkeycloaks,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=v1.edp.epam.com,resources=clusterkeycloaks/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=v1.edp.epam.com,resources=clusterkeycloaks/finalizers,verbs=update
 
+// forgetDeleted drops cached client and rate limiter of the deleted instance. Deleted instances aren't reconciled.
+func (r *ClusterKeycloakReconciler) forgetDeleted(e event.DeleteEvent) bool {
+	r.helper.ForgetKeycloakClient(e.Object.GetUID())
+
+	return false
+}
+
 // Reconcile is a loop for reconciling ClusterKeycloak object.
 func (r *ClusterKeycloakReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.log.WithValues("Request.Namespace", req.Namespace, "Request.Name", req.Name)
-	log.Info("Reconciling Keycloak")
+	log.Info("Reconciling ClusterKeycloak")
 
 	instance := &keycloakApi.ClusterKeycloak{}
 	if err := r.client.Get(ctx, req.NamespacedName, instance); err != nil {
@@ -60,6 +82,25 @@ func (r *ClusterKeycloakReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return reconcile.Result{RequeueAfter: helper.DefaultRequeueTime}, nil
 	}
 
+	credentialsVersion, err := r.checkCredentialsRotation(ctx, instance)
+	if err != nil {
+		log.Error(err, "unable to check admin credentials rotation")
+
+		return reconcile.Result{RequeueAfter: helper.DefaultRequeueTime}, nil
+	}
+
+	if err := r.updateConnectionStatusToKeycloak(ctx, instance, credentialsVersion); err != nil {
+		log.Error(err, "error during reconciliation")
+
+		return reconcile.Result{RequeueAfter: helper.DefaultRequeueTime}, nil
+	}
+
+	if !instance.Status.Connected {
+		log.Info("ClusterKeycloak is not connected")
+
+		return reconcile.Result{RequeueAfter: helper.DefaultRequeueTime}, nil
+	}
+
 	log.Info("Reconciling ClusterKeycloak has been finished")
 
 	return reconcile.Result{
@@ -67,16 +108,148 @@ func (r *ClusterKeycloakReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	}, nil
 }
 
+func (r *ClusterKeycloakReconciler) updateConnectionStatusToKeycloak(ctx context.Context, instance *keycloakApi.ClusterKeycloak,
+	credentialsVersion string,
+) error {
+	log := r.log.WithValues("clusterkeycloak cr", instance.Name)
+	log.Info("Start updating connection status to Keycloak")
+
+	oldStatus := instance.Status.DeepCopy()
+
+	r.setConnectionStatus(ctx, instance, log)
+	instance.Status.CredentialsVersion = credentialsVersion
+
+	if equality.Semantic.DeepEqual(oldStatus, &instance.Status) {
+		return nil
+	}
+
+	if err := r.client.Status().Update(ctx, instance); err != nil {
+		return pkgErrors.Wrap(err, "unable to update clusterkeycloak cr status")
+	}
+
+	log.Info("Status has been updated", "status", instance.Status)
+
+	return nil
+}
+
+// setConnectionStatus checks connection to Keycloak and fills the instance status with server info and conditions.
+func (r *ClusterKeycloakReconciler) setConnectionStatus(ctx context.Context, instance *keycloakApi.ClusterKeycloak, log logr.Logger) {
+	kClient, err := r.helper.CreateKeycloakClientFromClusterKeycloak(ctx, instance)
+	if err != nil {
+		log.Error(err, "error during the creation of connection")
+
+		instance.Status.Connected = false
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:               keycloakApi.ClusterKeycloakConditionConnected,
+			Status:             metav1.ConditionFalse,
+			Reason:             "ConnectionFailed",
+			Message:            err.Error(),
+			ObservedGeneration: instance.Generation,
+		})
+		helper.SetFailedConditions(instance, err.Error())
+
+		return
+	}
+
+	instance.Status.Connected = true
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:               keycloakApi.ClusterKeycloakConditionConnected,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Connected",
+		Message:            "Successfully connected to Keycloak",
+		ObservedGeneration: instance.Generation,
+	})
+	helper.SetSucceededConditions(instance)
+
+	info, err := kClient.GetServerInfo(ctx)
+	if err != nil {
+		log.Error(err, "unable to get keycloak server info")
+
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:               keycloakApi.ClusterKeycloakConditionServerInfoAvailable,
+			Status:             metav1.ConditionFalse,
+			Reason:             "ServerInfoFailed",
+			Message:            err.Error(),
+			ObservedGeneration: instance.Generation,
+		})
+
+		return
+	}
+
+	instance.Status.Version = info.Version
+	instance.Status.BasePath = info.BasePath
+	instance.Status.Features = info.Features
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:               keycloakApi.ClusterKeycloakConditionServerInfoAvailable,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ServerInfoRetrieved",
+		Message:            fmt.Sprintf("Keycloak version %s", info.Version),
+		ObservedGeneration: instance.Generation,
+	})
+}
+
+// checkCredentialsRotation invalidates cached keycloak client if the admin credentials secret
+// was changed since the last connection. It returns the current version of the secret.
+func (r *ClusterKeycloakReconciler) checkCredentialsRotation(ctx context.Context, instance *keycloakApi.ClusterKeycloak) (string, error) {
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: r.operatorNamespace, Name: instance.Spec.Secret}, secret); err != nil {
+		if errors.IsNotFound(err) {
+			// connection check reports the missing secret.
+			return "", nil
+		}
+
+		return "", pkgErrors.Wrap(err, "unable to get admin credentials secret")
+	}
+
+	if instance.Status.CredentialsVersion != "" && instance.Status.CredentialsVersion != secret.ResourceVersion {
+		r.log.Info("Admin credentials secret has been changed, invalidating keycloak client", "secret", secret.Name)
+
+		if err := r.helper.InvalidateClusterKeycloakClientTokenSecret(ctx, instance.Name); err != nil {
+			return "", pkgErrors.Wrap(err, "unable to invalidate keycloak client")
+		}
+	}
+
+	return secret.ResourceVersion, nil
+}
+
+// mapSecretToClusterKeycloaks returns requests for ClusterKeycloak instances which use the secret as admin credentials.
+// Only secrets from the operator namespace are taken into account.
+func (r *ClusterKeycloakReconciler) mapSecretToClusterKeycloaks(secret client.Object) []reconcile.Request {
+	if secret.GetNamespace() != r.operatorNamespace {
+		return nil
+	}
+
+	var list keycloakApi.ClusterKeycloakList
+	if err := r.client.List(context.Background(), &list); err != nil {
+		r.log.Error(err, "unable to list clusterkeycloaks for secret", "secret", secret.GetName())
+
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for i := range list.Items {
+		if list.Items[i].Spec.Secret == secret.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: list.Items[i].Name},
+			})
+		}
+	}
+
+	return requests
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterKeycloakReconciler) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
 	r.successReconcileTimeout = successReconcileTimeout
 
 	err := ctrl.NewControllerManagedBy(mgr).
-		For(&keycloakApi.ClusterKeycloak{}).
+		For(&keycloakApi.ClusterKeycloak{}, builder.WithPredicates(predicate.Funcs{DeleteFunc: r.forgetDeleted})).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.mapSecretToClusterKeycloaks)).
 		Complete(r)
 
 	if err != nil {
-		return fmt.Errorf("failed to setup Keycloak controller: %w", err)
+		return fmt.Errorf("failed to setup ClusterKeycloak controller: %w", err)
 	}
 
 	return nil
@@ -2,10 +2,14 @@ package clusterkeycloak
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-	appsv1 "k8s.io/api/apps/v1"
+	testifyMock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -15,11 +19,12 @@ import (
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1alpha1"
 	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
 )
 
 func TestNewReconcile(t *testing.T) {
-	kc := NewReconcile(nil, nil, mock.NewLogr(), &helper.Mock{})
+	kc := NewReconcile(nil, nil, mock.NewLogr(), &helper.Mock{}, "")
 	if kc.scheme != nil {
 		t.Fatal("something went wrong")
 	}
@@ -27,10 +32,6 @@ func TestNewReconcile(t *testing.T) {
 
 func TestReconcileClusterKeycloak_ReconcilePass(t *testing.T) {
 	cr := &keycloakApi.ClusterKeycloak{
-		TypeMeta: metav1.TypeMeta{
-			Kind:       "ClusterKeycloak",
-			APIVersion: "apps/v1alpha1",
-		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "NewKeycloak",
 			Namespace: "namespace",
@@ -45,7 +46,7 @@ func TestReconcileClusterKeycloak_ReconcilePass(t *testing.T) {
 		cr,
 	}
 	s := scheme.Scheme
-	s.AddKnownTypes(appsv1.SchemeGroupVersion, cr)
+	require.NoError(t, keycloakApi.AddToScheme(s))
 
 	cl := fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()
 
@@ -57,11 +58,21 @@ func TestReconcileClusterKeycloak_ReconcilePass(t *testing.T) {
 	}
 
 	logger := mock.NewLogr()
+	kClient := &adapter.Mock{}
+	kClient.On("GetServerInfo").Return(&adapter.ServerInfo{
+		Version:  "22.0.5",
+		BasePath: "/",
+		Features: []string{"declarative-user-profile"},
+	}, nil)
+
+	h := helper.Mock{}
+	h.On("CreateKeycloakClientFromClusterKeycloak", testifyMock.Anything).Return(kClient, nil)
 
 	r := ClusterKeycloakReconciler{
 		client: cl,
 		scheme: s,
 		log:    logger,
+		helper: &h,
 	}
 
 	res, err := r.Reconcile(context.TODO(), req)
@@ -69,6 +80,52 @@ func TestReconcileClusterKeycloak_ReconcilePass(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, res.Requeue)
 
+	persisted := &keycloakApi.ClusterKeycloak{}
+	err = cl.Get(context.TODO(), req.NamespacedName, persisted)
+	assert.Nil(t, err)
+	assert.True(t, persisted.Status.Connected)
+	assert.Equal(t, "22.0.5", persisted.Status.Version)
+	assert.Equal(t, "/", persisted.Status.BasePath)
+	assert.Equal(t, []string{"declarative-user-profile"}, persisted.Status.Features)
+	assert.True(t, meta.IsStatusConditionTrue(persisted.Status.Conditions, keycloakApi.ClusterKeycloakConditionServerInfoAvailable))
+	assert.True(t, meta.IsStatusConditionTrue(persisted.Status.Conditions, keycloakApi.ClusterKeycloakConditionConnected))
+}
+
+func TestReconcileClusterKeycloak_ReconcileNotConnected(t *testing.T) {
+	cr := &keycloakApi.ClusterKeycloak{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "NewKeycloak",
+		},
+		Spec: keycloakApi.ClusterKeycloakSpec{
+			Url:    "https://some",
+			Secret: "keycloak-secret",
+		},
+		Status: keycloakApi.ClusterKeycloakStatus{
+			Connected: true,
+		},
+	}
+
+	s := scheme.Scheme
+	require.NoError(t, keycloakApi.AddToScheme(s))
+
+	cl := fake.NewClientBuilder().WithRuntimeObjects(cr).Build()
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name: "NewKeycloak",
+		},
+	}
+
+	h := helper.Mock{}
+	h.On("CreateKeycloakClientFromClusterKeycloak", testifyMock.Anything).Return(nil, errors.New("fatal"))
+
+	r := NewReconcile(cl, s, mock.NewLogr(), &h, "")
+
+	res, err := r.Reconcile(context.TODO(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, helper.DefaultRequeueTime, res.RequeueAfter)
+
 	persisted := &keycloakApi.ClusterKeycloak{}
 	err = cl.Get(context.TODO(), req.NamespacedName, persisted)
 	assert.Nil(t, err)
@@ -78,7 +135,7 @@ func TestReconcileClusterKeycloak_ReconcilePass(t *testing.T) {
 func TestReconcileClusterKeycloak_ReconcilePassWithNoFound(t *testing.T) {
 	objs := []runtime.Object{}
 	s := scheme.Scheme
-	s.AddKnownTypes(appsv1.SchemeGroupVersion)
+	require.NoError(t, keycloakApi.AddToScheme(s))
 
 	cl := fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()
 
@@ -102,3 +159,55 @@ func TestReconcileClusterKeycloak_ReconcilePassWithNoFound(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, res.Requeue)
 }
+
+func TestReconcileClusterKeycloak_CredentialsRotation(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, keycloakApi.AddToScheme(s))
+	require.NoError(t, corev1.AddToScheme(s))
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "keycloak-secret", Namespace: "operator-ns"},
+		Data:       map[string][]byte{"username": []byte("user"), "password": []byte("pass")},
+	}
+	cr := &keycloakApi.ClusterKeycloak{
+		ObjectMeta: metav1.ObjectMeta{Name: "kc"},
+		Spec:       keycloakApi.ClusterKeycloakSpec{Url: "https://some", Secret: secret.Name},
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(cr, secret).Build()
+
+	kClient := &adapter.Mock{}
+	kClient.On("GetServerInfo").Return(&adapter.ServerInfo{Version: "22.0.5"}, nil)
+
+	h := helper.Mock{}
+	h.On("CreateKeycloakClientFromClusterKeycloak", testifyMock.Anything).Return(kClient, nil)
+
+	r := NewReconcile(cl, s, mock.NewLogr(), &h, "operator-ns")
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: cr.Name}}
+
+	_, err := r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	persisted := &keycloakApi.ClusterKeycloak{}
+	require.NoError(t, cl.Get(context.Background(), req.NamespacedName, persisted))
+	require.NotEmpty(t, persisted.Status.CredentialsVersion)
+	h.AssertNotCalled(t, "InvalidateClusterKeycloakClientTokenSecret", cr.Name)
+
+	secret.Data["password"] = []byte("new-pass")
+	require.NoError(t, cl.Update(context.Background(), secret))
+
+	assert.Equal(t, []reconcile.Request{req}, r.mapSecretToClusterKeycloaks(secret))
+
+	h.On("InvalidateClusterKeycloakClientTokenSecret", cr.Name).Return(nil).Once()
+
+	_, err = r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	require.NoError(t, cl.Get(context.Background(), req.NamespacedName, persisted))
+	assert.Equal(t, secret.ResourceVersion, persisted.Status.CredentialsVersion)
+	h.AssertExpectations(t)
+
+	otherNsSecret := secret.DeepCopy()
+	otherNsSecret.Namespace = "other-ns"
+	assert.Empty(t, r.mapSecretToClusterKeycloaks(otherNsSecret))
+}
@@ -0,0 +1,164 @@
+package clusterkeycloakrealm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlHandler "sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	keycloakAlpha "github.com/epam/edp-keycloak-operator/api/v1alpha1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain/handler"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+)
+
+const clusterKeycloakRealmOperatorFinalizerName = "keycloak.clusterrealm.operator.finalizer.name"
+
+type Helper interface {
+	SetFailureCount(fc helper.FailureCountable) time.Duration
+	UpdateStatus(obj client.Object) error
+	TryToDelete(ctx context.Context, obj helper.Deletable, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
+	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
+	InvalidateKeycloakClientTokenSecret(ctx context.Context, namespace, rootKeycloakName string) error
+	InvalidateClusterKeycloakClientTokenSecret(ctx context.Context, clusterKeycloakName string) error
+}
+
+func NewReconcile(client client.Client, log logr.Logger, helper Helper) *ReconcileClusterKeycloakRealm {
+	return &ReconcileClusterKeycloakRealm{
+		client: client,
+		helper: helper,
+		log:    log.WithName("cluster-keycloak-realm"),
+		chain:  chain.CreateClusterRealmChain(helper),
+	}
+}
+
+// ReconcileClusterKeycloakRealm reconciles a ClusterKeycloakRealm object.
+type ReconcileClusterKeycloakRealm struct {
+	client                  client.Client
+	helper                  Helper
+	log                     logr.Logger
+	chain                   handler.RealmHandler
+	successReconcileTimeout time.Duration
+}
+
+func (r *ReconcileClusterKeycloakRealm) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+	r.successReconcileTimeout = successReconcileTimeout
+	pred := predicate.Funcs{
+		UpdateFunc: helper.IsFailuresUpdated,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakAlpha.ClusterKeycloakRealm{}, builder.WithPredicates(pred)).
+		Watches(
+			&source.Kind{Type: &keycloakAlpha.ClusterKeycloak{}},
+			ctrlHandler.EnqueueRequestsFromMapFunc(r.mapClusterKeycloakToRealms),
+			builder.WithPredicates(predicate.Funcs{UpdateFunc: helper.IsConnectionUpdated}),
+		).
+		Complete(r)
+	if err != nil {
+		return fmt.Errorf("failed to setup ClusterKeycloakRealm controller: %w", err)
+	}
+
+	return nil
+}
+
+//+kubebuilder:rbac:groups=v1.edp.epam.com,resources=clusterkeycloakrealms,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=v1.edp.epam.com,resources=clusterkeycloakrealms/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=v1.edp.epam.com,resources=clusterkeycloakrealms/finalizers,verbs=update
+
+// Reconcile is a loop for reconciling ClusterKeycloakRealm object.
+func (r *ReconcileClusterKeycloakRealm) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resultErr error) {
+	log := r.log.WithValues("Request.Name", request.Name)
+	log.Info("Reconciling ClusterKeycloakRealm")
+
+	instance := &keycloakAlpha.ClusterKeycloakRealm{}
+	if err := r.client.Get(ctx, request.NamespacedName, instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return
+		}
+
+		resultErr = err
+
+		return
+	}
+
+	if err := r.tryReconcile(ctx, instance); err != nil {
+		instance.Status.Available = false
+		instance.Status.Value = err.Error()
+		result.RequeueAfter = r.helper.SetFailureCount(instance)
+
+		log.Error(err, "an error has occurred while handling cluster keycloak realm", "name", request.Name)
+	} else {
+		instance.Status.Available = true
+		instance.Status.Value = helper.StatusOK
+		instance.Status.FailureCount = 0
+		result.RequeueAfter = r.successReconcileTimeout
+	}
+
+	if err := r.helper.UpdateStatus(instance); err != nil {
+		resultErr = errors.Wrap(err, "unable to update status")
+	}
+
+	return
+}
+
+func (r *ReconcileClusterKeycloakRealm) tryReconcile(ctx context.Context, clusterRealm *keycloakAlpha.ClusterKeycloakRealm) error {
+	realm := helper.ClusterRealmToRealm(clusterRealm)
+
+	kClient, err := r.helper.CreateKeycloakClientForRealm(ctx, realm)
+	if err != nil {
+		return fmt.Errorf("failed to create keycloak client for cluster realm: %w", err)
+	}
+
+	deleted, err := r.helper.TryToDelete(ctx, clusterRealm,
+		makeTerminator(clusterRealm.Spec.RealmName, kClient, r.log.WithName("cluster-realm-term")),
+		clusterKeycloakRealmOperatorFinalizerName)
+	if err != nil {
+		return errors.Wrap(err, "error during cluster realm deletion")
+	}
+
+	if deleted {
+		return nil
+	}
+
+	if err := r.chain.ServeRequest(ctx, realm, kClient); err != nil {
+		return errors.Wrap(err, "error during cluster realm chain")
+	}
+
+	return nil
+}
+
+// mapClusterKeycloakToRealms returns requests for cluster realms which refer to the ClusterKeycloak instance.
+func (r *ReconcileClusterKeycloakRealm) mapClusterKeycloakToRealms(kc client.Object) []reconcile.Request {
+	var list keycloakAlpha.ClusterKeycloakRealmList
+	if err := r.client.List(context.Background(), &list); err != nil {
+		r.log.Error(err, "unable to list cluster realms for cluster keycloak", "clusterkeycloak", kc.GetName())
+
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for i := range list.Items {
+		if list.Items[i].Spec.ClusterKeycloakRef == kc.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: list.Items[i].Name},
+			})
+		}
+	}
+
+	return requests
+}
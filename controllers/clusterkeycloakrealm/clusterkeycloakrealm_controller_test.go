@@ -0,0 +1,109 @@
+package clusterkeycloakrealm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	keycloakAlpha "github.com/epam/edp-keycloak-operator/api/v1alpha1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func TestReconcileClusterKeycloakRealm_Reconcile(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakAlpha.AddToScheme(sch))
+
+	clusterRealm := &keycloakAlpha.ClusterKeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "shared",
+		},
+		Spec: keycloakAlpha.ClusterKeycloakRealmSpec{
+			ClusterKeycloakRef: "keycloak",
+			RealmName:          "shared-realm",
+		},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(sch).WithObjects(clusterRealm).Build()
+
+	kClient := new(adapter.Mock)
+	kClient.On("ExistRealm", "shared-realm").Return(false, nil)
+	kClient.On("CreateRealmWithDefaultConfig", testifyMock.Anything).Return(nil)
+
+	h := new(helper.Mock)
+	h.On("CreateKeycloakClientForRealm", &keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName:          "shared-realm",
+			ClusterKeycloakRef: "keycloak",
+		},
+	}).Return(kClient, nil)
+	h.On("TryToDelete", testifyMock.Anything, testifyMock.Anything, clusterKeycloakRealmOperatorFinalizerName).
+		Return(false, nil)
+	h.On("InvalidateClusterKeycloakClientTokenSecret", "keycloak").Return(nil)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
+
+	r := NewReconcile(client, mock.NewLogr(), h)
+	r.successReconcileTimeout = time.Minute
+
+	res, err := r.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: "shared"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, res.RequeueAfter)
+
+	updated := h.Calls[len(h.Calls)-1].Arguments.Get(0).(*keycloakAlpha.ClusterKeycloakRealm)
+	assert.True(t, updated.Status.Available)
+	assert.Equal(t, helper.StatusOK, updated.Status.Value)
+
+	h.AssertExpectations(t)
+	kClient.AssertExpectations(t)
+}
+
+func TestReconcileClusterKeycloakRealm_ReconcileFailure(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakAlpha.AddToScheme(sch))
+
+	clusterRealm := &keycloakAlpha.ClusterKeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "shared",
+		},
+		Spec: keycloakAlpha.ClusterKeycloakRealmSpec{
+			ClusterKeycloakRef: "keycloak",
+			RealmName:          "shared-realm",
+		},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(sch).WithObjects(clusterRealm).Build()
+
+	h := new(helper.Mock)
+	h.On("CreateKeycloakClientForRealm", testifyMock.Anything).
+		Return(nil, errors.New("ClusterKeycloak is not in connected status"))
+	h.On("SetFailureCount", testifyMock.Anything).Return(time.Second)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
+
+	r := NewReconcile(client, mock.NewLogr(), h)
+
+	res, err := r.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: "shared"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, res.RequeueAfter)
+
+	updated := h.Calls[len(h.Calls)-1].Arguments.Get(0).(*keycloakAlpha.ClusterKeycloakRealm)
+	assert.False(t, updated.Status.Available)
+	assert.Contains(t, updated.Status.Value, "ClusterKeycloak is not in connected status")
+}
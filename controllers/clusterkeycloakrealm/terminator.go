@@ -0,0 +1,41 @@
+package clusterkeycloakrealm
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+)
+
+type terminator struct {
+	realmName string
+	kClient   keycloak.Client
+	log       logr.Logger
+}
+
+func (t *terminator) DeleteResource(ctx context.Context) error {
+	log := t.log.WithValues("cluster keycloak realm cr", t.realmName)
+	log.Info("Start deleting keycloak realm...")
+
+	if err := t.kClient.DeleteRealm(ctx, t.realmName); err != nil {
+		return errors.Wrap(err, "unable to delete realm")
+	}
+
+	log.Info("realm deletion done")
+
+	return nil
+}
+
+func (t *terminator) GetLogger() logr.Logger {
+	return t.log
+}
+
+func makeTerminator(realmName string, kClient keycloak.Client, log logr.Logger) *terminator {
+	return &terminator{
+		realmName: realmName,
+		kClient:   kClient,
+		log:       log,
+	}
+}
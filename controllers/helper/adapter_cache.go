@@ -0,0 +1,208 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+// tokenRefreshThreshold is the time before the access token expiration when the token is refreshed.
+const tokenRefreshThreshold = 30 * time.Second
+
+// refreshableClient is a keycloak client which supports admin token refresh.
+type refreshableClient interface {
+	keycloak.Client
+	TokenExpiresAt() (time.Time, error)
+	RefreshToken(ctx context.Context) (*adapter.GoCloakAdapter, error)
+}
+
+// adapterCache keeps ready to use keycloak clients in memory.
+// Clients are keyed by UID of Keycloak or ClusterKeycloak resource
+// and are valid only for the generation of the resource they were created for.
+type adapterCache struct {
+	mu      sync.Mutex
+	clients map[types.UID]cachedClient
+}
+
+type cachedClient struct {
+	client     keycloak.Client
+	generation int64
+}
+
+func newAdapterCache() *adapterCache {
+	return &adapterCache{
+		clients: make(map[types.UID]cachedClient),
+	}
+}
+
+func (c *adapterCache) get(uid types.UID, generation int64) (keycloak.Client, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cl, ok := c.clients[uid]
+	if !ok || cl.generation != generation {
+		return nil, false
+	}
+
+	return cl.client, true
+}
+
+func (c *adapterCache) set(uid types.UID, generation int64, cl keycloak.Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.clients[uid] = cachedClient{client: cl, generation: generation}
+}
+
+func (c *adapterCache) delete(uid types.UID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.clients, uid)
+}
+
+// deleteStale removes the client created for another generation of the resource.
+// It returns true if the client was removed, which means the connection spec has been changed.
+func (c *adapterCache) deleteStale(uid types.UID, generation int64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cl, ok := c.clients[uid]
+	if !ok || cl.generation == generation {
+		return false
+	}
+
+	delete(c.clients, uid)
+
+	return true
+}
+
+// getCachedClient returns cached keycloak client if its token is still valid or was refreshed successfully.
+// If the token can't be refreshed, the client is removed from the cache.
+func (h *Helper) getCachedClient(ctx context.Context, conn *keycloakConnection) (keycloak.Client, bool) {
+	cl, ok := h.adapterCache.get(conn.uid, conn.generation)
+	if !ok {
+		return nil, false
+	}
+
+	rc, ok := cl.(refreshableClient)
+	if !ok {
+		return cl, true
+	}
+
+	expiresAt, err := rc.TokenExpiresAt()
+	if err == nil && time.Until(expiresAt) > tokenRefreshThreshold {
+		return cl, true
+	}
+
+	refreshed, err := rc.RefreshToken(ctx)
+	if err != nil {
+		h.logger.Info("Unable to refresh keycloak admin token, new login is required", "reason", err.Error())
+		h.adapterCache.delete(conn.uid)
+
+		return nil, false
+	}
+
+	h.adapterCache.set(conn.uid, conn.generation, refreshed)
+
+	return refreshed, true
+}
+
+// loginTimeout limits a single login, which is shared by all callers and is not canceled with any of them.
+const loginTimeout = 2 * time.Minute
+
+// loginCall is an in-flight or completed login for a single instance.
+type loginCall struct {
+	done chan struct{}
+	cl   keycloak.Client
+	err  error
+}
+
+// loginGroup deduplicates concurrent logins to the same Keycloak instance.
+// Calls are keyed by UID of Keycloak or ClusterKeycloak resource.
+type loginGroup struct {
+	mu    sync.Mutex
+	calls map[types.UID]*loginCall
+}
+
+func newLoginGroup() *loginGroup {
+	return &loginGroup{
+		calls: make(map[types.UID]*loginCall),
+	}
+}
+
+// do executes fn for the given uid. If fn is already running for the uid,
+// do waits for it and returns its result instead of starting a new one.
+// fn runs on a context detached from the callers, so canceling one caller doesn't fail the others.
+// Each caller stops waiting when its own ctx is done.
+func (g *loginGroup) do(ctx context.Context, uid types.UID,
+	fn func(ctx context.Context) (keycloak.Client, error),
+) (keycloak.Client, error) {
+	g.mu.Lock()
+
+	call, ok := g.calls[uid]
+	if !ok {
+		call = &loginCall{done: make(chan struct{})}
+		g.calls[uid] = call
+
+		go g.run(detachedContext{parent: ctx}, uid, call, fn)
+	}
+
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.cl, call.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for keycloak login: %w", ctx.Err())
+	}
+}
+
+func (g *loginGroup) run(ctx context.Context, uid types.UID, call *loginCall,
+	fn func(ctx context.Context) (keycloak.Client, error),
+) {
+	ctx, cancel := context.WithTimeout(ctx, loginTimeout)
+
+	defer func() {
+		if r := recover(); r != nil {
+			call.cl, call.err = nil, fmt.Errorf("keycloak login panicked: %v", r)
+		}
+
+		cancel()
+
+		g.mu.Lock()
+		delete(g.calls, uid)
+		g.mu.Unlock()
+
+		close(call.done)
+	}()
+
+	call.cl, call.err = fn(ctx)
+}
+
+// detachedContext keeps values of the parent context, e.g. logger, but is never canceled with it.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
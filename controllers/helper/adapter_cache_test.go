@@ -0,0 +1,192 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func TestLoginGroup_do_SameInstance(t *testing.T) {
+	g := newLoginGroup()
+	release := make(chan struct{})
+
+	var calls int32
+
+	fn := func(ctx context.Context) (keycloak.Client, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+
+		return &adapter.Mock{}, nil
+	}
+
+	const callers = 5
+
+	var wg sync.WaitGroup
+
+	results := make([]keycloak.Client, callers)
+
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			cl, err := g.do(context.Background(), "uid", fn)
+			assert.NoError(t, err)
+
+			results[i] = cl
+		}(i)
+	}
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&calls) == 1
+	}, time.Second, time.Millisecond)
+
+	// give other callers time to join the in-flight login
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	for _, cl := range results {
+		assert.Same(t, results[0], cl)
+	}
+
+	_, err := g.do(context.Background(), "uid", func(ctx context.Context) (keycloak.Client, error) {
+		return nil, errors.New("login failed")
+	})
+	require.Error(t, err, "completed login must not be reused")
+}
+
+func TestLoginGroup_do_DifferentInstances(t *testing.T) {
+	g := newLoginGroup()
+	release := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		_, _ = g.do(context.Background(), "slow", func(ctx context.Context) (keycloak.Client, error) {
+			<-release
+
+			return nil, errors.New("unreachable")
+		})
+	}()
+
+	_, err := g.do(context.Background(), "fast", func(ctx context.Context) (keycloak.Client, error) {
+		return &adapter.Mock{}, nil
+	})
+	require.NoError(t, err)
+
+	close(release)
+	<-done
+}
+
+func TestLoginGroup_do_CanceledCaller(t *testing.T) {
+	g := newLoginGroup()
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	var calls int32
+
+	fn := func(ctx context.Context) (keycloak.Client, error) {
+		atomic.AddInt32(&calls, 1)
+		close(started)
+		<-release
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		return &adapter.Mock{}, nil
+	}
+
+	firstCtx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error)
+
+	go func() {
+		_, err := g.do(firstCtx, "uid", fn)
+		firstErr <- err
+	}()
+
+	<-started
+	cancel()
+	require.ErrorIs(t, <-firstErr, context.Canceled)
+
+	// the login started by the first caller is still in flight, so the second caller joins it
+	time.AfterFunc(50*time.Millisecond, func() { close(release) })
+
+	cl, err := g.do(context.Background(), "uid", fn)
+	require.NoError(t, err, "login must not be canceled with the first caller")
+	require.NotNil(t, cl)
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestLoginGroup_do_Panic(t *testing.T) {
+	g := newLoginGroup()
+
+	cl, err := g.do(context.Background(), "uid", func(ctx context.Context) (keycloak.Client, error) {
+		panic("unexpected")
+	})
+	require.Error(t, err)
+	require.Nil(t, cl)
+	require.Contains(t, err.Error(), "keycloak login panicked: unexpected")
+
+	cl, err = g.do(context.Background(), "uid", func(ctx context.Context) (keycloak.Client, error) {
+		return &adapter.Mock{}, nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, cl)
+}
+
+func TestHelper_getCachedClient(t *testing.T) {
+	h := MakeHelper(fake.NewClientBuilder().Build(), runtime.NewScheme(), mock.NewLogr(), "")
+	conn := &keycloakConnection{uid: "uid", generation: 1}
+
+	_, ok := h.getCachedClient(context.Background(), conn)
+	require.False(t, ok)
+
+	cl := &adapter.Mock{}
+	h.adapterCache.set("uid", 1, cl)
+
+	cached, ok := h.getCachedClient(context.Background(), conn)
+	require.True(t, ok)
+	require.Same(t, cl, cached)
+
+	_, ok = h.getCachedClient(context.Background(), &keycloakConnection{uid: "uid", generation: 2})
+	require.False(t, ok, "client created for another generation should not be used")
+
+	h.adapterCache.set("uid", 1, &adapter.GoCloakAdapter{})
+
+	_, ok = h.getCachedClient(context.Background(), conn)
+	require.False(t, ok, "client with unparseable token and no refresh token should be evicted")
+
+	_, ok = h.adapterCache.get("uid", 1)
+	require.False(t, ok)
+}
+
+func TestAdapterCache_deleteStale(t *testing.T) {
+	c := newAdapterCache()
+	require.False(t, c.deleteStale("uid", 1))
+
+	c.set("uid", 1, &adapter.Mock{})
+	require.False(t, c.deleteStale("uid", 1))
+
+	require.True(t, c.deleteStale("uid", 2))
+
+	_, ok := c.get("uid", 1)
+	require.False(t, ok)
+}
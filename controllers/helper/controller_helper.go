@@ -3,7 +3,6 @@ package helper
 import (
 	"context"
 	"fmt"
-	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -18,6 +17,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	keycloakAlpha "github.com/epam/edp-keycloak-operator/api/v1alpha1"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 )
@@ -28,43 +28,47 @@ const (
 	localConfigsRelativePath = "build/configs"
 )
 
-type adapterBuilder func(ctx context.Context, url, user, password, adminType string, log logr.Logger,
+type adapterBuilder func(ctx context.Context, url, servedPath, user, password, adminType, realm string, log logr.Logger,
 	restyClient *resty.Client) (keycloak.Client, error)
 
 type Helper struct {
-	client          client.Client
-	scheme          *runtime.Scheme
-	restyClient     *resty.Client
-	logger          logr.Logger
-	adapterBuilder  adapterBuilder
-	tokenSecretLock *sync.Mutex
-}
-
-func (h *Helper) TokenSecretLock() *sync.Mutex {
-	return h.tokenSecretLock
+	client            client.Client
+	scheme            *runtime.Scheme
+	restyClient       *resty.Client
+	logger            logr.Logger
+	adapterBuilder    adapterBuilder
+	adapterCache      *adapterCache
+	logins            *loginGroup
+	rateLimiters      *rateLimiters
+	operatorNamespace string
 }
 
 func (h *Helper) GetScheme() *runtime.Scheme {
 	return h.scheme
 }
 
-func MakeHelper(client client.Client, scheme *runtime.Scheme, logger logr.Logger) *Helper {
+func MakeHelper(client client.Client, scheme *runtime.Scheme, logger logr.Logger, operatorNamespace string) *Helper {
 	return &Helper{
-		tokenSecretLock: new(sync.Mutex),
-		client:          client,
-		scheme:          scheme,
-		logger:          logger,
+		adapterCache:      newAdapterCache(),
+		logins:            newLoginGroup(),
+		rateLimiters:      newRateLimiters(),
+		client:            client,
+		scheme:            scheme,
+		logger:            logger,
+		operatorNamespace: operatorNamespace,
 		adapterBuilder: func(
 			ctx context.Context,
 			url,
+			servedPath,
 			user,
 			password,
-			adminType string,
+			adminType,
+			realm string,
 			log logr.Logger,
 			restyClient *resty.Client,
 		) (keycloak.Client, error) {
 			if adminType == keycloakApi.KeycloakAdminTypeServiceAccount {
-				goKeycloakAdapter, err := adapter.MakeFromServiceAccount(ctx, url, user, password, "master", log, restyClient)
+				goKeycloakAdapter, err := adapter.MakeFromServiceAccount(ctx, url, servedPath, user, password, realm, log, restyClient)
 				if err != nil {
 					return nil, fmt.Errorf("failed to make go keycloak adapter from seviceaccount: %w", err)
 				}
@@ -72,7 +76,16 @@ func MakeHelper(client client.Client, scheme *runtime.Scheme, logger logr.Logger
 				return goKeycloakAdapter, nil
 			}
 
-			goKeycloakAdapter, err := adapter.Make(ctx, url, user, password, log, restyClient)
+			if adminType == keycloakApi.KeycloakAdminTypePrivateKeyJWT {
+				goKeycloakAdapter, err := adapter.MakeFromPrivateKeyJWT(ctx, url, servedPath, user, password, realm, log, restyClient)
+				if err != nil {
+					return nil, fmt.Errorf("failed to make go keycloak adapter from private key jwt: %w", err)
+				}
+
+				return goKeycloakAdapter, nil
+			}
+
+			goKeycloakAdapter, err := adapter.Make(ctx, url, servedPath, user, password, realm, log, restyClient)
 			if err != nil {
 				return nil, fmt.Errorf("failed to make go keycloak adapter: %w", err)
 			}
@@ -260,7 +273,65 @@ type RealmChild interface {
 	v1.Object
 }
 
+// ClusterRealmChild is implemented by resources which can reference ClusterKeycloakRealm.
+type ClusterRealmChild interface {
+	K8SParentClusterRealmName() string
+}
+
+// getClusterKeycloakRealm returns ClusterKeycloakRealm represented as KeycloakRealm,
+// so child controllers can work with namespaced and cluster realms in the same way.
+// Owner reference is not set because namespaced objects can't be owned by cluster-scoped ones.
+func (h *Helper) getClusterKeycloakRealm(ctx context.Context, name string) (*keycloakApi.KeycloakRealm, error) {
+	var clusterRealm keycloakAlpha.ClusterKeycloakRealm
+	if err := h.client.Get(ctx, types.NamespacedName{Name: name}, &clusterRealm); err != nil {
+		return nil, errors.Wrap(err, "unable to get cluster realm from k8s")
+	}
+
+	return ClusterRealmToRealm(&clusterRealm), nil
+}
+
+// ClusterRealmToRealm converts ClusterKeycloakRealm to KeycloakRealm connected to ClusterKeycloak.
+func ClusterRealmToRealm(clusterRealm *keycloakAlpha.ClusterKeycloakRealm) *keycloakApi.KeycloakRealm {
+	realm := &keycloakApi.KeycloakRealm{
+		ObjectMeta: v1.ObjectMeta{
+			Name: clusterRealm.Name,
+		},
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName:              clusterRealm.Spec.RealmName,
+			ClusterKeycloakRef:     clusterRealm.Spec.ClusterKeycloakRef,
+			FrontendURL:            clusterRealm.Spec.FrontendURL,
+			BrowserFlow:            clusterRealm.Spec.BrowserFlow,
+			BrowserSecurityHeaders: clusterRealm.Spec.BrowserSecurityHeaders,
+		},
+	}
+
+	if clusterRealm.Spec.Themes != nil {
+		themes := keycloakApi.RealmThemes(*clusterRealm.Spec.Themes)
+		realm.Spec.Themes = &themes
+	}
+
+	if clusterRealm.Spec.RealmEventConfig != nil {
+		eventConfig := keycloakApi.RealmEventConfig(*clusterRealm.Spec.RealmEventConfig)
+		realm.Spec.RealmEventConfig = &eventConfig
+	}
+
+	for _, p := range clusterRealm.Spec.PasswordPolicies {
+		realm.Spec.PasswordPolicies = append(realm.Spec.PasswordPolicies, keycloakApi.PasswordPolicy(p))
+	}
+
+	return realm
+}
+
 func (h *Helper) GetOrCreateRealmOwnerRef(object RealmChild, objectMeta *v1.ObjectMeta) (*keycloakApi.KeycloakRealm, error) {
+	if clusterChild, ok := object.(ClusterRealmChild); ok && clusterChild.K8SParentClusterRealmName() != "" {
+		realm, err := h.getClusterKeycloakRealm(context.TODO(), clusterChild.K8SParentClusterRealmName())
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to get cluster realm from spec")
+		}
+
+		return realm, nil
+	}
+
 	realm, err := h.GetOwnerKeycloakRealm(objectMeta)
 	if err != nil {
 		ownerNotFoundErr := OwnerNotFoundError("")
@@ -272,6 +343,10 @@ func (h *Helper) GetOrCreateRealmOwnerRef(object RealmChild, objectMeta *v1.Obje
 				return nil, errors.Wrapf(err, "unable get parent realm for: %+v", object)
 			}
 
+			if parentRealm == "" {
+				return nil, errors.New("either realm or clusterRealmRef must be set")
+			}
+
 			if realm, err = h.getKeycloakRealm(object, parentRealm); err != nil {
 				return nil, errors.Wrap(err, "unable to get keycloak from spec")
 			}
@@ -303,6 +378,27 @@ type Terminator interface {
 	GetLogger() logr.Logger
 }
 
+// deletionPolicyObject is implemented by custom resources which have spec.deletionPolicy.
+type deletionPolicyObject interface {
+	GetDeletionPolicy() string
+}
+
+// GetDeletionPolicy returns deletion policy of the custom resource.
+// DeletionPolicyAnnotation takes precedence over spec.deletionPolicy.
+// Resources without deletion policy are deleted from keycloak.
+func GetDeletionPolicy(obj v1.Object) string {
+	switch policy := obj.GetAnnotations()[keycloakApi.DeletionPolicyAnnotation]; policy {
+	case keycloakApi.DeletionPolicyDelete, keycloakApi.DeletionPolicyRetain:
+		return policy
+	}
+
+	if o, ok := obj.(deletionPolicyObject); ok {
+		return o.GetDeletionPolicy()
+	}
+
+	return keycloakApi.DeletionPolicyDelete
+}
+
 func (h *Helper) TryToDelete(ctx context.Context, obj Deletable, terminator Terminator, finalizer string) (isDeleted bool, resultErr error) {
 	finalizers := obj.GetFinalizers()
 	logger := terminator.GetLogger()
@@ -326,10 +422,14 @@ func (h *Helper) TryToDelete(ctx context.Context, obj Deletable, terminator Term
 		return false, nil
 	}
 
-	logger.Info("terminator deleting resource")
+	if GetDeletionPolicy(obj) == keycloakApi.DeletionPolicyRetain {
+		logger.Info("deletion policy is Retain, keycloak resource is kept")
+	} else {
+		logger.Info("terminator deleting resource")
 
-	if err := terminator.DeleteResource(ctx); err != nil {
-		return false, errors.Wrap(err, "error during keycloak resource deletion")
+		if err := terminator.DeleteResource(ctx); err != nil {
+			return false, errors.Wrap(err, "error during keycloak resource deletion")
+		}
 	}
 
 	logger.Info("terminator removing finalizers")
@@ -346,6 +446,70 @@ func (h *Helper) TryToDelete(ctx context.Context, obj Deletable, terminator Term
 	return true, nil
 }
 
+// TryToReleaseRealmChild removes finalizer from the child resource being deleted without deleting it from keycloak
+// if its realm is deleted with Retain policy or doesn't exist anymore.
+// The realm is found the same way as in GetOrCreateRealmOwnerRef.
+// Child resources are garbage collected together with the realm,
+// so they must not wipe the content of the retained realm or wait for the realm which is already gone.
+func (h *Helper) TryToReleaseRealmChild(ctx context.Context, obj Deletable, realmChild RealmChild, finalizer string) (bool, error) {
+	if obj.GetDeletionTimestamp().IsZero() || !ContainsString(obj.GetFinalizers(), finalizer) {
+		return false, nil
+	}
+
+	log := h.logger.WithValues("name", obj.GetName(), "namespace", obj.GetNamespace())
+
+	parent, err := h.getParentRealm(ctx, obj, realmChild)
+
+	switch {
+	case k8sErrors.IsNotFound(err):
+		log.Info("parent realm doesn't exist, keycloak resource is kept")
+	case err != nil:
+		return false, err
+	case !parent.GetDeletionTimestamp().IsZero() && GetDeletionPolicy(parent) == keycloakApi.DeletionPolicyRetain:
+		log.Info("parent realm is deleted with Retain policy, keycloak resource is kept")
+	default:
+		return false, nil
+	}
+
+	obj.SetFinalizers(RemoveString(obj.GetFinalizers(), finalizer))
+
+	if err := h.client.Update(ctx, obj); err != nil {
+		return false, errors.Wrap(err, "unable to update instance")
+	}
+
+	return true, nil
+}
+
+// getParentRealm returns KeycloakRealm or ClusterKeycloakRealm which the child resource belongs to.
+func (h *Helper) getParentRealm(ctx context.Context, obj v1.Object, realmChild RealmChild) (client.Object, error) {
+	if clusterChild, ok := realmChild.(ClusterRealmChild); ok && clusterChild.K8SParentClusterRealmName() != "" {
+		var clusterRealm keycloakAlpha.ClusterKeycloakRealm
+		if err := h.client.Get(ctx, types.NamespacedName{Name: clusterChild.K8SParentClusterRealmName()}, &clusterRealm); err != nil {
+			return nil, errors.Wrap(err, "unable to get cluster realm from k8s")
+		}
+
+		return &clusterRealm, nil
+	}
+
+	var name string
+
+	if ownerRef := getOwnerRef(obj.GetOwnerReferences(), "KeycloakRealm"); ownerRef != nil {
+		name = ownerRef.Name
+	} else {
+		var err error
+		if name, err = realmChild.K8SParentRealmName(); err != nil {
+			return nil, errors.Wrap(err, "unable to get parent realm name")
+		}
+	}
+
+	var realm keycloakApi.KeycloakRealm
+	if err := h.client.Get(ctx, types.NamespacedName{Name: name, Namespace: obj.GetNamespace()}, &realm); err != nil {
+		return nil, errors.Wrap(err, "unable to get realm from k8s")
+	}
+
+	return &realm, nil
+}
+
 func CreatePathToTemplateDirectory(directory string) string {
 	return fmt.Sprintf("%s/%s", localConfigsRelativePath, directory)
 }
@@ -4,23 +4,50 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/go-resty/resty/v2"
 	"github.com/pkg/errors"
 	coreV1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	keycloakAlpha "github.com/epam/edp-keycloak-operator/api/v1alpha1"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 )
 
 const (
-	keycloakTokenSecretPrefix = "kc-token-"
-	keycloakTokenSecretKey    = "token"
+	keycloakTokenSecretPrefix        = "kc-token-"
+	clusterKeycloakTokenSecretPrefix = "kc-token-cluster-"
+	keycloakTokenSecretKey           = "token"
 )
 
+// keycloakConnection describes connection to Keycloak or ClusterKeycloak instance.
+type keycloakConnection struct {
+	uid               types.UID
+	generation        int64
+	kind              string
+	instance          types.NamespacedName
+	url               string
+	servedPath        string
+	adminType         string
+	adminRealm        string
+	tls               *keycloakApi.KeycloakTLS
+	retry             *keycloakApi.KeycloakRetry
+	rateLimit         *keycloakApi.KeycloakRateLimit
+	namespace         string
+	credentialsSecret types.NamespacedName
+	tokenSecret       types.NamespacedName
+	persistToken      bool
+}
+
 func (h *Helper) CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error) {
+	if realm.Spec.ClusterKeycloakRef != "" {
+		return h.createKeycloakClientForClusterKeycloakRef(ctx, realm.Spec.ClusterKeycloakRef)
+	}
+
 	kc, err := h.GetOrCreateKeycloakOwnerRef(realm)
 	if err != nil {
 		return nil, err
@@ -30,55 +57,201 @@ func (h *Helper) CreateKeycloakClientForRealm(ctx context.Context, realm *keyclo
 		return nil, errors.New("Owner keycloak is not in connected status")
 	}
 
-	h.tokenSecretLock.Lock()
-	defer h.tokenSecretLock.Unlock()
+	return h.CreateKeycloakClientFromKeycloak(ctx, kc)
+}
 
-	clientAdapter, err := h.CreateKeycloakClientFromTokenSecret(ctx, kc)
-	if err == nil {
+func (h *Helper) createKeycloakClientForClusterKeycloakRef(ctx context.Context, name string) (keycloak.Client, error) {
+	var kc keycloakAlpha.ClusterKeycloak
+	if err := h.client.Get(ctx, types.NamespacedName{Name: name}, &kc); err != nil {
+		return nil, errors.Wrap(err, "unable to get cluster keycloak")
+	}
+
+	if !kc.Status.Connected {
+		return nil, errors.New("ClusterKeycloak is not in connected status")
+	}
+
+	return h.CreateKeycloakClientFromClusterKeycloak(ctx, &kc)
+}
+
+// CreateKeycloakClientFromKeycloak creates keycloak client for the Keycloak instance.
+// The client is taken from the in-memory cache if possible.
+func (h *Helper) CreateKeycloakClientFromKeycloak(ctx context.Context, kc *keycloakApi.Keycloak) (keycloak.Client, error) {
+	return h.getOrCreateKeycloakClient(ctx, keycloakConnectionFromKeycloak(kc))
+}
+
+// CreateKeycloakClientFromClusterKeycloak creates keycloak client for the ClusterKeycloak instance.
+// The client is taken from the in-memory cache if possible.
+// Admin credentials and the token secret are kept in the operator namespace.
+func (h *Helper) CreateKeycloakClientFromClusterKeycloak(ctx context.Context, kc *keycloakAlpha.ClusterKeycloak) (keycloak.Client, error) {
+	return h.getOrCreateKeycloakClient(ctx, &keycloakConnection{
+		uid:               kc.UID,
+		generation:        kc.Generation,
+		kind:              "ClusterKeycloak",
+		instance:          types.NamespacedName{Name: kc.Name},
+		url:               kc.Spec.Url,
+		servedPath:        servedPath(kc.Status.Conditions, kc.Generation, kc.Status.BasePath),
+		adminType:         kc.GetAdminType(),
+		adminRealm:        kc.GetAdminRealm(),
+		tls:               clusterKeycloakTLS(kc.Spec.TLS),
+		retry:             clusterKeycloakRetry(kc.Spec.Retry),
+		rateLimit:         clusterKeycloakRateLimit(kc.Spec.RateLimit),
+		namespace:         h.operatorNamespace,
+		credentialsSecret: types.NamespacedName{Namespace: h.operatorNamespace, Name: kc.Spec.Secret},
+		tokenSecret:       types.NamespacedName{Namespace: h.operatorNamespace, Name: clusterTokenSecretName(kc.Name)},
+		persistToken:      kc.Spec.PersistToken,
+	})
+}
+
+func keycloakConnectionFromKeycloak(kc *keycloakApi.Keycloak) *keycloakConnection {
+	return &keycloakConnection{
+		uid:               kc.UID,
+		generation:        kc.Generation,
+		kind:              "Keycloak",
+		instance:          types.NamespacedName{Namespace: kc.Namespace, Name: kc.Name},
+		url:               kc.Spec.Url,
+		servedPath:        servedPath(kc.Status.Conditions, kc.Generation, kc.Status.BasePath),
+		adminType:         kc.GetAdminType(),
+		adminRealm:        kc.GetAdminRealm(),
+		tls:               kc.Spec.TLS,
+		retry:             kc.Spec.Retry,
+		rateLimit:         kc.Spec.RateLimit,
+		namespace:         kc.Namespace,
+		credentialsSecret: types.NamespacedName{Namespace: kc.Namespace, Name: kc.Spec.Secret},
+		tokenSecret:       types.NamespacedName{Namespace: kc.Namespace, Name: tokenSecretName(kc.Name)},
+		persistToken:      kc.Spec.PersistToken,
+	}
+}
+
+// servedPath returns the path Keycloak is served at if it was detected for the current generation of the instance.
+// Empty path means the client mode is detected on login.
+func servedPath(conditions []metav1.Condition, generation int64, basePath string) string {
+	c := meta.FindStatusCondition(conditions, keycloakApi.KeycloakConditionServerInfoAvailable)
+	if c == nil || c.Status != metav1.ConditionTrue || c.ObservedGeneration != generation {
+		return ""
+	}
+
+	return basePath
+}
+
+// getOrCreateKeycloakClient returns cached keycloak client or creates a new one.
+// Concurrent calls for the same instance share a single login, calls for different instances don't block each other.
+func (h *Helper) getOrCreateKeycloakClient(ctx context.Context, conn *keycloakConnection) (keycloak.Client, error) {
+	h.rateLimiters.update(conn)
+
+	return h.logins.do(ctx, conn.uid, func(ctx context.Context) (keycloak.Client, error) {
+		return h.loginKeycloakClient(ctx, conn)
+	})
+}
+
+// loginKeycloakClient returns cached keycloak client or creates a new one.
+// The token secret is used only if token persistence is enabled,
+// otherwise a new client is created from admin credentials.
+func (h *Helper) loginKeycloakClient(ctx context.Context, conn *keycloakConnection) (keycloak.Client, error) {
+	if h.adapterCache.deleteStale(conn.uid, conn.generation) && conn.persistToken {
+		// the token was issued for the previous connection spec
+		if err := h.deleteTokenSecret(ctx, conn.tokenSecret); err != nil {
+			return nil, err
+		}
+	}
+
+	if clientAdapter, ok := h.getCachedClient(ctx, conn); ok {
 		return clientAdapter, nil
 	}
 
-	if !k8sErrors.IsNotFound(err) && !adapter.IsErrTokenExpired(err) {
-		return nil, errors.Wrap(err, "unexpected error")
+	restyClient, err := h.makeRestyClient(ctx, conn)
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure http client for keycloak: %w", err)
 	}
 
-	clientAdapter, err = h.CreateKeycloakClientFromLoginPassword(ctx, kc)
+	if conn.persistToken {
+		clientAdapter, err := h.createKeycloakClientFromTokenSecret(ctx, conn, restyClient)
+		if err == nil {
+			h.adapterCache.set(conn.uid, conn.generation, clientAdapter)
+
+			return clientAdapter, nil
+		}
+
+		if !k8sErrors.IsNotFound(err) && !adapter.IsErrTokenExpired(err) {
+			return nil, errors.Wrap(err, "unexpected error")
+		}
+	} else if err := h.deleteTokenSecret(ctx, conn.tokenSecret); err != nil {
+		// the token could be saved before token persistence was disabled
+		return nil, err
+	}
+
+	clientAdapter, err := h.createKeycloakClientFromLoginPassword(ctx, conn, restyClient)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create kc client from login password")
 	}
 
+	h.adapterCache.set(conn.uid, conn.generation, clientAdapter)
+
 	return clientAdapter, nil
 }
 
+// CreateKeycloakClientFromLoginPassword creates keycloak client from admin credentials.
+// The token is saved to the token secret only if token persistence is enabled.
 func (h *Helper) CreateKeycloakClientFromLoginPassword(ctx context.Context, kc *keycloakApi.Keycloak) (keycloak.Client, error) {
+	conn := keycloakConnectionFromKeycloak(kc)
+
+	restyClient, err := h.makeRestyClient(ctx, conn)
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure http client for keycloak: %w", err)
+	}
+
+	return h.createKeycloakClientFromLoginPassword(ctx, conn, restyClient)
+}
+
+// createKeycloakClientFromLoginPassword creates keycloak client from admin credentials.
+// If token persistence is enabled, the token is saved to the token secret.
+func (h *Helper) createKeycloakClientFromLoginPassword(ctx context.Context, conn *keycloakConnection,
+	restyClient *resty.Client,
+) (keycloak.Client, error) {
 	var secret coreV1.Secret
-	if err := h.client.Get(ctx, types.NamespacedName{
-		Name:      kc.Spec.Secret,
-		Namespace: kc.Namespace,
-	}, &secret); err != nil {
+	if err := h.client.Get(ctx, conn.credentialsSecret, &secret); err != nil {
 		return nil, errors.Wrap(err, "kc login password secret not found")
 	}
 
-	clientAdapter, err := h.CreateKeycloakClient(ctx, kc.Spec.Url, string(secret.Data["username"]),
-		string(secret.Data["password"]), kc.GetAdminType())
+	user, password := adminCredentials(&secret, conn.adminType)
+
+	clientAdapter, err := h.createKeycloakClient(ctx, conn.url, conn.servedPath, user, password, conn.adminType, conn.adminRealm, restyClient)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to init kc client adapter")
 	}
 
+	if !conn.persistToken {
+		return clientAdapter, nil
+	}
+
 	jwtToken, err := clientAdapter.ExportToken()
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to export kc client token")
 	}
 
-	if err := h.SaveKeycloakClientTokenSecret(ctx, kc, jwtToken); err != nil {
+	if err := h.saveTokenSecret(ctx, conn.tokenSecret, jwtToken); err != nil {
 		return nil, errors.Wrap(err, "unable to save kc token to secret")
 	}
 
 	return clientAdapter, nil
 }
 
+// adminCredentials returns user and password from the admin secret.
+// For privateKeyJWT admin type the password is PEM encoded private key.
+func adminCredentials(secret *coreV1.Secret, adminType string) (user, password string) {
+	if adminType == keycloakApi.KeycloakAdminTypePrivateKeyJWT {
+		return string(secret.Data["username"]), string(secret.Data["privateKey"])
+	}
+
+	return string(secret.Data["username"]), string(secret.Data["password"])
+}
+
 func (h *Helper) CreateKeycloakClient(ctx context.Context, url, user, password, adminType string) (keycloak.Client, error) {
-	clientAdapter, err := h.adapterBuilder(ctx, url, user, password, adminType, h.logger, h.restyClient)
+	return h.createKeycloakClient(ctx, url, "", user, password, adminType, keycloakApi.KeycloakDefaultAdminRealm, h.restyClient)
+}
+
+func (h *Helper) createKeycloakClient(ctx context.Context, url, servedPath, user, password, adminType, adminRealm string,
+	restyClient *resty.Client) (keycloak.Client, error) {
+	clientAdapter, err := h.adapterBuilder(ctx, url, servedPath, user, password, adminType, adminRealm, h.logger, restyClient)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to init kc client adapter")
 	}
@@ -86,10 +259,48 @@ func (h *Helper) CreateKeycloakClient(ctx context.Context, url, user, password,
 	return clientAdapter, nil
 }
 
+// InvalidateKeycloakClientTokenSecret removes cached client and the token secret of the Keycloak instance,
+// so the next client is created with a new token.
 func (h *Helper) InvalidateKeycloakClientTokenSecret(ctx context.Context, namespace, rootKeycloakName string) error {
+	var kc keycloakApi.Keycloak
+	if err := h.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: rootKeycloakName}, &kc); err != nil {
+		return errors.Wrap(err, "unable to get keycloak")
+	}
+
+	h.adapterCache.delete(kc.UID)
+
+	return h.deleteTokenSecret(ctx, types.NamespacedName{Namespace: namespace, Name: tokenSecretName(rootKeycloakName)})
+}
+
+// InvalidateClusterKeycloakClientTokenSecret removes cached client and the token secret of the ClusterKeycloak instance.
+func (h *Helper) InvalidateClusterKeycloakClientTokenSecret(ctx context.Context, clusterKeycloakName string) error {
+	var kc keycloakAlpha.ClusterKeycloak
+	if err := h.client.Get(ctx, types.NamespacedName{Name: clusterKeycloakName}, &kc); err != nil {
+		return errors.Wrap(err, "unable to get cluster keycloak")
+	}
+
+	h.adapterCache.delete(kc.UID)
+
+	return h.deleteTokenSecret(ctx, types.NamespacedName{
+		Namespace: h.operatorNamespace,
+		Name:      clusterTokenSecretName(clusterKeycloakName),
+	})
+}
+
+// ForgetKeycloakClient removes cached client and rate limiter of the deleted Keycloak or ClusterKeycloak instance.
+func (h *Helper) ForgetKeycloakClient(uid types.UID) {
+	h.adapterCache.delete(uid)
+	h.rateLimiters.delete(uid)
+}
+
+// deleteTokenSecret removes the token secret. Missing secret is not an error because token persistence is optional.
+func (h *Helper) deleteTokenSecret(ctx context.Context, nsn types.NamespacedName) error {
 	var secret coreV1.Secret
-	if err := h.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: tokenSecretName(rootKeycloakName)},
-		&secret); err != nil {
+	if err := h.client.Get(ctx, nsn, &secret); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil
+		}
+
 		return errors.Wrap(err, "unable to get client token secret")
 	}
 
@@ -101,9 +312,13 @@ func (h *Helper) InvalidateKeycloakClientTokenSecret(ctx context.Context, namesp
 }
 
 func (h *Helper) SaveKeycloakClientTokenSecret(ctx context.Context, kc *keycloakApi.Keycloak, token []byte) error {
+	return h.saveTokenSecret(ctx, types.NamespacedName{Namespace: kc.Namespace, Name: tokenSecretName(kc.Name)}, token)
+}
+
+func (h *Helper) saveTokenSecret(ctx context.Context, nsn types.NamespacedName, token []byte) error {
 	var secret coreV1.Secret
 
-	err := h.client.Get(ctx, types.NamespacedName{Namespace: kc.Namespace, Name: tokenSecretName(kc.Name)}, &secret)
+	err := h.client.Get(ctx, nsn, &secret)
 	if err == nil {
 		secret.Data = map[string][]byte{
 			keycloakTokenSecretKey: token,
@@ -118,8 +333,8 @@ func (h *Helper) SaveKeycloakClientTokenSecret(ctx context.Context, kc *keycloak
 
 	if k8sErrors.IsNotFound(err) {
 		secret = coreV1.Secret{ObjectMeta: metav1.ObjectMeta{
-			Namespace: kc.Namespace,
-			Name:      tokenSecretName(kc.Name),
+			Namespace: nsn.Namespace,
+			Name:      nsn.Name,
 		}, Data: map[string][]byte{
 			keycloakTokenSecretKey: token,
 		}}
@@ -135,15 +350,32 @@ func (h *Helper) SaveKeycloakClientTokenSecret(ctx context.Context, kc *keycloak
 }
 
 func (h *Helper) CreateKeycloakClientFromTokenSecret(ctx context.Context, kc *keycloakApi.Keycloak) (keycloak.Client, error) {
+	conn := keycloakConnectionFromKeycloak(kc)
+
+	restyClient, err := h.makeRestyClient(ctx, conn)
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure http client for keycloak: %w", err)
+	}
+
+	return h.createKeycloakClientFromTokenSecret(ctx, conn, restyClient)
+}
+
+// createKeycloakClientFromTokenSecret creates keycloak client from the persisted token.
+// Admin credentials are required to refresh the token with the same client and realm it was issued by.
+func (h *Helper) createKeycloakClientFromTokenSecret(ctx context.Context, conn *keycloakConnection,
+	restyClient *resty.Client) (keycloak.Client, error) {
 	var tokenSecret coreV1.Secret
-	if err := h.client.Get(ctx, types.NamespacedName{
-		Name:      tokenSecretName(kc.Name),
-		Namespace: kc.Namespace,
-	}, &tokenSecret); err != nil {
+	if err := h.client.Get(ctx, conn.tokenSecret, &tokenSecret); err != nil {
 		return nil, errors.Wrap(err, "unable to get token secret")
 	}
 
-	clientAdapter, err := adapter.MakeFromToken(kc.Spec.Url, tokenSecret.Data[keycloakTokenSecretKey], h.logger)
+	var secret coreV1.Secret
+	if err := h.client.Get(ctx, conn.credentialsSecret, &secret); err != nil {
+		return nil, errors.Wrap(err, "kc login password secret not found")
+	}
+
+	clientAdapter, err := adapter.MakeFromToken(conn.url, conn.servedPath, tokenSecret.Data[keycloakTokenSecretKey],
+		tokenCredentials(&secret, conn.adminType, conn.adminRealm), h.logger, restyClient)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to make kc client from token")
 	}
@@ -151,6 +383,24 @@ func (h *Helper) CreateKeycloakClientFromTokenSecret(ctx context.Context, kc *ke
 	return clientAdapter, nil
 }
 
+// tokenCredentials returns credentials of the client which issues admin tokens for the given admin type.
+func tokenCredentials(secret *coreV1.Secret, adminType, adminRealm string) adapter.TokenCredentials {
+	user, password := adminCredentials(secret, adminType)
+
+	switch adminType {
+	case keycloakApi.KeycloakAdminTypeServiceAccount:
+		return adapter.TokenCredentials{Realm: adminRealm, ClientID: user, ClientSecret: password}
+	case keycloakApi.KeycloakAdminTypePrivateKeyJWT:
+		return adapter.TokenCredentials{Realm: adminRealm, ClientID: user, PrivateKey: password}
+	default:
+		return adapter.TokenCredentials{Realm: adminRealm}
+	}
+}
+
 func tokenSecretName(keycloakName string) string {
 	return fmt.Sprintf("%s%s", keycloakTokenSecretPrefix, keycloakName)
 }
+
+func clusterTokenSecretName(clusterKeycloakName string) string {
+	return fmt.Sprintf("%s%s", clusterKeycloakTokenSecretPrefix, clusterKeycloakName)
+}
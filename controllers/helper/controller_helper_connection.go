@@ -0,0 +1,33 @@
+package helper
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	keycloakAlpha "github.com/epam/edp-keycloak-operator/api/v1alpha1"
+)
+
+// IsConnectionUpdated returns true if connection status or admin credentials of Keycloak or ClusterKeycloak were updated.
+// It is used by dependent controllers to requeue resources after re-authentication.
+func IsConnectionUpdated(e event.UpdateEvent) bool {
+	switch oo := e.ObjectOld.(type) {
+	case *keycloakApi.Keycloak:
+		no, ok := e.ObjectNew.(*keycloakApi.Keycloak)
+		if !ok {
+			return false
+		}
+
+		return oo.Status.Connected != no.Status.Connected ||
+			oo.Status.CredentialsVersion != no.Status.CredentialsVersion
+	case *keycloakAlpha.ClusterKeycloak:
+		no, ok := e.ObjectNew.(*keycloakAlpha.ClusterKeycloak)
+		if !ok {
+			return false
+		}
+
+		return oo.Status.Connected != no.Status.Connected ||
+			oo.Status.CredentialsVersion != no.Status.CredentialsVersion
+	default:
+		return false
+	}
+}
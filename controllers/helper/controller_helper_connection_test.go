@@ -0,0 +1,71 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	keycloakAlpha "github.com/epam/edp-keycloak-operator/api/v1alpha1"
+)
+
+func TestIsConnectionUpdated(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		e    event.UpdateEvent
+		want bool
+	}{
+		{
+			name: "keycloak connected",
+			e: event.UpdateEvent{
+				ObjectOld: &keycloakApi.Keycloak{},
+				ObjectNew: &keycloakApi.Keycloak{Status: keycloakApi.KeycloakStatus{Connected: true}},
+			},
+			want: true,
+		},
+		{
+			name: "keycloak credentials rotated",
+			e: event.UpdateEvent{
+				ObjectOld: &keycloakApi.Keycloak{Status: keycloakApi.KeycloakStatus{Connected: true, CredentialsVersion: "1"}},
+				ObjectNew: &keycloakApi.Keycloak{Status: keycloakApi.KeycloakStatus{Connected: true, CredentialsVersion: "2"}},
+			},
+			want: true,
+		},
+		{
+			name: "keycloak not changed",
+			e: event.UpdateEvent{
+				ObjectOld: &keycloakApi.Keycloak{Status: keycloakApi.KeycloakStatus{Connected: true, Version: "21"}},
+				ObjectNew: &keycloakApi.Keycloak{Status: keycloakApi.KeycloakStatus{Connected: true, Version: "22"}},
+			},
+			want: false,
+		},
+		{
+			name: "cluster keycloak credentials rotated",
+			e: event.UpdateEvent{
+				ObjectOld: &keycloakAlpha.ClusterKeycloak{Status: keycloakAlpha.ClusterKeycloakStatus{CredentialsVersion: "1"}},
+				ObjectNew: &keycloakAlpha.ClusterKeycloak{Status: keycloakAlpha.ClusterKeycloakStatus{CredentialsVersion: "2"}},
+			},
+			want: true,
+		},
+		{
+			name: "unsupported object",
+			e: event.UpdateEvent{
+				ObjectOld: &keycloakApi.KeycloakRealm{},
+				ObjectNew: &keycloakApi.KeycloakRealm{},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, IsConnectionUpdated(tt.e))
+		})
+	}
+}
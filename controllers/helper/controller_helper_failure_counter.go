@@ -3,7 +3,16 @@ package helper
 import (
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+)
+
+const (
+	ReasonReconciled      = "Reconciled"
+	ReasonReconcileFailed = "ReconcileFailed"
 )
 
 type FailureCountable interface {
@@ -21,6 +30,14 @@ type StatusValueFailureCountable interface {
 	StatusValue
 }
 
+// ConditionsObject is a resource with Ready, Synced and Degraded status conditions.
+type ConditionsObject interface {
+	GetGeneration() int64
+	GetConditions() []metav1.Condition
+	SetConditions(conditions []metav1.Condition)
+	SetObservedGeneration(generation int64)
+}
+
 func (h *Helper) SetFailureCount(fc FailureCountable) time.Duration {
 	failures := fc.GetFailureCount()
 
@@ -29,6 +46,15 @@ func (h *Helper) SetFailureCount(fc FailureCountable) time.Duration {
 	failures += 1
 	fc.SetFailureCount(failures)
 
+	if obj, ok := fc.(ConditionsObject); ok {
+		message := "reconciliation failed"
+		if sv, ok := fc.(StatusValue); ok && sv.GetStatus() != "" {
+			message = sv.GetStatus()
+		}
+
+		SetFailedConditions(obj, message)
+	}
+
 	return timeout
 }
 
@@ -53,4 +79,49 @@ func IsFailuresUpdated(e event.UpdateEvent) bool {
 func SetSuccessStatus(el StatusValueFailureCountable) {
 	el.SetStatus(StatusOK)
 	el.SetFailureCount(0)
+
+	if obj, ok := el.(ConditionsObject); ok {
+		SetSucceededConditions(obj)
+	}
+}
+
+// SetSucceededConditions marks the resource as Ready and Synced for its current generation.
+func SetSucceededConditions(obj ConditionsObject) {
+	const message = "Resource is reconciled"
+
+	setConditions(obj,
+		metav1.Condition{Type: keycloakApi.ConditionReady, Status: metav1.ConditionTrue, Reason: ReasonReconciled, Message: message},
+		metav1.Condition{Type: keycloakApi.ConditionSynced, Status: metav1.ConditionTrue, Reason: ReasonReconciled, Message: message},
+		metav1.Condition{Type: keycloakApi.ConditionDegraded, Status: metav1.ConditionFalse, Reason: ReasonReconciled, Message: message},
+	)
+}
+
+// SetFailedConditions marks the resource as not Synced and Degraded with the given message.
+// Ready is kept if the current generation has already been reconciled, e.g. when periodic resync fails.
+func SetFailedConditions(obj ConditionsObject, message string) {
+	ready := metav1.Condition{Type: keycloakApi.ConditionReady, Status: metav1.ConditionFalse, Reason: ReasonReconcileFailed, Message: message}
+
+	current := meta.FindStatusCondition(obj.GetConditions(), keycloakApi.ConditionReady)
+	if current != nil && current.Status == metav1.ConditionTrue && current.ObservedGeneration == obj.GetGeneration() {
+		ready = *current
+	}
+
+	setConditions(obj,
+		ready,
+		metav1.Condition{Type: keycloakApi.ConditionSynced, Status: metav1.ConditionFalse, Reason: ReasonReconcileFailed, Message: message},
+		metav1.Condition{Type: keycloakApi.ConditionDegraded, Status: metav1.ConditionTrue, Reason: ReasonReconcileFailed, Message: message},
+	)
+}
+
+func setConditions(obj ConditionsObject, conditions ...metav1.Condition) {
+	generation := obj.GetGeneration()
+	current := obj.GetConditions()
+
+	for _, c := range conditions {
+		c.ObservedGeneration = generation
+		meta.SetStatusCondition(&current, c)
+	}
+
+	obj.SetConditions(current)
+	obj.SetObservedGeneration(generation)
 }
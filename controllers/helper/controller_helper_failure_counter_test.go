@@ -0,0 +1,73 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+)
+
+func TestSetSuccessStatus(t *testing.T) {
+	group := keycloakApi.KeycloakRealmGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "group", Generation: 2},
+		Status:     keycloakApi.KeycloakRealmGroupStatus{Value: "fail", FailureCount: 3},
+	}
+
+	SetSuccessStatus(&group)
+
+	assert.Equal(t, StatusOK, group.Status.Value)
+	assert.Equal(t, int64(0), group.Status.FailureCount)
+	assert.Equal(t, int64(2), group.Status.ObservedGeneration)
+	assert.True(t, meta.IsStatusConditionTrue(group.Status.Conditions, keycloakApi.ConditionReady))
+	assert.True(t, meta.IsStatusConditionTrue(group.Status.Conditions, keycloakApi.ConditionSynced))
+	assert.True(t, meta.IsStatusConditionFalse(group.Status.Conditions, keycloakApi.ConditionDegraded))
+
+	ready := meta.FindStatusCondition(group.Status.Conditions, keycloakApi.ConditionReady)
+	require.NotNil(t, ready)
+	assert.Equal(t, int64(2), ready.ObservedGeneration)
+	assert.Equal(t, ReasonReconciled, ready.Reason)
+}
+
+func TestHelper_SetFailureCount_Conditions(t *testing.T) {
+	h := Helper{}
+
+	group := keycloakApi.KeycloakRealmGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "group", Generation: 1},
+		Status:     keycloakApi.KeycloakRealmGroupStatus{Value: "unable to sync group"},
+	}
+
+	h.SetFailureCount(&group)
+
+	assert.Equal(t, int64(1), group.Status.FailureCount)
+	assert.True(t, meta.IsStatusConditionFalse(group.Status.Conditions, keycloakApi.ConditionReady))
+	assert.True(t, meta.IsStatusConditionFalse(group.Status.Conditions, keycloakApi.ConditionSynced))
+	assert.True(t, meta.IsStatusConditionTrue(group.Status.Conditions, keycloakApi.ConditionDegraded))
+
+	synced := meta.FindStatusCondition(group.Status.Conditions, keycloakApi.ConditionSynced)
+	require.NotNil(t, synced)
+	assert.Equal(t, "unable to sync group", synced.Message)
+	assert.Equal(t, ReasonReconcileFailed, synced.Reason)
+}
+
+func TestSetFailedConditions_KeepsReadyForReconciledGeneration(t *testing.T) {
+	group := keycloakApi.KeycloakRealmGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "group", Generation: 1},
+	}
+
+	SetSuccessStatus(&group)
+	SetFailedConditions(&group, "keycloak is unavailable")
+
+	assert.True(t, meta.IsStatusConditionTrue(group.Status.Conditions, keycloakApi.ConditionReady))
+	assert.True(t, meta.IsStatusConditionFalse(group.Status.Conditions, keycloakApi.ConditionSynced))
+	assert.True(t, meta.IsStatusConditionTrue(group.Status.Conditions, keycloakApi.ConditionDegraded))
+
+	group.Generation = 2
+	SetFailedConditions(&group, "invalid spec")
+
+	assert.True(t, meta.IsStatusConditionFalse(group.Status.Conditions, keycloakApi.ConditionReady))
+	assert.Equal(t, int64(2), group.Status.ObservedGeneration)
+}
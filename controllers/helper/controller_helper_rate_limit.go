@@ -0,0 +1,114 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	keycloakAlpha "github.com/epam/edp-keycloak-operator/api/v1alpha1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+// RequestQueueDepth is a number of requests to Keycloak waiting for the rate limiter or a free concurrency slot.
+// It should be registered in the controller-runtime metrics registry.
+var RequestQueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "keycloak_operator",
+	Name:      "request_queue_depth",
+	Help:      "Number of requests to Keycloak waiting for the client-side rate limiter.",
+}, []string{"kind", "namespace", "name"})
+
+// rateLimiters keeps rate limiters of Keycloak instances.
+// Limiters outlive cached clients, so the limits are kept across re-logins,
+// and spec changes are applied to already created clients.
+type rateLimiters struct {
+	mu       sync.RWMutex
+	limiters map[types.UID]*adapter.RateLimiter
+}
+
+func newRateLimiters() *rateLimiters {
+	return &rateLimiters{
+		limiters: make(map[types.UID]*adapter.RateLimiter),
+	}
+}
+
+// update sets rate limiter of the instance according to the connection settings.
+// The limiter is recreated only if the settings are changed.
+func (r *rateLimiters) update(conn *keycloakConnection) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if conn.rateLimit == nil {
+		delete(r.limiters, conn.uid)
+
+		return
+	}
+
+	policy := adapter.RateLimitPolicy(*conn.rateLimit)
+
+	if l, ok := r.limiters[conn.uid]; ok && l.Policy() == policy {
+		return
+	}
+
+	r.limiters[conn.uid] = adapter.NewRateLimiter(policy,
+		RequestQueueDepth.WithLabelValues(conn.kind, conn.instance.Namespace, conn.instance.Name))
+}
+
+func (r *rateLimiters) delete(uid types.UID) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.limiters, uid)
+}
+
+func (r *rateLimiters) get(uid types.UID) *adapter.RateLimiter {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.limiters[uid]
+}
+
+// instanceRateLimiter looks up the current rate limiter of the instance on each request.
+type instanceRateLimiter struct {
+	uid      types.UID
+	limiters *rateLimiters
+}
+
+func (l instanceRateLimiter) Wait(ctx context.Context) (func(), error) {
+	limiter := l.limiters.get(l.uid)
+	if limiter == nil {
+		return func() {}, nil
+	}
+
+	release, err := limiter.Wait(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to wait for keycloak rate limiter: %w", err)
+	}
+
+	return release, nil
+}
+
+func clusterKeycloakRateLimit(in *keycloakAlpha.KeycloakRateLimit) *keycloakApi.KeycloakRateLimit {
+	if in == nil {
+		return nil
+	}
+
+	out := keycloakApi.KeycloakRateLimit(*in)
+
+	return &out
+}
@@ -0,0 +1,56 @@
+package helper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	keycloakAlpha "github.com/epam/edp-keycloak-operator/api/v1alpha1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestRateLimiters_update(t *testing.T) {
+	t.Parallel()
+
+	limiters := newRateLimiters()
+	conn := &keycloakConnection{
+		uid:       "uid",
+		kind:      "Keycloak",
+		instance:  types.NamespacedName{Namespace: "ns", Name: "kc"},
+		rateLimit: &keycloakApi.KeycloakRateLimit{RequestsPerSecond: 10},
+	}
+
+	limiters.update(conn)
+	first := limiters.get("uid")
+	require.NotNil(t, first)
+	assert.Equal(t, adapter.RateLimitPolicy{RequestsPerSecond: 10}, first.Policy())
+
+	limiters.update(conn)
+	assert.Same(t, first, limiters.get("uid"), "limiter must be kept if settings are not changed")
+
+	conn.rateLimit = &keycloakApi.KeycloakRateLimit{RequestsPerSecond: 10, MaxConcurrentRequests: 5}
+	limiters.update(conn)
+	assert.NotSame(t, first, limiters.get("uid"))
+
+	conn.rateLimit = nil
+	limiters.update(conn)
+	assert.Nil(t, limiters.get("uid"))
+
+	release, err := instanceRateLimiter{uid: "uid", limiters: limiters}.Wait(context.Background())
+	require.NoError(t, err)
+	release()
+}
+
+func TestClusterKeycloakRateLimit(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, clusterKeycloakRateLimit(nil))
+	assert.Equal(t,
+		&keycloakApi.KeycloakRateLimit{RequestsPerSecond: 5, Burst: 10, MaxConcurrentRequests: 2},
+		clusterKeycloakRateLimit(&keycloakAlpha.KeycloakRateLimit{RequestsPerSecond: 5, Burst: 10, MaxConcurrentRequests: 2}),
+	)
+}
@@ -0,0 +1,41 @@
+package helper
+
+import (
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	keycloakAlpha "github.com/epam/edp-keycloak-operator/api/v1alpha1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+// retryPolicy converts Keycloak retry settings to the adapter retry policy.
+// Unset fields are taken from the default policy.
+func retryPolicy(retry *keycloakApi.KeycloakRetry) adapter.RetryPolicy {
+	policy := adapter.DefaultRetryPolicy()
+
+	if retry == nil {
+		return policy
+	}
+
+	if retry.MaxRetries != nil {
+		policy.MaxRetries = *retry.MaxRetries
+	}
+
+	if retry.WaitTime != nil {
+		policy.WaitTime = retry.WaitTime.Duration
+	}
+
+	if retry.MaxWaitTime != nil {
+		policy.MaxWaitTime = retry.MaxWaitTime.Duration
+	}
+
+	return policy
+}
+
+func clusterKeycloakRetry(in *keycloakAlpha.KeycloakRetry) *keycloakApi.KeycloakRetry {
+	if in == nil {
+		return nil
+	}
+
+	out := keycloakApi.KeycloakRetry(*in)
+
+	return &out
+}
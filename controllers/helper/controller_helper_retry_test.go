@@ -0,0 +1,46 @@
+package helper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	keycloakAlpha "github.com/epam/edp-keycloak-operator/api/v1alpha1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, adapter.DefaultRetryPolicy(), retryPolicy(nil))
+
+	noRetries := 0
+	policy := retryPolicy(&keycloakApi.KeycloakRetry{
+		MaxRetries: &noRetries,
+		WaitTime:   &metav1.Duration{Duration: time.Second},
+	})
+
+	assert.Equal(t, adapter.RetryPolicy{
+		MaxRetries:  0,
+		WaitTime:    time.Second,
+		MaxWaitTime: adapter.DefaultRetryPolicy().MaxWaitTime,
+	}, policy)
+}
+
+func TestClusterKeycloakRetry(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, clusterKeycloakRetry(nil))
+
+	maxRetries := 5
+	assert.Equal(t, &keycloakApi.KeycloakRetry{
+		MaxRetries:  &maxRetries,
+		MaxWaitTime: &metav1.Duration{Duration: time.Minute},
+	}, clusterKeycloakRetry(&keycloakAlpha.KeycloakRetry{
+		MaxRetries:  &maxRetries,
+		MaxWaitTime: &metav1.Duration{Duration: time.Minute},
+	}))
+}
@@ -19,9 +19,11 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	keycloakAlpha "github.com/epam/edp-keycloak-operator/api/v1alpha1"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
 	"github.com/epam/edp-keycloak-operator/pkg/fakehttp"
 )
@@ -32,7 +34,7 @@ func TestHelper_GetOrCreateRealmOwnerRef(t *testing.T) {
 	sch := runtime.NewScheme()
 	utilruntime.Must(keycloakApi.AddToScheme(sch))
 
-	helper := MakeHelper(&mc, sch, mock.NewLogr())
+	helper := MakeHelper(&mc, sch, mock.NewLogr(), "")
 
 	kcGroup := keycloakApi.KeycloakRealmGroup{
 		ObjectMeta: metav1.ObjectMeta{
@@ -78,7 +80,7 @@ func TestHelper_GetOrCreateRealmOwnerRef_Failure(t *testing.T) {
 	sch := runtime.NewScheme()
 	utilruntime.Must(keycloakApi.AddToScheme(sch))
 
-	helper := MakeHelper(&mc, sch, mock.NewLogr())
+	helper := MakeHelper(&mc, sch, mock.NewLogr(), "")
 
 	kcGroup := keycloakApi.KeycloakRealmGroup{
 		ObjectMeta: metav1.ObjectMeta{
@@ -126,13 +128,75 @@ func TestHelper_GetOrCreateRealmOwnerRef_Failure(t *testing.T) {
 	assert.ErrorIs(t, err, mockErr)
 }
 
+func TestHelper_GetOrCreateRealmOwnerRef_NoRealm(t *testing.T) {
+	mc := K8SClientMock{}
+
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	helper := MakeHelper(&mc, sch, mock.NewLogr(), "")
+
+	kcGroup := keycloakApi.KeycloakRealmGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+		},
+	}
+
+	_, err := helper.GetOrCreateRealmOwnerRef(&kcGroup, &kcGroup.ObjectMeta)
+	require.Error(t, err)
+	assert.Equal(t, "either realm or clusterRealmRef must be set", err.Error())
+	mc.AssertNotCalled(t, "Get")
+}
+
+func TestHelper_GetOrCreateRealmOwnerRef_ClusterRealm(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+	utilruntime.Must(keycloakAlpha.AddToScheme(sch))
+
+	clusterRealm := &keycloakAlpha.ClusterKeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "shared",
+		},
+		Spec: keycloakAlpha.ClusterKeycloakRealmSpec{
+			ClusterKeycloakRef: "keycloak",
+			RealmName:          "shared-realm",
+		},
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(sch).WithObjects(clusterRealm).Build()
+	helper := MakeHelper(cl, sch, mock.NewLogr(), "")
+
+	kcRole := keycloakApi.KeycloakRealmRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+		},
+		Spec: keycloakApi.KeycloakRealmRoleSpec{
+			Name:            "role",
+			ClusterRealmRef: "shared",
+		},
+	}
+
+	realm, err := helper.GetOrCreateRealmOwnerRef(&kcRole, &kcRole.ObjectMeta)
+	require.NoError(t, err)
+	assert.Equal(t, "shared", realm.Name)
+	assert.Equal(t, "shared-realm", realm.Spec.RealmName)
+	assert.Equal(t, "keycloak", realm.Spec.ClusterKeycloakRef)
+	assert.Empty(t, kcRole.OwnerReferences)
+
+	kcRole.Spec.ClusterRealmRef = "not-found"
+
+	_, err = helper.GetOrCreateRealmOwnerRef(&kcRole, &kcRole.ObjectMeta)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to get cluster realm")
+}
+
 func TestHelper_GetOrCreateKeycloakOwnerRef(t *testing.T) {
 	mc := K8SClientMock{}
 
 	sch := runtime.NewScheme()
 	utilruntime.Must(keycloakApi.AddToScheme(sch))
 
-	helper := MakeHelper(&mc, sch, mock.NewLogr())
+	helper := MakeHelper(&mc, sch, mock.NewLogr(), "")
 
 	realm := keycloakApi.KeycloakRealm{
 		ObjectMeta: metav1.ObjectMeta{
@@ -179,7 +243,7 @@ func TestHelper_GetOrCreateKeycloakOwnerRef_Failure(t *testing.T) {
 	sch := runtime.NewScheme()
 	utilruntime.Must(keycloakApi.AddToScheme(sch))
 
-	helper := MakeHelper(&mc, sch, mock.NewLogr())
+	helper := MakeHelper(&mc, sch, mock.NewLogr(), "")
 
 	realm := keycloakApi.KeycloakRealm{}
 
@@ -280,17 +344,36 @@ func TestMakeHelper(t *testing.T) {
 	defer mockServer.Close()
 
 	logger := mock.NewLogr()
-	h := MakeHelper(nil, nil, logger)
-	_, err := h.adapterBuilder(context.Background(), mockServer.GetURL(), "foo", "bar",
-		keycloakApi.KeycloakAdminTypeServiceAccount, logger, rCl)
+	h := MakeHelper(nil, nil, logger, "")
+	_, err := h.adapterBuilder(context.Background(), mockServer.GetURL(), "", "foo", "bar",
+		keycloakApi.KeycloakAdminTypeServiceAccount, keycloakApi.KeycloakDefaultAdminRealm, logger, rCl)
 	require.NoError(t, err)
 }
 
+func TestMakeHelper_PrivateKeyJWT(t *testing.T) {
+	mockServer := fakehttp.NewServerBuilder().
+		AddStringResponder("/realms/admins/protocol/openid-connect/token/", "{}").
+		BuildAndStart()
+	defer mockServer.Close()
+
+	_, keyPEM := generateTestCert(t)
+
+	logger := mock.NewLogr()
+	h := MakeHelper(nil, nil, logger, "")
+	_, err := h.adapterBuilder(context.Background(), mockServer.GetURL(), "", "foo", string(keyPEM),
+		keycloakApi.KeycloakAdminTypePrivateKeyJWT, "admins", logger, resty.New())
+	require.NoError(t, err)
+
+	_, err = h.adapterBuilder(context.Background(), mockServer.GetURL(), "", "foo", "invalid key",
+		keycloakApi.KeycloakAdminTypePrivateKeyJWT, "admins", logger, resty.New())
+	require.Error(t, err)
+}
+
 func TestHelper_CreateKeycloakClient(t *testing.T) {
 	mc := K8SClientMock{}
 
 	utilruntime.Must(keycloakApi.AddToScheme(scheme.Scheme))
-	helper := MakeHelper(&mc, scheme.Scheme, mock.NewLogr())
+	helper := MakeHelper(&mc, scheme.Scheme, mock.NewLogr(), "")
 	realm := keycloakApi.KeycloakRealm{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: "test",
@@ -376,3 +459,174 @@ func TestHelper_TryToDelete(t *testing.T) {
 		t.Fatalf("wrong error returned: %s", err.Error())
 	}
 }
+
+func TestHelper_TryToDelete_RetainPolicy(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	realm := keycloakApi.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{
+		Name:              "realm",
+		Namespace:         "ns",
+		Finalizers:        []string{"fin"},
+		DeletionTimestamp: &metav1.Time{Time: time.Now()},
+	}}
+	h := Helper{client: fake.NewClientBuilder().WithScheme(sch).WithObjects(&realm).Build()}
+	term := testTerminator{log: mock.NewLogr(), err: errors.New("must not be called")}
+
+	deleted, err := h.TryToDelete(context.Background(), &realm, &term, "fin")
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	assert.Empty(t, realm.Finalizers)
+}
+
+func TestHelper_TryToReleaseRealmChild(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+	utilruntime.Must(keycloakAlpha.AddToScheme(sch))
+
+	deletedAt := &metav1.Time{Time: time.Now()}
+
+	tests := []struct {
+		name         string
+		group        keycloakApi.KeycloakRealmGroup
+		objects      []client.Object
+		wantReleased bool
+	}{
+		{
+			name: "foreground deletion of retained realm",
+			group: keycloakApi.KeycloakRealmGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "group", Namespace: "ns", Finalizers: []string{"fin"}, DeletionTimestamp: deletedAt},
+				Spec:       keycloakApi.KeycloakRealmGroupSpec{Realm: "realm"},
+			},
+			objects: []client.Object{&keycloakApi.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{
+				Name: "realm", Namespace: "ns", Finalizers: []string{"foregroundDeletion"}, DeletionTimestamp: deletedAt,
+			}}},
+			wantReleased: true,
+		},
+		{
+			name: "background deletion, realm is already gone",
+			group: keycloakApi.KeycloakRealmGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "group", Namespace: "ns", Finalizers: []string{"fin"}, DeletionTimestamp: deletedAt,
+					OwnerReferences: []metav1.OwnerReference{{Kind: "KeycloakRealm", Name: "realm"}},
+				},
+			},
+			wantReleased: true,
+		},
+		{
+			name: "retained cluster realm is deleted",
+			group: keycloakApi.KeycloakRealmGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "group", Namespace: "ns", Finalizers: []string{"fin"}, DeletionTimestamp: deletedAt},
+				Spec:       keycloakApi.KeycloakRealmGroupSpec{ClusterRealmRef: "cluster-realm"},
+			},
+			objects: []client.Object{&keycloakAlpha.ClusterKeycloakRealm{ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster-realm", Finalizers: []string{"foregroundDeletion"}, DeletionTimestamp: deletedAt,
+			}}},
+			wantReleased: true,
+		},
+		{
+			name: "realm is deleted with Delete policy",
+			group: keycloakApi.KeycloakRealmGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "group", Namespace: "ns", Finalizers: []string{"fin"}, DeletionTimestamp: deletedAt},
+				Spec:       keycloakApi.KeycloakRealmGroupSpec{Realm: "realm"},
+			},
+			objects: []client.Object{&keycloakApi.KeycloakRealm{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "realm", Namespace: "ns", Finalizers: []string{"foregroundDeletion"}, DeletionTimestamp: deletedAt,
+				},
+				Spec: keycloakApi.KeycloakRealmSpec{DeletionPolicy: keycloakApi.DeletionPolicyDelete},
+			}},
+			wantReleased: false,
+		},
+		{
+			name: "retained realm is not deleted",
+			group: keycloakApi.KeycloakRealmGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "group", Namespace: "ns", Finalizers: []string{"fin"}, DeletionTimestamp: deletedAt},
+				Spec:       keycloakApi.KeycloakRealmGroupSpec{Realm: "realm"},
+			},
+			objects:      []client.Object{&keycloakApi.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{Name: "realm", Namespace: "ns"}}},
+			wantReleased: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			group := tt.group
+			h := Helper{
+				client: fake.NewClientBuilder().WithScheme(sch).WithObjects(append(tt.objects, &group)...).Build(),
+				logger: mock.NewLogr(),
+			}
+
+			released, err := h.TryToReleaseRealmChild(context.Background(), &group, &group, "fin")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantReleased, released)
+
+			if tt.wantReleased {
+				assert.Empty(t, group.Finalizers)
+			} else {
+				assert.Equal(t, []string{"fin"}, group.Finalizers)
+			}
+		})
+	}
+}
+
+func TestGetDeletionPolicy(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  Deletable
+		want string
+	}{
+		{
+			name: "realm is retained by default",
+			obj:  &keycloakApi.KeycloakRealm{},
+			want: keycloakApi.DeletionPolicyRetain,
+		},
+		{
+			name: "cluster realm is retained by default",
+			obj:  &keycloakAlpha.ClusterKeycloakRealm{},
+			want: keycloakApi.DeletionPolicyRetain,
+		},
+		{
+			name: "client is deleted by default",
+			obj:  &keycloakApi.KeycloakClient{},
+			want: keycloakApi.DeletionPolicyDelete,
+		},
+		{
+			name: "spec policy",
+			obj: &keycloakApi.KeycloakRealmGroup{Spec: keycloakApi.KeycloakRealmGroupSpec{
+				DeletionPolicy: keycloakApi.DeletionPolicyRetain,
+			}},
+			want: keycloakApi.DeletionPolicyRetain,
+		},
+		{
+			name: "annotation overrides spec",
+			obj: &keycloakApi.KeycloakRealm{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+					keycloakApi.DeletionPolicyAnnotation: keycloakApi.DeletionPolicyDelete,
+				}},
+				Spec: keycloakApi.KeycloakRealmSpec{DeletionPolicy: keycloakApi.DeletionPolicyRetain},
+			},
+			want: keycloakApi.DeletionPolicyDelete,
+		},
+		{
+			name: "invalid annotation is ignored",
+			obj: &keycloakApi.KeycloakRealmRole{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+				keycloakApi.DeletionPolicyAnnotation: "Orphan",
+			}}},
+			want: keycloakApi.DeletionPolicyDelete,
+		},
+		{
+			name: "resource without policy",
+			obj:  &corev1.Secret{},
+			want: keycloakApi.DeletionPolicyDelete,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GetDeletionPolicy(tt.obj))
+		})
+	}
+}
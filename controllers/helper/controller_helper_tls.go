@@ -0,0 +1,150 @@
+package helper
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	keycloakAlpha "github.com/epam/edp-keycloak-operator/api/v1alpha1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+// makeRestyClient returns a new resty client configured with Keycloak TLS settings, retry policy and rate limiter.
+// ConfigMaps and Secrets referenced in the TLS settings are taken from the connection namespace.
+func (h *Helper) makeRestyClient(ctx context.Context, conn *keycloakConnection) (*resty.Client, error) {
+	restyClient := resty.New()
+
+	if conn.tls != nil {
+		tlsConfig, err := h.makeTLSConfig(ctx, conn.tls, conn.namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		restyClient.SetTLSClientConfig(tlsConfig)
+	}
+
+	adapter.ConfigureRetry(restyClient, retryPolicy(conn.retry))
+
+	return adapter.ConfigureRateLimit(restyClient, instanceRateLimiter{uid: conn.uid, limiters: h.rateLimiters}), nil
+}
+
+func (h *Helper) makeTLSConfig(ctx context.Context, tlsSpec *keycloakApi.KeycloakTLS, namespace string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: tlsSpec.InsecureSkipVerify,
+	}
+
+	if tlsSpec.CACert != nil {
+		caBundle, err := h.getCACert(ctx, tlsSpec.CACert, namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+
+		if !rootCAs.AppendCertsFromPEM(caBundle) {
+			return nil, errors.New("no valid PEM certificates found in CA bundle")
+		}
+
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if tlsSpec.ClientCertSecret != "" {
+		var secret coreV1.Secret
+		if err := h.client.Get(ctx, types.NamespacedName{
+			Namespace: namespace,
+			Name:      tlsSpec.ClientCertSecret,
+		}, &secret); err != nil {
+			return nil, fmt.Errorf("failed to get client certificate secret: %w", err)
+		}
+
+		clientCert, err := tls.X509KeyPair(secret.Data[coreV1.TLSCertKey], secret.Data[coreV1.TLSPrivateKeyKey])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse client certificate from secret %s: %w", tlsSpec.ClientCertSecret, err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
+	return tlsConfig, nil
+}
+
+func (h *Helper) getCACert(ctx context.Context, source *keycloakApi.CACertSource, namespace string) ([]byte, error) {
+	if source.ConfigMapKeyRef != nil && source.SecretKeyRef != nil {
+		return nil, errors.New("only one of configMapKeyRef or secretKeyRef should be specified for CA certificate")
+	}
+
+	if source.ConfigMapKeyRef != nil {
+		var configMap coreV1.ConfigMap
+		if err := h.client.Get(ctx, types.NamespacedName{
+			Namespace: namespace,
+			Name:      source.ConfigMapKeyRef.Name,
+		}, &configMap); err != nil {
+			return nil, fmt.Errorf("failed to get CA certificate config map: %w", err)
+		}
+
+		caBundle, ok := configMap.Data[source.ConfigMapKeyRef.Key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in config map %s", source.ConfigMapKeyRef.Key, source.ConfigMapKeyRef.Name)
+		}
+
+		return []byte(caBundle), nil
+	}
+
+	if source.SecretKeyRef != nil {
+		var secret coreV1.Secret
+		if err := h.client.Get(ctx, types.NamespacedName{
+			Namespace: namespace,
+			Name:      source.SecretKeyRef.Name,
+		}, &secret); err != nil {
+			return nil, fmt.Errorf("failed to get CA certificate secret: %w", err)
+		}
+
+		caBundle, ok := secret.Data[source.SecretKeyRef.Key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in secret %s", source.SecretKeyRef.Key, source.SecretKeyRef.Name)
+		}
+
+		return caBundle, nil
+	}
+
+	return nil, errors.New("configMapKeyRef or secretKeyRef should be specified for CA certificate")
+}
+
+// clusterKeycloakTLS converts ClusterKeycloak TLS settings to the Keycloak ones.
+func clusterKeycloakTLS(in *keycloakAlpha.KeycloakTLS) *keycloakApi.KeycloakTLS {
+	if in == nil {
+		return nil
+	}
+
+	out := &keycloakApi.KeycloakTLS{
+		ClientCertSecret:   in.ClientCertSecret,
+		InsecureSkipVerify: in.InsecureSkipVerify,
+	}
+
+	if in.CACert != nil {
+		out.CACert = &keycloakApi.CACertSource{}
+
+		if in.CACert.ConfigMapKeyRef != nil {
+			selector := keycloakApi.KeySelector(*in.CACert.ConfigMapKeyRef)
+			out.CACert.ConfigMapKeyRef = &selector
+		}
+
+		if in.CACert.SecretKeyRef != nil {
+			selector := keycloakApi.KeySelector(*in.CACert.SecretKeyRef)
+			out.CACert.SecretKeyRef = &selector
+		}
+	}
+
+	return out
+}
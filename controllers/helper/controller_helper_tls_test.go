@@ -0,0 +1,221 @@
+package helper
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	keycloakAlpha "github.com/epam/edp-keycloak-operator/api/v1alpha1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func TestHelper_makeRestyClient(t *testing.T) {
+	certPEM, keyPEM := generateTestCert(t)
+
+	serverCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{serverCert}, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		tlsSpec    *keycloakApi.KeycloakTLS
+		objects    []client.Object
+		wantErr    require.ErrorAssertionFunc
+		requestErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "CA from config map",
+			tlsSpec: &keycloakApi.KeycloakTLS{
+				CACert: &keycloakApi.CACertSource{
+					ConfigMapKeyRef: &keycloakApi.KeySelector{Name: "ca", Key: "ca.crt"},
+				},
+			},
+			objects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: "ns"},
+					Data:       map[string]string{"ca.crt": string(certPEM)},
+				},
+			},
+			wantErr:    require.NoError,
+			requestErr: require.NoError,
+		},
+		{
+			name: "CA from secret with client certificate",
+			tlsSpec: &keycloakApi.KeycloakTLS{
+				CACert: &keycloakApi.CACertSource{
+					SecretKeyRef: &keycloakApi.KeySelector{Name: "ca", Key: "ca.crt"},
+				},
+				ClientCertSecret: "client-cert",
+			},
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: "ns"},
+					Data:       map[string][]byte{"ca.crt": certPEM},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "client-cert", Namespace: "ns"},
+					Data: map[string][]byte{
+						corev1.TLSCertKey:       certPEM,
+						corev1.TLSPrivateKeyKey: keyPEM,
+					},
+				},
+			},
+			wantErr:    require.NoError,
+			requestErr: require.NoError,
+		},
+		{
+			name:       "insecure skip verify",
+			tlsSpec:    &keycloakApi.KeycloakTLS{InsecureSkipVerify: true},
+			wantErr:    require.NoError,
+			requestErr: require.NoError,
+		},
+		{
+			name:       "unknown CA",
+			tlsSpec:    &keycloakApi.KeycloakTLS{},
+			wantErr:    require.NoError,
+			requestErr: require.Error,
+		},
+		{
+			name: "CA config map not found",
+			tlsSpec: &keycloakApi.KeycloakTLS{
+				CACert: &keycloakApi.CACertSource{
+					ConfigMapKeyRef: &keycloakApi.KeySelector{Name: "ca", Key: "ca.crt"},
+				},
+			},
+			wantErr: require.Error,
+		},
+		{
+			name: "both CA sources are specified",
+			tlsSpec: &keycloakApi.KeycloakTLS{
+				CACert: &keycloakApi.CACertSource{
+					ConfigMapKeyRef: &keycloakApi.KeySelector{Name: "ca", Key: "ca.crt"},
+					SecretKeyRef:    &keycloakApi.KeySelector{Name: "ca", Key: "ca.crt"},
+				},
+			},
+			wantErr: require.Error,
+		},
+		{
+			name: "invalid client certificate",
+			tlsSpec: &keycloakApi.KeycloakTLS{
+				ClientCertSecret: "client-cert",
+			},
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "client-cert", Namespace: "ns"},
+					Data:       map[string][]byte{corev1.TLSCertKey: []byte("invalid")},
+				},
+			},
+			wantErr: require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			sch := runtime.NewScheme()
+			utilruntime.Must(corev1.AddToScheme(sch))
+
+			cl := fake.NewClientBuilder().WithScheme(sch).WithObjects(tt.objects...).Build()
+			h := MakeHelper(cl, sch, mock.NewLogr(), "")
+			noRetries := 0
+
+			restyClient, err := h.makeRestyClient(context.Background(), &keycloakConnection{
+				tls:       tt.tlsSpec,
+				retry:     &keycloakApi.KeycloakRetry{MaxRetries: &noRetries},
+				namespace: "ns",
+			})
+			tt.wantErr(t, err)
+
+			if err != nil {
+				return
+			}
+
+			_, err = restyClient.R().Get(server.URL)
+			tt.requestErr(t, err)
+		})
+	}
+}
+
+func TestHelper_makeRestyClient_NoTLS(t *testing.T) {
+	h := MakeHelper(fake.NewClientBuilder().Build(), runtime.NewScheme(), mock.NewLogr(), "")
+
+	restyClient, err := h.makeRestyClient(context.Background(), &keycloakConnection{namespace: "ns"})
+	require.NoError(t, err)
+	require.NotNil(t, restyClient)
+	assert.Equal(t, adapter.DefaultRetryPolicy().MaxRetries, restyClient.RetryCount)
+}
+
+func TestClusterKeycloakTLS(t *testing.T) {
+	assert.Nil(t, clusterKeycloakTLS(nil))
+
+	assert.Equal(t, &keycloakApi.KeycloakTLS{
+		CACert: &keycloakApi.CACertSource{
+			SecretKeyRef: &keycloakApi.KeySelector{Name: "ca", Key: "ca.crt"},
+		},
+		ClientCertSecret:   "client-cert",
+		InsecureSkipVerify: true,
+	}, clusterKeycloakTLS(&keycloakAlpha.KeycloakTLS{
+		CACert: &keycloakAlpha.CACertSource{
+			SecretKeyRef: &keycloakAlpha.KeySelector{Name: "ca", Key: "ca.crt"},
+		},
+		ClientCertSecret:   "client-cert",
+		InsecureSkipVerify: true,
+	}))
+}
+
+func generateTestCert(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "keycloak"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
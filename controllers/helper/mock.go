@@ -2,21 +2,21 @@ package helper
 
 import (
 	"context"
-	"sync"
 	"time"
 
 	"github.com/stretchr/testify/mock"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	keycloakAlpha "github.com/epam/edp-keycloak-operator/api/v1alpha1"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
 )
 
 type Mock struct {
 	mock.Mock
-	tokenSecretLock sync.Mutex
 }
 
 func (m *Mock) TryToDelete(_ context.Context, obj Deletable, terminator Terminator, finalizer string) (isDeleted bool, resultErr error) {
@@ -28,6 +28,19 @@ func (m *Mock) TryToDelete(_ context.Context, obj Deletable, terminator Terminat
 	return called.Bool(0), nil
 }
 
+func (m *Mock) TryToReleaseRealmChild(_ context.Context, obj Deletable, realmChild RealmChild, finalizer string) (bool, error) {
+	called := m.Called(obj, realmChild, finalizer)
+	if err := called.Error(1); err != nil {
+		return false, err
+	}
+
+	return called.Bool(0), nil
+}
+
+func (m *Mock) ForgetKeycloakClient(uid types.UID) {
+	m.Called(uid)
+}
+
 func (m *Mock) SetFailureCount(fc FailureCountable) time.Duration {
 	return m.Called(fc).Get(0).(time.Duration)
 }
@@ -88,6 +101,15 @@ func (m *Mock) CreateKeycloakClientFromTokenSecret(ctx context.Context, kc *keyc
 	return called.Get(0).(keycloak.Client), nil
 }
 
+func (m *Mock) CreateKeycloakClientFromKeycloak(ctx context.Context, kc *keycloakApi.Keycloak) (keycloak.Client, error) {
+	called := m.Called(kc)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(keycloak.Client), nil
+}
+
 func (m *Mock) CreateKeycloakClientFromLoginPassword(ctx context.Context, kc *keycloakApi.Keycloak) (keycloak.Client, error) {
 	called := m.Called(kc)
 	if err := called.Error(1); err != nil {
@@ -101,6 +123,15 @@ func (m *Mock) InvalidateKeycloakClientTokenSecret(ctx context.Context, namespac
 	return m.Called(namespace, rootKeycloakName).Error(0)
 }
 
-func (m *Mock) TokenSecretLock() *sync.Mutex {
-	return &m.tokenSecretLock
+func (m *Mock) InvalidateClusterKeycloakClientTokenSecret(ctx context.Context, clusterKeycloakName string) error {
+	return m.Called(clusterKeycloakName).Error(0)
+}
+
+func (m *Mock) CreateKeycloakClientFromClusterKeycloak(ctx context.Context, kc *keycloakAlpha.ClusterKeycloak) (keycloak.Client, error) {
+	called := m.Called(kc)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(keycloak.Client), nil
 }
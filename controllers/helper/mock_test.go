@@ -50,6 +50,18 @@ func TestMock_CreateKeycloakClientFromLoginPassword(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestMock_CreateKeycloakClientFromKeycloak(t *testing.T) {
+	m := Mock{}
+	kc := keycloakApi.Keycloak{}
+	m.On("CreateKeycloakClientFromKeycloak", &kc).Return(&adapter.Mock{}, nil).Once()
+	_, err := m.CreateKeycloakClientFromKeycloak(context.Background(), &kc)
+	require.NoError(t, err)
+
+	m.On("CreateKeycloakClientFromKeycloak", &kc).Return(nil, errors.New("fatal")).Once()
+	_, err = m.CreateKeycloakClientFromKeycloak(context.Background(), &kc)
+	require.Error(t, err)
+}
+
 func TestMock_CreateKeycloakClientFromTokenSecret(t *testing.T) {
 	m := Mock{}
 	kc := keycloakApi.Keycloak{}
@@ -108,10 +120,3 @@ func TestMock_OneLiners(t *testing.T) {
 		t.Fatal("wrong owner")
 	}
 }
-
-func TestMock_TokenSecretLock(t *testing.T) {
-	m := Mock{}
-	if m.TokenSecretLock() != &m.tokenSecretLock {
-		t.Fatal("wrong token secret lock")
-	}
-}
@@ -3,24 +3,28 @@ package keycloak
 import (
 	"context"
 	"fmt"
-	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	pkgErrors "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
 	"github.com/epam/edp-keycloak-operator/controllers/helper"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
-	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 )
 
 const (
@@ -28,9 +32,9 @@ const (
 )
 
 type Helper interface {
-	CreateKeycloakClientFromTokenSecret(ctx context.Context, kc *keycloakApi.Keycloak) (keycloak.Client, error)
-	CreateKeycloakClientFromLoginPassword(ctx context.Context, kc *keycloakApi.Keycloak) (keycloak.Client, error)
-	TokenSecretLock() *sync.Mutex
+	CreateKeycloakClientFromKeycloak(ctx context.Context, kc *keycloakApi.Keycloak) (keycloak.Client, error)
+	InvalidateKeycloakClientTokenSecret(ctx context.Context, namespace, rootKeycloakName string) error
+	ForgetKeycloakClient(uid types.UID)
 }
 
 func NewReconcileKeycloak(client client.Client, scheme *runtime.Scheme, log logr.Logger, helper Helper) *ReconcileKeycloak {
@@ -55,13 +59,12 @@ func (r *ReconcileKeycloak) SetupWithManager(mgr ctrl.Manager, successReconcileT
 	r.successReconcileTimeout = successReconcileTimeout
 
 	pred := predicate.Funcs{
-		DeleteFunc: func(e event.DeleteEvent) bool {
-			return false
-		},
+		DeleteFunc: r.forgetDeleted,
 	}
 
 	err := ctrl.NewControllerManagedBy(mgr).
-		For(&keycloakApi.Keycloak{}, builder.WithPredicates(pred)).
+		For(&keycloakApi.Keycloak{}, builder.WithPredicates(pred, predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.mapSecretToKeycloaks)).
 		Complete(r)
 	if err != nil {
 		return fmt.Errorf("failed to setup Keycloak controller: %w", err)
@@ -73,6 +76,15 @@ func (r *ReconcileKeycloak) SetupWithManager(mgr ctrl.Manager, successReconcileT
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloaks,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloaks/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloaks/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=configmaps,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=secrets,verbs=get;list;watch
+
+// forgetDeleted drops cached client and rate limiter of the deleted instance. Deleted instances aren't reconciled.
+func (r *ReconcileKeycloak) forgetDeleted(e event.DeleteEvent) bool {
+	r.helper.ForgetKeycloakClient(e.Object.GetUID())
+
+	return false
+}
 
 // Reconcile is a loop for reconciling Keycloak object.
 func (r *ReconcileKeycloak) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
@@ -105,6 +117,10 @@ func (r *ReconcileKeycloak) Reconcile(ctx context.Context, request reconcile.Req
 
 func (r *ReconcileKeycloak) tryToReconcile(ctx context.Context, instance *keycloakApi.Keycloak,
 	request reconcile.Request) error {
+	if err := r.checkCredentialsRotation(ctx, instance); err != nil {
+		return pkgErrors.Wrap(err, "unable to check admin credentials rotation")
+	}
+
 	if err := r.updateConnectionStatusToKeycloak(ctx, instance); err != nil {
 		return pkgErrors.Wrap(err, "unable to update connection status to keycloak")
 	}
@@ -125,14 +141,9 @@ func (r *ReconcileKeycloak) updateConnectionStatusToKeycloak(ctx context.Context
 	log := r.log.WithValues(keycloakCRLogKey, instance)
 	log.Info("Start updating connection status to Keycloak")
 
-	connected, err := r.isInstanceConnected(ctx, instance, log)
-	if err != nil {
-		return pkgErrors.Wrap(err, "error during kc checking connection")
-	}
+	r.setConnectionStatus(ctx, instance, log)
 
-	instance.Status.Connected = connected
-
-	err = r.client.Status().Update(ctx, instance)
+	err := r.client.Status().Update(ctx, instance)
 	if err != nil {
 		log.Error(err, "unable to update keycloak cr status")
 
@@ -148,26 +159,110 @@ func (r *ReconcileKeycloak) updateConnectionStatusToKeycloak(ctx context.Context
 	return nil
 }
 
-func (r *ReconcileKeycloak) isInstanceConnected(ctx context.Context, instance *keycloakApi.Keycloak,
-	logger logr.Logger) (bool, error) {
-	r.helper.TokenSecretLock().Lock()
-	defer r.helper.TokenSecretLock().Unlock()
+// setConnectionStatus checks connection to Keycloak and fills the instance status with server info and conditions.
+func (r *ReconcileKeycloak) setConnectionStatus(ctx context.Context, instance *keycloakApi.Keycloak, logger logr.Logger) {
+	kClient, err := r.helper.CreateKeycloakClientFromKeycloak(ctx, instance)
+	if err != nil {
+		logger.Error(err, "error during the creation of connection")
 
-	_, err := r.helper.CreateKeycloakClientFromTokenSecret(ctx, instance)
-	if err == nil {
-		return true, nil
+		instance.Status.Connected = false
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:               keycloakApi.KeycloakConditionConnected,
+			Status:             metav1.ConditionFalse,
+			Reason:             "ConnectionFailed",
+			Message:            err.Error(),
+			ObservedGeneration: instance.Generation,
+		})
+		helper.SetFailedConditions(instance, err.Error())
+
+		return
 	}
 
-	if !errors.IsNotFound(err) && !adapter.IsErrTokenExpired(err) {
-		return false, fmt.Errorf("failed to create keycloak client from token: %w", err)
+	now := metav1.Now()
+	instance.Status.Connected = true
+	instance.Status.LastConnected = &now
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:               keycloakApi.KeycloakConditionConnected,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Connected",
+		Message:            "Successfully connected to Keycloak",
+		ObservedGeneration: instance.Generation,
+	})
+	helper.SetSucceededConditions(instance)
+
+	info, err := kClient.GetServerInfo(ctx)
+	if err != nil {
+		logger.Error(err, "unable to get keycloak server info")
+
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:               keycloakApi.KeycloakConditionServerInfoAvailable,
+			Status:             metav1.ConditionFalse,
+			Reason:             "ServerInfoFailed",
+			Message:            err.Error(),
+			ObservedGeneration: instance.Generation,
+		})
+
+		return
 	}
 
-	_, err = r.helper.CreateKeycloakClientFromLoginPassword(ctx, instance)
-	if err != nil {
-		logger.Error(err, "error during the creation of connection")
+	instance.Status.Version = info.Version
+	instance.Status.BasePath = info.BasePath
+	instance.Status.Features = info.Features
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:               keycloakApi.KeycloakConditionServerInfoAvailable,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ServerInfoRetrieved",
+		Message:            fmt.Sprintf("Keycloak version %s", info.Version),
+		ObservedGeneration: instance.Generation,
+	})
+}
+
+// checkCredentialsRotation invalidates cached keycloak client if the admin credentials secret
+// was changed since the last connection.
+func (r *ReconcileKeycloak) checkCredentialsRotation(ctx context.Context, instance *keycloakApi.Keycloak) error {
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: instance.Spec.Secret}, secret); err != nil {
+		if errors.IsNotFound(err) {
+			// connection check reports the missing secret.
+			return nil
+		}
+
+		return pkgErrors.Wrap(err, "unable to get admin credentials secret")
+	}
+
+	if instance.Status.CredentialsVersion != "" && instance.Status.CredentialsVersion != secret.ResourceVersion {
+		r.log.Info("Admin credentials secret has been changed, invalidating keycloak client", "secret", secret.Name)
+
+		if err := r.helper.InvalidateKeycloakClientTokenSecret(ctx, instance.Namespace, instance.Name); err != nil {
+			return pkgErrors.Wrap(err, "unable to invalidate keycloak client")
+		}
+	}
+
+	instance.Status.CredentialsVersion = secret.ResourceVersion
+
+	return nil
+}
+
+// mapSecretToKeycloaks returns requests for Keycloak instances which use the secret as admin credentials.
+func (r *ReconcileKeycloak) mapSecretToKeycloaks(secret client.Object) []reconcile.Request {
+	var list keycloakApi.KeycloakList
+	if err := r.client.List(context.Background(), &list, client.InNamespace(secret.GetNamespace())); err != nil {
+		r.log.Error(err, "unable to list keycloaks for secret", "secret", secret.GetName())
+
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for i := range list.Items {
+		if list.Items[i].Spec.Secret == secret.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: list.Items[i].Namespace, Name: list.Items[i].Name},
+			})
+		}
 	}
 
-	return err == nil, nil
+	return requests
 }
 
 func (r *ReconcileKeycloak) isStatusConnected(ctx context.Context, request reconcile.Request) (bool, error) {
@@ -6,15 +6,18 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
@@ -72,9 +75,7 @@ func TestReconcileKeycloak_ReconcileInvalidSpec(t *testing.T) {
 
 	logger := mock.NewLogr()
 	h := helper.Mock{}
-	h.On("CreateKeycloakClientFromTokenSecret", cr).
-		Return(nil, adapter.TokenExpiredError("token expired"))
-	h.On("CreateKeycloakClientFromLoginPassword", cr).Return(nil, errors.New("fatal"))
+	h.On("CreateKeycloakClientFromKeycloak", testifyMock.Anything).Return(nil, errors.New("fatal"))
 
 	r := ReconcileKeycloak{
 		client: cl,
@@ -166,9 +167,7 @@ func TestReconcileKeycloak_Reconcile_FailureUpdateConnectionStatusToKeycloak(t *
 
 	logger := mock.NewLogr()
 	h := helper.Mock{}
-	h.On("CreateKeycloakClientFromTokenSecret", cr).
-		Return(nil, adapter.TokenExpiredError("token expired"))
-	h.On("CreateKeycloakClientFromLoginPassword", cr).Return(nil,
+	h.On("CreateKeycloakClientFromKeycloak", cr).Return(nil,
 		errors.New(`secrets "keycloak-secret" not found`))
 
 	r := ReconcileKeycloak{
@@ -215,9 +214,7 @@ func TestReconcileKeycloak_Reconcile_FailureIsStatusConnected(t *testing.T) {
 	cl.On("Get", types.NamespacedName{Namespace: kc.Namespace, Name: kc.Spec.Secret},
 		&corev1.Secret{}).Return(nil)
 
-	hm.On("CreateKeycloakClientFromTokenSecret", &kc).
-		Return(nil, adapter.TokenExpiredError("token expired"))
-	hm.On("CreateKeycloakClientFromLoginPassword", &kc).Return(&kClMock, nil)
+	hm.On("CreateKeycloakClientFromKeycloak", &kc).Return(&kClMock, nil)
 
 	cl.On("Get", rq.NamespacedName, &keycloakApi.Keycloak{}).
 		Return(errors.New("isStatusConnected fatal")).Once()
@@ -230,3 +227,127 @@ func TestReconcileKeycloak_Reconcile_FailureIsStatusConnected(t *testing.T) {
 	require.Error(t, loggerSink.LastError())
 	assert.Contains(t, loggerSink.LastError().Error(), "isStatusConnected fatal")
 }
+
+func TestReconcileKeycloak_Reconcile_ServerInfo(t *testing.T) {
+	sch := runtime.NewScheme()
+	require.NoError(t, keycloakApi.AddToScheme(sch))
+	require.NoError(t, corev1.AddToScheme(sch))
+
+	tests := []struct {
+		name           string
+		serverInfo     *adapter.ServerInfo
+		serverInfoErr  error
+		wantVersion    string
+		wantInfoStatus metav1.ConditionStatus
+	}{
+		{
+			name:           "server info is available",
+			serverInfo:     &adapter.ServerInfo{Version: "22.0.5", BasePath: "/", Features: []string{"token-exchange"}},
+			wantVersion:    "22.0.5",
+			wantInfoStatus: metav1.ConditionTrue,
+		},
+		{
+			name:           "server info is not available",
+			serverInfoErr:  errors.New("forbidden"),
+			wantInfoStatus: metav1.ConditionFalse,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			kc := &keycloakApi.Keycloak{
+				ObjectMeta: metav1.ObjectMeta{Name: "kc", Namespace: "ns"},
+				Spec:       keycloakApi.KeycloakSpec{Url: "https://some", Secret: "keycloak-secret"},
+			}
+			cl := fake.NewClientBuilder().WithScheme(sch).WithObjects(kc).Build()
+
+			kClient := adapter.Mock{}
+			kClient.On("GetServerInfo").Return(tt.serverInfo, tt.serverInfoErr)
+
+			h := helper.Mock{}
+			h.On("CreateKeycloakClientFromKeycloak", testifyMock.Anything).Return(&kClient, nil)
+
+			r := NewReconcileKeycloak(cl, sch, mock.NewLogr(), &h)
+
+			_, err := r.Reconcile(context.Background(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: kc.Name, Namespace: kc.Namespace},
+			})
+			require.NoError(t, err)
+
+			got := &keycloakApi.Keycloak{}
+			require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: kc.Name, Namespace: kc.Namespace}, got))
+
+			assert.True(t, got.Status.Connected)
+			assert.NotNil(t, got.Status.LastConnected)
+			assert.Equal(t, tt.wantVersion, got.Status.Version)
+			assert.True(t, meta.IsStatusConditionTrue(got.Status.Conditions, keycloakApi.KeycloakConditionConnected))
+
+			infoCondition := meta.FindStatusCondition(got.Status.Conditions, keycloakApi.KeycloakConditionServerInfoAvailable)
+			require.NotNil(t, infoCondition)
+			assert.Equal(t, tt.wantInfoStatus, infoCondition.Status)
+		})
+	}
+}
+
+func TestReconcileKeycloak_Reconcile_CredentialsRotation(t *testing.T) {
+	sch := runtime.NewScheme()
+	require.NoError(t, keycloakApi.AddToScheme(sch))
+	require.NoError(t, corev1.AddToScheme(sch))
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "keycloak-secret", Namespace: "ns"},
+		Data:       map[string][]byte{"username": []byte("user"), "password": []byte("pass")},
+	}
+	kc := &keycloakApi.Keycloak{
+		ObjectMeta: metav1.ObjectMeta{Name: "kc", Namespace: "ns"},
+		Spec:       keycloakApi.KeycloakSpec{Url: "https://some", Secret: secret.Name},
+	}
+	otherKc := &keycloakApi.Keycloak{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-kc", Namespace: "ns"},
+		Spec:       keycloakApi.KeycloakSpec{Url: "https://some", Secret: "other-secret"},
+	}
+	cl := fake.NewClientBuilder().WithScheme(sch).WithObjects(kc, otherKc, secret).Build()
+
+	kClient := adapter.Mock{}
+	kClient.On("GetServerInfo").Return(&adapter.ServerInfo{Version: "22.0.5"}, nil)
+
+	h := helper.Mock{}
+	h.On("CreateKeycloakClientFromKeycloak", testifyMock.Anything).Return(&kClient, nil)
+
+	r := NewReconcileKeycloak(cl, sch, mock.NewLogr(), &h)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: kc.Name, Namespace: kc.Namespace}}
+
+	_, err := r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	got := &keycloakApi.Keycloak{}
+	require.NoError(t, cl.Get(context.Background(), req.NamespacedName, got))
+	require.NotEmpty(t, got.Status.CredentialsVersion)
+
+	secret.Data["password"] = []byte("new-pass")
+	require.NoError(t, cl.Update(context.Background(), secret))
+
+	assert.Equal(t, []reconcile.Request{req}, r.mapSecretToKeycloaks(secret))
+
+	h.On("InvalidateKeycloakClientTokenSecret", kc.Namespace, kc.Name).Return(nil).Once()
+
+	_, err = r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	require.NoError(t, cl.Get(context.Background(), req.NamespacedName, got))
+	assert.Equal(t, secret.ResourceVersion, got.Status.CredentialsVersion)
+	h.AssertExpectations(t)
+}
+
+func TestReconcileKeycloak_forgetDeleted(t *testing.T) {
+	h := helper.Mock{}
+	h.On("ForgetKeycloakClient", types.UID("kc-uid")).Return()
+
+	r := NewReconcileKeycloak(nil, nil, mock.NewLogr(), &h)
+
+	assert.False(t, r.forgetDeleted(event.DeleteEvent{
+		Object: &keycloakApi.Keycloak{ObjectMeta: metav1.ObjectMeta{Name: "kc", UID: "kc-uid"}},
+	}))
+	h.AssertExpectations(t)
+}
@@ -70,7 +70,7 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).ToNot(HaveOccurred())
 
-	h := helper.MakeHelper(k8sManager.GetClient(), k8sManager.GetScheme(), logf.Log.WithName("helper"))
+	h := helper.MakeHelper(k8sManager.GetClient(), k8sManager.GetScheme(), logf.Log.WithName("helper"), "default")
 
 	err = NewReconcileKeycloak(k8sManager.GetClient(), k8sManager.GetScheme(), logf.Log.WithName("controller.keycloak"), h).
 		SetupWithManager(k8sManager, 0)
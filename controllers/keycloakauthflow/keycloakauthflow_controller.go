@@ -28,6 +28,7 @@ const finalizerName = "keycloak.authflow.operator.finalizer.name"
 type Helper interface {
 	SetFailureCount(fc helper.FailureCountable) time.Duration
 	UpdateStatus(obj client.Object) error
+	TryToReleaseRealmChild(ctx context.Context, obj helper.Deletable, realmChild helper.RealmChild, finalizer string) (bool, error)
 	TryToDelete(ctx context.Context, obj helper.Deletable, terminator helper.Terminator,
 		finalizer string) (isDeleted bool, resultErr error)
 	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
@@ -102,6 +103,21 @@ func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (r
 		return
 	}
 
+	if !instance.GetDeletionTimestamp().IsZero() {
+		released, err := r.helper.TryToReleaseRealmChild(ctx, &instance, &instance, finalizerName)
+		if err != nil {
+			resultErr = errors.Wrap(err, "unable to release keycloak auth flow")
+
+			return
+		}
+
+		if released {
+			log.Info("Parent realm is retained or deleted, keycloak resource is kept")
+
+			return
+		}
+	}
+
 	if err := r.tryReconcile(ctx, &instance); err != nil {
 		instance.Status.Value = err.Error()
 		result.RequeueAfter = r.helper.SetFailureCount(&instance)
@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	testifyMock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -71,7 +73,10 @@ func TestNewReconcile(t *testing.T) {
 
 	h.On("TryToDelete", &flow,
 		makeTerminator(&realm, keycloakAuthFlow, client, &kClient, log), finalizerName).Return(false, nil)
-	h.On("UpdateStatus", &flow).Return(nil)
+	h.On("UpdateStatus", testifyMock.MatchedBy(func(obj *keycloakApi.KeycloakAuthFlow) bool {
+		return obj.Status.Value == helper.StatusOK &&
+			meta.IsStatusConditionTrue(obj.Status.Conditions, keycloakApi.ConditionReady)
+	})).Return(nil)
 
 	r := Reconcile{
 		helper:                  &h,
@@ -42,7 +42,7 @@ func TestPrivateClientSecret(t *testing.T) {
 	s := scheme.Scheme
 	s.AddKnownTypes(v1.SchemeGroupVersion, &kc)
 	client := fake.NewClientBuilder().WithRuntimeObjects(&kc, &secret).Build()
-	h := helper.MakeHelper(client, s, mock.NewLogr())
+	h := helper.MakeHelper(client, s, mock.NewLogr(), "")
 
 	clientDTO := dto.ConvertSpecToClient(&kc.Spec, "")
 
@@ -121,7 +121,7 @@ func TestMake(t *testing.T) {
 	s := scheme.Scheme
 	s.AddKnownTypes(v1.SchemeGroupVersion, &k, &kr, &kc, &keycloakApi.KeycloakRealm{}, &keycloakApi.KeycloakRealmList{})
 	client := fake.NewClientBuilder().WithRuntimeObjects(&secret, &k, &kr, &kc).Build()
-	h := helper.MakeHelper(client, s, mock.NewLogr())
+	h := helper.MakeHelper(client, s, mock.NewLogr(), "")
 
 	kClient := new(adapter.Mock)
 	chain := Make(h.GetScheme(), client, mock.NewLogr())
@@ -69,6 +69,10 @@ func (c *clientRealmFinder) K8SParentRealmName() (string, error) {
 	return "main", nil
 }
 
+func (c *clientRealmFinder) K8SParentClusterRealmName() string {
+	return c.parent.Spec.ClusterRealmRef
+}
+
 func (c *clientRealmFinder) SetOwnerReferences(or []v1.OwnerReference) {
 	c.parent.SetOwnerReferences(or)
 }
@@ -24,6 +24,7 @@ import (
 
 type Helper interface {
 	SetFailureCount(fc helper.FailureCountable) time.Duration
+	TryToReleaseRealmChild(ctx context.Context, obj helper.Deletable, realmChild helper.RealmChild, finalizer string) (bool, error)
 	TryToDelete(ctx context.Context, obj helper.Deletable, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
 	GetScheme() *runtime.Scheme
 	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
@@ -94,6 +95,22 @@ func (r *ReconcileKeycloakClient) Reconcile(ctx context.Context, request reconci
 		return
 	}
 
+	if !instance.GetDeletionTimestamp().IsZero() {
+		released, err := r.helper.TryToReleaseRealmChild(ctx, &instance,
+			&clientRealmFinder{parent: &instance, client: r.client}, keyCloakClientOperatorFinalizerName)
+		if err != nil {
+			resultErr = pkgErrors.Wrap(err, "unable to release keycloak client")
+
+			return
+		}
+
+		if released {
+			log.Info("Parent realm is retained or deleted, keycloak resource is kept")
+
+			return
+		}
+	}
+
 	if err := r.tryReconcile(ctx, &instance); err != nil {
 		instance.Status.Value = err.Error()
 		result.RequeueAfter = r.helper.SetFailureCount(&instance)
@@ -7,8 +7,10 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -138,7 +140,10 @@ func TestReconcileKeycloakClient_ReconcileWithMappers(t *testing.T) {
 	h.On("TryToDelete", &kc,
 		makeTerminator(kc.Status.ClientID, kc.Spec.TargetRealm, kclient, logger),
 		keyCloakClientOperatorFinalizerName).Return(true, nil)
-	h.On("UpdateStatus", &kc).Return(nil)
+	h.On("UpdateStatus", testifyMock.MatchedBy(func(obj *keycloakApi.KeycloakClient) bool {
+		return obj.Status.Value == helper.StatusOK &&
+			meta.IsStatusConditionTrue(obj.Status.Conditions, keycloakApi.ConditionReady)
+	})).Return(nil)
 
 	r := ReconcileKeycloakClient{
 		client:                  client,
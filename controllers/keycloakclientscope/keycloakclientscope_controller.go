@@ -30,6 +30,7 @@ type Helper interface {
 	UpdateStatus(obj client.Object) error
 	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
 	GetOrCreateRealmOwnerRef(object helper.RealmChild, objectMeta *v1.ObjectMeta) (*keycloakApi.KeycloakRealm, error)
+	TryToReleaseRealmChild(ctx context.Context, obj helper.Deletable, realmChild helper.RealmChild, finalizer string) (bool, error)
 	TryToDelete(ctx context.Context, obj helper.Deletable, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
 }
 
@@ -102,6 +103,21 @@ func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (r
 		return
 	}
 
+	if !instance.GetDeletionTimestamp().IsZero() {
+		released, err := r.helper.TryToReleaseRealmChild(ctx, &instance, &instance, finalizerName)
+		if err != nil {
+			resultErr = errors.Wrap(err, "unable to release keycloak client scope")
+
+			return
+		}
+
+		if released {
+			log.Info("Parent realm is retained or deleted, keycloak resource is kept")
+
+			return
+		}
+	}
+
 	scopeID, err := r.tryReconcile(ctx, &instance)
 	if err != nil {
 		instance.Status.Value = err.Error()
@@ -7,8 +7,10 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -78,7 +80,10 @@ func TestReconcile_Reconcile(t *testing.T) {
 	updatedClientScopeWithStatus.ResourceVersion = "999"
 	updatedClientScopeWithStatus.Status.Value = helper.StatusOK
 
-	h.On("UpdateStatus", updatedClientScopeWithStatus).Return(nil)
+	h.On("UpdateStatus", testifyMock.MatchedBy(func(obj *keycloakApi.KeycloakClientScope) bool {
+		return obj.Status.Value == helper.StatusOK && obj.Status.ID == "scope12" &&
+			meta.IsStatusConditionTrue(obj.Status.Conditions, keycloakApi.ConditionReady)
+	})).Return(nil)
 
 	h.On("TryToDelete", updatedClientScopeWithID,
 		makeTerminator(kClient, realm.Spec.RealmName, "scope12", logger), finalizerName).
@@ -186,7 +191,7 @@ func TestReconcile_Reconcile_FailureNoRealm(t *testing.T) {
 
 	client := fake.NewClientBuilder().WithRuntimeObjects(instance).WithScheme(scheme).Build()
 	logger := mock.NewLogr()
-	rec := NewReconcile(client, logger, helper.MakeHelper(client, scheme, logger))
+	rec := NewReconcile(client, logger, helper.MakeHelper(client, scheme, logger, ""))
 
 	if _, err := rec.Reconcile(context.Background(),
 		reconcile.Request{NamespacedName: types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}}); err != nil {
@@ -7,10 +7,10 @@ import (
 
 	"github.com/Nerzal/gocloak/v12"
 	"github.com/stretchr/testify/require"
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
@@ -44,9 +44,10 @@ func TestCreateDefChain(t *testing.T) {
 		},
 	}
 
-	s := scheme.Scheme
-	s.AddKnownTypes(appsv1.SchemeGroupVersion, &k, &kr, &keycloakApi.KeycloakClient{})
-	client := fake.NewClientBuilder().WithRuntimeObjects(&secret, &k, &kr, &clientSecret).Build()
+	s := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(s))
+	utilruntime.Must(corev1.AddToScheme(s))
+	client := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(&secret, &k, &kr, &clientSecret).Build()
 
 	testRealm := dto.Realm{Name: realmName, SsoRealmEnabled: true, SsoAutoRedirectEnabled: true}
 	kClient := new(adapter.Mock)
@@ -104,9 +105,10 @@ func TestCreateDefChain_SSORealm(t *testing.T) {
 			}},
 	}
 
-	s := scheme.Scheme
-	s.AddKnownTypes(appsv1.SchemeGroupVersion, &k, &kr, &keycloakApi.KeycloakClient{})
-	client := fake.NewClientBuilder().WithRuntimeObjects(&secret, &k, &kr).Build()
+	s := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(s))
+	utilruntime.Must(corev1.AddToScheme(s))
+	client := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(&secret, &k, &kr).Build()
 
 	realmUser := dto.User{RealmRoles: []string{"foo", "bar"}}
 	testRealm := dto.Realm{Name: realmName, SsoRealmEnabled: true, SsoRealmName: "openshift", SsoAutoRedirectEnabled: true,
@@ -166,9 +168,10 @@ func TestCreateDefChainNoSSO(t *testing.T) {
 			}},
 	}
 
-	s := scheme.Scheme
-	s.AddKnownTypes(appsv1.SchemeGroupVersion, &k, &kr, &keycloakApi.KeycloakClient{})
-	client := fake.NewClientBuilder().WithRuntimeObjects(&secret, &k, &kr).Build()
+	s := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(s))
+	utilruntime.Must(corev1.AddToScheme(s))
+	client := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(&secret, &k, &kr).Build()
 
 	testRealm := dto.Realm{Name: realmName, SsoRealmEnabled: false, Users: []dto.User{{}}}
 	kClient := new(adapter.Mock)
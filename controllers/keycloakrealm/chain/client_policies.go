@@ -0,0 +1,107 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain/handler"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+// PutClientPolicies configures client profiles and client policies of the realm.
+// Profiles are put first, so policies can refer to them.
+type PutClientPolicies struct {
+	next handler.RealmHandler
+}
+
+func (h PutClientPolicies) ServeRequest(ctx context.Context, realm *keycloakApi.KeycloakRealm, kClient keycloak.Client) error {
+	rLog := log.WithValues("realm name", realm.Spec.RealmName)
+
+	if len(realm.Spec.ClientProfiles) == 0 && len(realm.Spec.ClientPolicies) == 0 {
+		rLog.Info("Client policies are not set, skip.")
+		return nextServeOrNil(ctx, h.next, realm, kClient)
+	}
+
+	rLog.Info("Start putting client policies")
+
+	if len(realm.Spec.ClientProfiles) > 0 {
+		profiles, err := convertClientProfiles(realm.Spec.ClientProfiles)
+		if err != nil {
+			return err
+		}
+
+		if err := kClient.SyncRealmClientProfiles(ctx, realm.Spec.RealmName, profiles); err != nil {
+			return fmt.Errorf("unable to sync realm client profiles: %w", err)
+		}
+	}
+
+	if len(realm.Spec.ClientPolicies) > 0 {
+		policies, err := convertClientPolicies(realm.Spec.ClientPolicies)
+		if err != nil {
+			return err
+		}
+
+		if err := kClient.SyncRealmClientPolicies(ctx, realm.Spec.RealmName, policies); err != nil {
+			return fmt.Errorf("unable to sync realm client policies: %w", err)
+		}
+	}
+
+	rLog.Info("Client policies have been put")
+
+	return nextServeOrNil(ctx, h.next, realm, kClient)
+}
+
+func convertClientProfiles(spec []keycloakApi.ClientProfile) ([]adapter.ClientProfile, error) {
+	profiles := make([]adapter.ClientProfile, len(spec))
+
+	for i, p := range spec {
+		executors := make([]adapter.ClientProfileExecutor, len(p.Executors))
+
+		for j, e := range p.Executors {
+			config, err := decodeJSONMap(e.Configuration)
+			if err != nil {
+				return nil, fmt.Errorf("unable to decode %s executor configuration of client profile %s: %w", e.Executor, p.Name, err)
+			}
+
+			executors[j] = adapter.ClientProfileExecutor{Executor: e.Executor, Configuration: config}
+		}
+
+		profiles[i] = adapter.ClientProfile{Name: p.Name, Description: p.Description, Executors: executors}
+	}
+
+	return profiles, nil
+}
+
+func convertClientPolicies(spec []keycloakApi.ClientPolicy) ([]adapter.ClientPolicy, error) {
+	policies := make([]adapter.ClientPolicy, len(spec))
+
+	for i, p := range spec {
+		conditions := make([]adapter.ClientPolicyCondition, len(p.Conditions))
+
+		for j, c := range p.Conditions {
+			config, err := decodeJSONMap(c.Configuration)
+			if err != nil {
+				return nil, fmt.Errorf("unable to decode %s condition configuration of client policy %s: %w", c.Condition, p.Name, err)
+			}
+
+			conditions[j] = adapter.ClientPolicyCondition{Condition: c.Condition, Configuration: config}
+		}
+
+		profiles := p.Profiles
+		if profiles == nil {
+			profiles = []string{}
+		}
+
+		policies[i] = adapter.ClientPolicy{
+			Name:        p.Name,
+			Description: p.Description,
+			Enabled:     p.Enabled,
+			Conditions:  conditions,
+			Profiles:    profiles,
+		}
+	}
+
+	return policies, nil
+}
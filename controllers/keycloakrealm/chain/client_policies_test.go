@@ -0,0 +1,86 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestPutClientPolicies_ServeRequest(t *testing.T) {
+	realm := keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "realm", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName: "realm1",
+			ClientProfiles: []keycloakApi.ClientProfile{
+				{
+					Name: "pkce",
+					Executors: []keycloakApi.ClientProfileExecutor{
+						{Executor: "pkce-enforcer", Configuration: map[string]apiextensionsv1.JSON{
+							"auto-configure": {Raw: []byte("true")},
+						}},
+					},
+				},
+			},
+			ClientPolicies: []keycloakApi.ClientPolicy{
+				{
+					Name:    "confidential-clients",
+					Enabled: true,
+					Conditions: []keycloakApi.ClientPolicyCondition{
+						{Condition: "client-access-type", Configuration: map[string]apiextensionsv1.JSON{
+							"type": {Raw: []byte(`["confidential"]`)},
+						}},
+					},
+					Profiles: []string{"pkce"},
+				},
+			},
+		},
+	}
+
+	kClient := new(adapter.Mock)
+	kClient.On("SyncRealmClientProfiles", "realm1", []adapter.ClientProfile{
+		{
+			Name: "pkce",
+			Executors: []adapter.ClientProfileExecutor{
+				{Executor: "pkce-enforcer", Configuration: map[string]interface{}{"auto-configure": true}},
+			},
+		},
+	}).Return(nil)
+	kClient.On("SyncRealmClientPolicies", "realm1", []adapter.ClientPolicy{
+		{
+			Name:    "confidential-clients",
+			Enabled: true,
+			Conditions: []adapter.ClientPolicyCondition{
+				{Condition: "client-access-type", Configuration: map[string]interface{}{"type": []interface{}{"confidential"}}},
+			},
+			Profiles: []string{"pkce"},
+		},
+	}).Return(nil).Once()
+
+	h := PutClientPolicies{}
+
+	require.NoError(t, h.ServeRequest(context.Background(), &realm, kClient))
+
+	kClient.On("SyncRealmClientPolicies", "realm1", mock.Anything).Return(errors.New("fatal")).Once()
+
+	err := h.ServeRequest(context.Background(), &realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to sync realm client policies")
+	kClient.AssertExpectations(t)
+
+	realm.Spec.ClientPolicies[0].Conditions[0].Configuration["type"] = apiextensionsv1.JSON{Raw: []byte("{")}
+	err = h.ServeRequest(context.Background(), &realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to decode client-access-type condition configuration")
+
+	realm.Spec.ClientProfiles = nil
+	realm.Spec.ClientPolicies = nil
+	require.NoError(t, h.ServeRequest(context.Background(), &realm, new(adapter.Mock)))
+}
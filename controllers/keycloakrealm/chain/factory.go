@@ -28,7 +28,23 @@ func CreateDefChain(client client.Client, scheme *runtime.Scheme, hlp Helper) ha
 								next: PutIdentityProvider{
 									next: PutDefaultIdP{
 										next: RealmSettings{
-											next: AuthFlow{},
+											next: PutSMTP{
+												next: PutLocalization{
+													next: PutRequiredActions{
+														next: PutUserProfile{
+															next: PutClientPolicies{
+																next: PutRealmDefaults{
+																	next:   AuthFlow{},
+																	client: client,
+																},
+															},
+															client: client,
+														},
+													},
+													client: client,
+												},
+												client: client,
+											},
 										},
 									},
 									client: client,
@@ -49,6 +65,17 @@ func CreateDefChain(client client.Client, scheme *runtime.Scheme, hlp Helper) ha
 	}
 }
 
+// CreateClusterRealmChain creates chain for ClusterKeycloakRealm.
+// Cluster realm doesn't manage users, clients and identity providers, so only the realm and its settings are handled.
+func CreateClusterRealmChain(hlp Helper) handler.RealmHandler {
+	return PutRealm{
+		hlp: hlp,
+		next: RealmSettings{
+			next: AuthFlow{},
+		},
+	}
+}
+
 func nextServeOrNil(ctx context.Context, next handler.RealmHandler, realm *keycloakApi.KeycloakRealm, kClient keycloak.Client) error {
 	if next != nil {
 		err := next.ServeRequest(ctx, realm, kClient)
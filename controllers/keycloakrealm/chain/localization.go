@@ -0,0 +1,71 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain/handler"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+)
+
+// PutLocalization puts localization texts of the realm. The texts are taken from ConfigMaps in the realm namespace.
+type PutLocalization struct {
+	next   handler.RealmHandler
+	client client.Client
+}
+
+func (h PutLocalization) ServeRequest(ctx context.Context, realm *keycloakApi.KeycloakRealm, kClient keycloak.Client) error {
+	rLog := log.WithValues("realm name", realm.Spec.RealmName)
+
+	if realm.Spec.Localization == nil || len(realm.Spec.Localization.Texts) == 0 {
+		rLog.Info("Localization texts are not set, skip.")
+		return nextServeOrNil(ctx, h.next, realm, kClient)
+	}
+
+	rLog.Info("Start putting localization texts")
+
+	addOnly := realm.Spec.Localization.GetReconciliationStrategy() == keycloakApi.ReconciliationStrategyAddOnly
+
+	locales := make([]string, 0, len(realm.Spec.Localization.Texts))
+
+	for _, ref := range realm.Spec.Localization.Texts {
+		texts, err := h.getTexts(ctx, realm.Namespace, ref.ConfigMapName)
+		if err != nil {
+			return err
+		}
+
+		if err := kClient.SyncRealmLocalizationTexts(ctx, realm.Spec.RealmName, ref.Locale, texts, addOnly); err != nil {
+			return fmt.Errorf("unable to sync localization texts of locale %s: %w", ref.Locale, err)
+		}
+
+		locales = append(locales, ref.Locale)
+	}
+
+	if !addOnly {
+		if err := kClient.DeleteRealmLocalizationTextsExcept(ctx, realm.Spec.RealmName, locales); err != nil {
+			return fmt.Errorf("unable to delete localization texts of unused locales: %w", err)
+		}
+	}
+
+	rLog.Info("Localization texts have been put")
+
+	return nextServeOrNil(ctx, h.next, realm, kClient)
+}
+
+func (h PutLocalization) getTexts(ctx context.Context, namespace, name string) (map[string]string, error) {
+	configMap := &coreV1.ConfigMap{}
+	if err := h.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, configMap); err != nil {
+		return nil, fmt.Errorf("unable to get localization config map %s: %w", name, err)
+	}
+
+	if configMap.Data == nil {
+		return map[string]string{}, nil
+	}
+
+	return configMap.Data, nil
+}
@@ -0,0 +1,63 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestPutLocalization_ServeRequest(t *testing.T) {
+	sch := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(sch))
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "texts-de", Namespace: "ns"},
+		Data:       map[string]string{"loginTitle": "Anmelden"},
+	}
+
+	realm := keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "realm", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName: "realm1",
+			Localization: &keycloakApi.RealmLocalization{
+				SupportedLocales: []string{"en", "de"},
+				Texts: []keycloakApi.LocalizationTexts{
+					{Locale: "de", ConfigMapName: "texts-de"},
+				},
+			},
+		},
+	}
+
+	h := PutLocalization{client: fake.NewClientBuilder().WithScheme(sch).WithObjects(configMap).Build()}
+
+	kClient := new(adapter.Mock)
+	kClient.On("SyncRealmLocalizationTexts", "realm1", "de", map[string]string{"loginTitle": "Anmelden"}, false).
+		Return(nil).Once()
+	kClient.On("DeleteRealmLocalizationTextsExcept", "realm1", []string{"de"}).Return(nil).Once()
+
+	require.NoError(t, h.ServeRequest(context.Background(), &realm, kClient))
+
+	realm.Spec.Localization.ReconciliationStrategy = keycloakApi.ReconciliationStrategyAddOnly
+
+	kClient.On("SyncRealmLocalizationTexts", "realm1", "de", map[string]string{"loginTitle": "Anmelden"}, true).
+		Return(nil).Once()
+
+	require.NoError(t, h.ServeRequest(context.Background(), &realm, kClient))
+	kClient.AssertExpectations(t)
+
+	realm.Spec.Localization.Texts[0].ConfigMapName = "missing"
+	err := h.ServeRequest(context.Background(), &realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to get localization config map missing")
+
+	realm.Spec.Localization = nil
+	require.NoError(t, h.ServeRequest(context.Background(), &realm, new(adapter.Mock)))
+}
@@ -2,6 +2,7 @@ package chain
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/pkg/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -14,6 +15,7 @@ import (
 
 type Helper interface {
 	InvalidateKeycloakClientTokenSecret(ctx context.Context, namespace, rootKeycloakName string) error
+	InvalidateClusterKeycloakClientTokenSecret(ctx context.Context, clusterKeycloakName string) error
 }
 
 type PutRealm struct {
@@ -50,7 +52,7 @@ func (h PutRealm) ServeRequest(ctx context.Context, realm *keycloakApi.KeycloakR
 		}
 	}
 
-	if err := h.hlp.InvalidateKeycloakClientTokenSecret(ctx, realm.Namespace, realm.Spec.KeycloakOwner); err != nil {
+	if err := h.invalidateKeycloakClientToken(ctx, realm); err != nil {
 		return errors.Wrap(err, "unable invalidate keycloak client token")
 	}
 
@@ -59,6 +61,22 @@ func (h PutRealm) ServeRequest(ctx context.Context, realm *keycloakApi.KeycloakR
 	return nextServeOrNil(ctx, h.next, realm, kClient)
 }
 
+func (h PutRealm) invalidateKeycloakClientToken(ctx context.Context, realm *keycloakApi.KeycloakRealm) error {
+	if realm.Spec.ClusterKeycloakRef != "" {
+		if err := h.hlp.InvalidateClusterKeycloakClientTokenSecret(ctx, realm.Spec.ClusterKeycloakRef); err != nil {
+			return fmt.Errorf("failed to invalidate cluster keycloak token: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := h.hlp.InvalidateKeycloakClientTokenSecret(ctx, realm.Namespace, realm.Spec.KeycloakOwner); err != nil {
+		return fmt.Errorf("failed to invalidate keycloak token: %w", err)
+	}
+
+	return nil
+}
+
 func (h PutRealm) putRealmRoles(realm *keycloakApi.KeycloakRealm, kClient keycloak.Client) error {
 	allRoles := make(map[string]string)
 	// check if all user roles exists
@@ -0,0 +1,104 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain/handler"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+// PutRealmDefaults configures default groups and default roles of the realm.
+type PutRealmDefaults struct {
+	next   handler.RealmHandler
+	client client.Client
+}
+
+func (h PutRealmDefaults) ServeRequest(ctx context.Context, realm *keycloakApi.KeycloakRealm, kClient keycloak.Client) error {
+	rLog := log.WithValues("realm name", realm.Spec.RealmName)
+
+	if len(realm.Spec.DefaultGroups) == 0 && realm.Spec.DefaultRoles == nil {
+		rLog.Info("Default groups and roles are not set, skip.")
+		return nextServeOrNil(ctx, h.next, realm, kClient)
+	}
+
+	rLog.Info("Start putting default groups and roles")
+
+	addOnly := realm.Spec.GetDefaultsReconciliationStrategy() == keycloakApi.ReconciliationStrategyAddOnly
+
+	if len(realm.Spec.DefaultGroups) > 0 {
+		if err := kClient.SyncRealmDefaultGroups(ctx, realm.Spec.RealmName, realm.Spec.DefaultGroups, addOnly); err != nil {
+			return fmt.Errorf("unable to sync realm default groups: %w", err)
+		}
+	}
+
+	if realm.Spec.DefaultRoles != nil {
+		roles := adapter.DefaultRoles{
+			RealmRoles:  realm.Spec.DefaultRoles.RealmRoles,
+			ClientRoles: make(map[string][]string, len(realm.Spec.DefaultRoles.ClientRoles)),
+		}
+
+		for _, cr := range realm.Spec.DefaultRoles.ClientRoles {
+			roles.ClientRoles[cr.ClientID] = append(roles.ClientRoles[cr.ClientID], cr.Roles...)
+		}
+
+		if !addOnly {
+			preserved, err := h.defaultRolesOfResources(ctx, realm)
+			if err != nil {
+				return err
+			}
+
+			roles.PreservedRealmRoles = preserved
+		}
+
+		if err := kClient.SyncRealmDefaultRoles(ctx, realm.Spec.RealmName, &roles, addOnly); err != nil {
+			return fmt.Errorf("unable to sync realm default roles: %w", err)
+		}
+	}
+
+	rLog.Info("Default groups and roles have been put")
+
+	return nextServeOrNil(ctx, h.next, realm, kClient)
+}
+
+// defaultRolesOfResources returns names of roles which are made default by KeycloakRealmRole
+// and KeycloakRealmRoleBatch resources of the realm, so full reconciliation doesn't remove them.
+func (h PutRealmDefaults) defaultRolesOfResources(ctx context.Context, realm *keycloakApi.KeycloakRealm) ([]string, error) {
+	var roleList keycloakApi.KeycloakRealmRoleList
+	if err := h.client.List(ctx, &roleList, client.InNamespace(realm.Namespace)); err != nil {
+		return nil, fmt.Errorf("unable to list realm roles: %w", err)
+	}
+
+	var names []string
+
+	for i := range roleList.Items {
+		role := &roleList.Items[i]
+		if role.Spec.IsDefault && role.Spec.ClusterRealmRef == "" && role.Spec.Realm == realm.Name {
+			names = append(names, role.Spec.Name)
+		}
+	}
+
+	var batchList keycloakApi.KeycloakRealmRoleBatchList
+	if err := h.client.List(ctx, &batchList, client.InNamespace(realm.Namespace)); err != nil {
+		return nil, fmt.Errorf("unable to list realm role batches: %w", err)
+	}
+
+	for i := range batchList.Items {
+		batch := &batchList.Items[i]
+		if batch.Spec.ClusterRealmRef != "" || batch.Spec.Realm != realm.Name {
+			continue
+		}
+
+		for _, role := range batch.Spec.Roles {
+			if role.IsDefault {
+				names = append(names, role.Name)
+			}
+		}
+	}
+
+	return names, nil
+}
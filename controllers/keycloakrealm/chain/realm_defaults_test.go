@@ -0,0 +1,108 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestPutRealmDefaults_ServeRequest(t *testing.T) {
+	realm := keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "realm", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName:     "realm1",
+			DefaultGroups: []string{"users"},
+			DefaultRoles: &keycloakApi.RealmDefaultRoles{
+				RealmRoles: []string{"developer"},
+				ClientRoles: []keycloakApi.ClientRole{
+					{ClientID: "account", Roles: []string{"view-profile"}},
+					{ClientID: "account", Roles: []string{"manage-account"}},
+				},
+			},
+			DefaultsReconciliationStrategy: keycloakApi.ReconciliationStrategyAddOnly,
+		},
+	}
+
+	kClient := new(adapter.Mock)
+	kClient.On("SyncRealmDefaultGroups", "realm1", []string{"users"}, true).Return(nil)
+	kClient.On("SyncRealmDefaultRoles", "realm1", &adapter.DefaultRoles{
+		RealmRoles:  []string{"developer"},
+		ClientRoles: map[string][]string{"account": {"view-profile", "manage-account"}},
+	}, true).Return(nil)
+
+	h := PutRealmDefaults{}
+
+	require.NoError(t, h.ServeRequest(context.Background(), &realm, kClient))
+	kClient.AssertExpectations(t)
+
+	realm.Spec.DefaultsReconciliationStrategy = ""
+	realm.Spec.DefaultRoles = nil
+
+	kClient = new(adapter.Mock)
+	kClient.On("SyncRealmDefaultGroups", "realm1", []string{"users"}, false).Return(errors.New("fatal"))
+
+	err := h.ServeRequest(context.Background(), &realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to sync realm default groups")
+
+	realm.Spec.DefaultGroups = nil
+	require.NoError(t, h.ServeRequest(context.Background(), &realm, new(adapter.Mock)))
+}
+
+func TestPutRealmDefaults_ServeRequest_PreservesDefaultRolesOfResources(t *testing.T) {
+	realm := keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "realm", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName:    "realm1",
+			DefaultRoles: &keycloakApi.RealmDefaultRoles{RealmRoles: []string{"developer"}},
+		},
+	}
+
+	s := runtime.NewScheme()
+	require.NoError(t, keycloakApi.AddToScheme(s))
+
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(
+		&keycloakApi.KeycloakRealmRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "tester", Namespace: "ns"},
+			Spec:       keycloakApi.KeycloakRealmRoleSpec{Name: "tester", Realm: "realm", IsDefault: true},
+		},
+		&keycloakApi.KeycloakRealmRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "not-default", Namespace: "ns"},
+			Spec:       keycloakApi.KeycloakRealmRoleSpec{Name: "not-default", Realm: "realm"},
+		},
+		&keycloakApi.KeycloakRealmRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-realm", Namespace: "ns"},
+			Spec:       keycloakApi.KeycloakRealmRoleSpec{Name: "other-realm", Realm: "other", IsDefault: true},
+		},
+		&keycloakApi.KeycloakRealmRoleBatch{
+			ObjectMeta: metav1.ObjectMeta{Name: "batch", Namespace: "ns"},
+			Spec: keycloakApi.KeycloakRealmRoleBatchSpec{
+				Realm: "realm",
+				Roles: []keycloakApi.BatchRole{
+					{Name: "batch-default", IsDefault: true},
+					{Name: "batch-role"},
+				},
+			},
+		},
+	).Build()
+
+	kClient := new(adapter.Mock)
+	kClient.On("SyncRealmDefaultRoles", "realm1", &adapter.DefaultRoles{
+		RealmRoles:          []string{"developer"},
+		ClientRoles:         map[string][]string{},
+		PreservedRealmRoles: []string{"tester", "batch-default"},
+	}, false).Return(nil)
+
+	h := PutRealmDefaults{client: cl}
+
+	require.NoError(t, h.ServeRequest(context.Background(), &realm, kClient))
+	kClient.AssertExpectations(t)
+}
@@ -32,7 +32,9 @@ func (h RealmSettings) ServeRequest(ctx context.Context, realm *keycloakApi.Keyc
 		}
 	}
 
-	if realm.Spec.BrowserSecurityHeaders == nil && realm.Spec.Themes == nil && len(realm.Spec.PasswordPolicies) == 0 {
+	if realm.Spec.BrowserSecurityHeaders == nil && realm.Spec.Themes == nil && len(realm.Spec.PasswordPolicies) == 0 &&
+		realm.Spec.TokenSettings == nil && realm.Spec.Sessions == nil && realm.Spec.Login == nil &&
+		realm.Spec.Localization == nil && !realm.Spec.HasSecurityPolicies() {
 		rLog.Info("Realm settings is not set, exit.")
 		return nextServeOrNil(ctx, h.next, realm, kClient)
 	}
@@ -59,6 +61,30 @@ func (h RealmSettings) ServeRequest(ctx context.Context, realm *keycloakApi.Keyc
 		settings.PasswordPolicies = h.makePasswordPolicies(realm.Spec.PasswordPolicies)
 	}
 
+	if realm.Spec.TokenSettings != nil {
+		tokenSettings := adapter.TokenSettings(*realm.Spec.TokenSettings)
+		settings.TokenSettings = &tokenSettings
+	}
+
+	if realm.Spec.Sessions != nil {
+		sessions := adapter.RealmSessions(*realm.Spec.Sessions)
+		settings.Sessions = &sessions
+	}
+
+	if realm.Spec.Login != nil {
+		login := adapter.RealmLogin(*realm.Spec.Login)
+		settings.Login = &login
+	}
+
+	setSecurityPolicies(&settings, &realm.Spec)
+
+	if realm.Spec.Localization != nil {
+		settings.Localization = &adapter.RealmLocalization{
+			SupportedLocales: realm.Spec.Localization.SupportedLocales,
+			DefaultLocale:    realm.Spec.Localization.DefaultLocale,
+		}
+	}
+
 	if err := kClient.UpdateRealmSettings(realm.Spec.RealmName, &settings); err != nil {
 		return errors.Wrap(err, "unable to update realm settings")
 	}
@@ -76,3 +102,20 @@ func (h RealmSettings) makePasswordPolicies(policiesSpec []keycloakApi.PasswordP
 
 	return policies
 }
+
+func setSecurityPolicies(settings *adapter.RealmSettings, spec *keycloakApi.KeycloakRealmSpec) {
+	if spec.BruteForceProtection != nil {
+		protection := adapter.BruteForceProtection(*spec.BruteForceProtection)
+		settings.BruteForceProtection = &protection
+	}
+
+	if spec.OTPPolicy != nil {
+		otpPolicy := adapter.OTPPolicy(*spec.OTPPolicy)
+		settings.OTPPolicy = &otpPolicy
+	}
+
+	if spec.WebAuthnPolicy != nil {
+		webAuthnPolicy := adapter.WebAuthnPolicy(*spec.WebAuthnPolicy)
+		settings.WebAuthnPolicy = &webAuthnPolicy
+	}
+}
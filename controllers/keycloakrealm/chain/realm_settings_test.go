@@ -75,3 +75,60 @@ func TestRealmSettings_ServeRequest(t *testing.T) {
 
 	kClient.AssertExpectations(t)
 }
+
+func TestRealmSettings_ServeRequest_TokenSettingsAndSessions(t *testing.T) {
+	kClient := new(adapter.Mock)
+	accessTokenLifespan := 300
+	ssoSessionIdleTimeout := 1800
+
+	realm := keycloakApi.KeycloakRealm{
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName: "realm1",
+			TokenSettings: &keycloakApi.TokenSettings{
+				AccessTokenLifespan: &accessTokenLifespan,
+			},
+			Sessions: &keycloakApi.RealmSessions{
+				SSOSessionIdleTimeout: &ssoSessionIdleTimeout,
+			},
+		},
+	}
+
+	kClient.On("UpdateRealmSettings", realm.Spec.RealmName, &adapter.RealmSettings{
+		TokenSettings: &adapter.TokenSettings{
+			AccessTokenLifespan: &accessTokenLifespan,
+		},
+		Sessions: &adapter.RealmSessions{
+			SSOSessionIdleTimeout: &ssoSessionIdleTimeout,
+		},
+	}).Return(nil)
+
+	err := RealmSettings{}.ServeRequest(context.Background(), &realm, kClient)
+	require.NoError(t, err)
+
+	kClient.AssertExpectations(t)
+}
+
+func TestRealmSettings_ServeRequest_Login(t *testing.T) {
+	kClient := new(adapter.Mock)
+	rememberMe := true
+
+	realm := keycloakApi.KeycloakRealm{
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName: "realm1",
+			Login: &keycloakApi.RealmLogin{
+				RememberMe: &rememberMe,
+			},
+		},
+	}
+
+	kClient.On("UpdateRealmSettings", realm.Spec.RealmName, &adapter.RealmSettings{
+		Login: &adapter.RealmLogin{
+			RememberMe: &rememberMe,
+		},
+	}).Return(nil)
+
+	err := RealmSettings{}.ServeRequest(context.Background(), &realm, kClient)
+	require.NoError(t, err)
+
+	kClient.AssertExpectations(t)
+}
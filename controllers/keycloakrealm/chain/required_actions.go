@@ -0,0 +1,40 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain/handler"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+// PutRequiredActions configures required actions of the realm.
+type PutRequiredActions struct {
+	next handler.RealmHandler
+}
+
+func (h PutRequiredActions) ServeRequest(ctx context.Context, realm *keycloakApi.KeycloakRealm, kClient keycloak.Client) error {
+	rLog := log.WithValues("realm name", realm.Spec.RealmName)
+
+	if len(realm.Spec.RequiredActions) == 0 {
+		rLog.Info("Required actions are not set, skip.")
+		return nextServeOrNil(ctx, h.next, realm, kClient)
+	}
+
+	rLog.Info("Start putting required actions")
+
+	actions := make([]adapter.RequiredAction, len(realm.Spec.RequiredActions))
+	for i, v := range realm.Spec.RequiredActions {
+		actions[i] = adapter.RequiredAction(v)
+	}
+
+	if err := kClient.SyncRealmRequiredActions(ctx, realm.Spec.RealmName, actions); err != nil {
+		return fmt.Errorf("unable to sync realm required actions: %w", err)
+	}
+
+	rLog.Info("Required actions have been put")
+
+	return nextServeOrNil(ctx, h.next, realm, kClient)
+}
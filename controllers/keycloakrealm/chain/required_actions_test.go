@@ -0,0 +1,47 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestPutRequiredActions_ServeRequest(t *testing.T) {
+	priority := 10
+	realm := keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "realm", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName: "realm1",
+			RequiredActions: []keycloakApi.RequiredAction{
+				{Alias: "CONFIGURE_TOTP", Enabled: true, DefaultAction: true, Priority: &priority},
+			},
+		},
+	}
+
+	kClient := new(adapter.Mock)
+	kClient.On("SyncRealmRequiredActions", "realm1", []adapter.RequiredAction{
+		{Alias: "CONFIGURE_TOTP", Enabled: true, DefaultAction: true, Priority: &priority},
+	}).Return(nil).Once()
+
+	h := PutRequiredActions{}
+
+	require.NoError(t, h.ServeRequest(context.Background(), &realm, kClient))
+
+	kClient.On("SyncRealmRequiredActions", "realm1", []adapter.RequiredAction{
+		{Alias: "CONFIGURE_TOTP", Enabled: true, DefaultAction: true, Priority: &priority},
+	}).Return(errors.New("fatal")).Once()
+
+	err := h.ServeRequest(context.Background(), &realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to sync realm required actions")
+	kClient.AssertExpectations(t)
+
+	realm.Spec.RequiredActions = nil
+	require.NoError(t, h.ServeRequest(context.Background(), &realm, new(adapter.Mock)))
+}
@@ -0,0 +1,76 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain/handler"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+// PutSMTP configures SMTP server of the realm. The password is taken from the secret in the realm namespace.
+type PutSMTP struct {
+	next   handler.RealmHandler
+	client client.Client
+}
+
+func (h PutSMTP) ServeRequest(ctx context.Context, realm *keycloakApi.KeycloakRealm, kClient keycloak.Client) error {
+	rLog := log.WithValues("realm name", realm.Spec.RealmName)
+
+	if realm.Spec.SMTP == nil {
+		rLog.Info("SMTP server is not set, skip.")
+		return nextServeOrNil(ctx, h.next, realm, kClient)
+	}
+
+	rLog.Info("Start putting SMTP server")
+
+	smtp := realm.Spec.SMTP
+	server := adapter.SMTPServer{
+		Host:            smtp.Host,
+		Port:            smtp.Port,
+		From:            smtp.From,
+		FromDisplayName: smtp.FromDisplayName,
+		ReplyTo:         smtp.ReplyTo,
+		EnvelopeFrom:    smtp.EnvelopeFrom,
+		SSL:             smtp.SSL,
+		StartTLS:        smtp.StartTLS,
+		User:            smtp.User,
+	}
+
+	if smtp.PasswordSecret != nil {
+		password, err := h.getPassword(ctx, realm.Namespace, smtp.PasswordSecret)
+		if err != nil {
+			return err
+		}
+
+		server.Password = password
+	}
+
+	if err := kClient.UpdateRealmSMTPServer(ctx, realm.Spec.RealmName, &server); err != nil {
+		return fmt.Errorf("unable to update realm smtp server: %w", err)
+	}
+
+	rLog.Info("SMTP server has been configured")
+
+	return nextServeOrNil(ctx, h.next, realm, kClient)
+}
+
+func (h PutSMTP) getPassword(ctx context.Context, namespace string, ref *keycloakApi.PasswordSecret) (string, error) {
+	secret := &coreV1.Secret{}
+	if err := h.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, secret); err != nil {
+		return "", fmt.Errorf("unable to get smtp password secret %s: %w", ref.Name, err)
+	}
+
+	password, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in smtp password secret %s", ref.Key, ref.Name)
+	}
+
+	return string(password), nil
+}
@@ -0,0 +1,63 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestPutSMTP_ServeRequest(t *testing.T) {
+	sch := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(sch))
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "smtp", Namespace: "ns"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+
+	realm := keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "realm", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName: "realm1",
+			SMTP: &keycloakApi.RealmSMTP{
+				Host:           "smtp.example.com",
+				Port:           587,
+				From:           "noreply@example.com",
+				StartTLS:       true,
+				User:           "user",
+				PasswordSecret: &keycloakApi.PasswordSecret{Name: "smtp", Key: "password"},
+			},
+		},
+	}
+
+	kClient := new(adapter.Mock)
+	kClient.On("UpdateRealmSMTPServer", "realm1", &adapter.SMTPServer{
+		Host:     "smtp.example.com",
+		Port:     587,
+		From:     "noreply@example.com",
+		StartTLS: true,
+		User:     "user",
+		Password: "secret",
+	}).Return(nil)
+
+	h := PutSMTP{client: fake.NewClientBuilder().WithScheme(sch).WithObjects(secret).Build()}
+
+	require.NoError(t, h.ServeRequest(context.Background(), &realm, kClient))
+	kClient.AssertExpectations(t)
+
+	realm.Spec.SMTP.PasswordSecret.Key = "missing"
+	err := h.ServeRequest(context.Background(), &realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "key missing not found")
+
+	realm.Spec.SMTP = nil
+	require.NoError(t, h.ServeRequest(context.Background(), &realm, new(adapter.Mock)))
+}
@@ -0,0 +1,364 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	coreV1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	keycloakAlpha "github.com/epam/edp-keycloak-operator/api/v1alpha1"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain/handler"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+const (
+	// userProfileMinVersion is the first Keycloak version with declarative user profile admin API.
+	userProfileMinVersion = 22
+	// userProfileGAVersion is the first Keycloak version where declarative user profile is always enabled.
+	userProfileGAVersion = 24
+
+	declarativeUserProfileFeature = "DECLARATIVE_USER_PROFILE"
+)
+
+// PutUserProfile configures declarative user profile of the realm.
+type PutUserProfile struct {
+	next   handler.RealmHandler
+	client client.Client
+}
+
+func (h PutUserProfile) ServeRequest(ctx context.Context, realm *keycloakApi.KeycloakRealm, kClient keycloak.Client) error {
+	rLog := log.WithValues("realm name", realm.Spec.RealmName)
+
+	if realm.Spec.UserProfile == nil {
+		rLog.Info("User profile is not set, skip.")
+		return nextServeOrNil(ctx, h.next, realm, kClient)
+	}
+
+	if err := h.enableUserProfile(ctx, realm, kClient); err != nil {
+		return err
+	}
+
+	rLog.Info("Start putting user profile")
+
+	current, err := kClient.GetUserProfile(ctx, realm.Spec.RealmName)
+	if err != nil {
+		return fmt.Errorf("unable to get user profile: %w", err)
+	}
+
+	desired, err := h.makeUserProfile(ctx, realm, current)
+	if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(current, desired) {
+		rLog.Info("User profile is up to date")
+		return nextServeOrNil(ctx, h.next, realm, kClient)
+	}
+
+	if err := kClient.UpdateUserProfile(ctx, realm.Spec.RealmName, desired); err != nil {
+		return fmt.Errorf("unable to update user profile: %w", err)
+	}
+
+	rLog.Info("User profile has been put")
+
+	return nextServeOrNil(ctx, h.next, realm, kClient)
+}
+
+// enableUserProfile checks if the server supports declarative user profile and enables it in the realm if needed.
+// User profile set in the spec is never skipped silently, so unsupported server is reported as an error.
+func (h PutUserProfile) enableUserProfile(ctx context.Context, realm *keycloakApi.KeycloakRealm, kClient keycloak.Client) error {
+	info, err := h.serverInfo(ctx, realm, kClient)
+	if err != nil {
+		return err
+	}
+
+	major, err := majorVersion(info.Version)
+	if err != nil {
+		return err
+	}
+
+	if major < userProfileMinVersion {
+		return fmt.Errorf("user profile is not supported by Keycloak %s, version %d or later is required",
+			info.Version, userProfileMinVersion)
+	}
+
+	if major >= userProfileGAVersion {
+		return nil
+	}
+
+	if !hasFeature(info.Features, declarativeUserProfileFeature) {
+		return fmt.Errorf("user profile requires %s feature to be enabled in Keycloak %s",
+			declarativeUserProfileFeature, info.Version)
+	}
+
+	enabled := true
+	if err := kClient.UpdateRealmSettings(realm.Spec.RealmName, &adapter.RealmSettings{UserProfileEnabled: &enabled}); err != nil {
+		return fmt.Errorf("unable to enable user profile in realm: %w", err)
+	}
+
+	return nil
+}
+
+// serverInfo returns version and features from the status of the Keycloak or ClusterKeycloak which owns the realm.
+// The server is requested only if the status doesn't have them yet.
+func (h PutUserProfile) serverInfo(ctx context.Context, realm *keycloakApi.KeycloakRealm, kClient keycloak.Client) (*adapter.ServerInfo, error) {
+	info, err := h.serverInfoFromStatus(ctx, realm)
+	if err != nil {
+		return nil, err
+	}
+
+	if info != nil {
+		return info, nil
+	}
+
+	if info, err = kClient.GetServerInfo(ctx); err != nil {
+		return nil, fmt.Errorf("unable to get keycloak server info: %w", err)
+	}
+
+	return info, nil
+}
+
+// serverInfoFromStatus returns nil if the owner of the realm is not found or its status doesn't have server info.
+func (h PutUserProfile) serverInfoFromStatus(ctx context.Context, realm *keycloakApi.KeycloakRealm) (*adapter.ServerInfo, error) {
+	if realm.Spec.ClusterKeycloakRef != "" {
+		var kc keycloakAlpha.ClusterKeycloak
+		if err := h.client.Get(ctx, types.NamespacedName{Name: realm.Spec.ClusterKeycloakRef}, &kc); err != nil {
+			if k8sErrors.IsNotFound(err) {
+				return nil, nil
+			}
+
+			return nil, fmt.Errorf("unable to get cluster keycloak %s: %w", realm.Spec.ClusterKeycloakRef, err)
+		}
+
+		return makeServerInfo(kc.Status.Version, kc.Status.Features), nil
+	}
+
+	name := keycloakOwnerName(realm)
+	if name == "" {
+		return nil, nil
+	}
+
+	var kc keycloakApi.Keycloak
+	if err := h.client.Get(ctx, types.NamespacedName{Namespace: realm.Namespace, Name: name}, &kc); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("unable to get keycloak %s: %w", name, err)
+	}
+
+	return makeServerInfo(kc.Status.Version, kc.Status.Features), nil
+}
+
+func makeServerInfo(version string, features []string) *adapter.ServerInfo {
+	if version == "" {
+		return nil
+	}
+
+	return &adapter.ServerInfo{Version: version, Features: features}
+}
+
+func keycloakOwnerName(realm *keycloakApi.KeycloakRealm) string {
+	for _, ref := range realm.OwnerReferences {
+		if ref.Kind == "Keycloak" {
+			return ref.Name
+		}
+	}
+
+	return realm.Spec.KeycloakOwner
+}
+
+func (h PutUserProfile) makeUserProfile(
+	ctx context.Context,
+	realm *keycloakApi.KeycloakRealm,
+	current *adapter.UserProfileConfig,
+) (*adapter.UserProfileConfig, error) {
+	spec := realm.Spec.UserProfile
+
+	if spec.ConfigMapKeyRef != nil {
+		return h.getUserProfileFromConfigMap(ctx, realm.Namespace, spec.ConfigMapKeyRef)
+	}
+
+	desired := &adapter.UserProfileConfig{
+		Attributes:               append([]adapter.UserProfileAttribute(nil), current.Attributes...),
+		Groups:                   append([]adapter.UserProfileGroup(nil), current.Groups...),
+		UnmanagedAttributePolicy: current.UnmanagedAttributePolicy,
+	}
+
+	for i := range spec.Attributes {
+		attr, err := convertUserProfileAttribute(&spec.Attributes[i])
+		if err != nil {
+			return nil, err
+		}
+
+		desired.Attributes = putUserProfileAttribute(desired.Attributes, attr)
+	}
+
+	for i := range spec.Groups {
+		desired.Groups = putUserProfileGroup(desired.Groups, convertUserProfileGroup(&spec.Groups[i]))
+	}
+
+	if spec.UnmanagedAttributePolicy != "" {
+		desired.UnmanagedAttributePolicy = spec.UnmanagedAttributePolicy
+	}
+
+	return desired, nil
+}
+
+func (h PutUserProfile) getUserProfileFromConfigMap(
+	ctx context.Context,
+	namespace string,
+	ref *keycloakApi.KeySelector,
+) (*adapter.UserProfileConfig, error) {
+	configMap := &coreV1.ConfigMap{}
+	if err := h.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, configMap); err != nil {
+		return nil, fmt.Errorf("unable to get user profile config map %s: %w", ref.Name, err)
+	}
+
+	data, ok := configMap.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in user profile config map %s", ref.Key, ref.Name)
+	}
+
+	profile := &adapter.UserProfileConfig{}
+	if err := json.Unmarshal([]byte(data), profile); err != nil {
+		return nil, fmt.Errorf("unable to decode user profile from config map %s: %w", ref.Name, err)
+	}
+
+	return profile, nil
+}
+
+func convertUserProfileAttribute(attr *keycloakApi.UserProfileAttribute) (adapter.UserProfileAttribute, error) {
+	out := adapter.UserProfileAttribute{
+		Name:        attr.Name,
+		DisplayName: attr.DisplayName,
+		Group:       attr.Group,
+		Multivalued: attr.Multivalued,
+		Annotations: convertAnnotations(attr.Annotations),
+	}
+
+	if attr.Validations != nil {
+		out.Validations = make(map[string]map[string]interface{}, len(attr.Validations))
+
+		for validator, config := range attr.Validations {
+			validatorConfig, err := decodeJSONMap(config)
+			if err != nil {
+				return out, fmt.Errorf("unable to decode %s validator config of attribute %s: %w", validator, attr.Name, err)
+			}
+
+			out.Validations[validator] = validatorConfig
+		}
+	}
+
+	if attr.Required != nil {
+		required := adapter.UserProfileAttributeRequired(*attr.Required)
+		out.Required = &required
+	}
+
+	if attr.Permissions != nil {
+		permissions := adapter.UserProfileAttributePermissions(*attr.Permissions)
+		out.Permissions = &permissions
+	}
+
+	if attr.Selector != nil {
+		selector := adapter.UserProfileAttributeSelector(*attr.Selector)
+		out.Selector = &selector
+	}
+
+	return out, nil
+}
+
+func convertUserProfileGroup(group *keycloakApi.UserProfileGroup) adapter.UserProfileGroup {
+	return adapter.UserProfileGroup{
+		Name:               group.Name,
+		DisplayHeader:      group.DisplayHeader,
+		DisplayDescription: group.DisplayDescription,
+		Annotations:        convertAnnotations(group.Annotations),
+	}
+}
+
+func convertAnnotations(annotations map[string]string) map[string]interface{} {
+	if annotations == nil {
+		return nil
+	}
+
+	out := make(map[string]interface{}, len(annotations))
+	for k, v := range annotations {
+		out[k] = v
+	}
+
+	return out
+}
+
+// decodeJSONMap decodes arbitrary JSON values of the map.
+// The result is nil if m is nil, so it matches the representation returned by Keycloak.
+func decodeJSONMap(m map[string]apiextensionsv1.JSON) (map[string]interface{}, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	out := make(map[string]interface{}, len(m))
+
+	for k, v := range m {
+		var value interface{}
+		if err := json.Unmarshal(v.Raw, &value); err != nil {
+			return nil, fmt.Errorf("unable to decode value of %s: %w", k, err)
+		}
+
+		out[k] = value
+	}
+
+	return out, nil
+}
+
+func putUserProfileAttribute(attrs []adapter.UserProfileAttribute, attr adapter.UserProfileAttribute) []adapter.UserProfileAttribute {
+	for i := range attrs {
+		if attrs[i].Name == attr.Name {
+			attrs[i] = attr
+			return attrs
+		}
+	}
+
+	return append(attrs, attr)
+}
+
+func putUserProfileGroup(groups []adapter.UserProfileGroup, group adapter.UserProfileGroup) []adapter.UserProfileGroup {
+	for i := range groups {
+		if groups[i].Name == group.Name {
+			groups[i] = group
+			return groups
+		}
+	}
+
+	return append(groups, group)
+}
+
+// majorVersion returns major version of Keycloak server, e.g. 22 for 22.0.5 or 21 for 21.1.2.redhat-00001.
+func majorVersion(version string) (int, error) {
+	major, err := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse keycloak version %q: %w", version, err)
+	}
+
+	return major, nil
+}
+
+func hasFeature(features []string, feature string) bool {
+	for _, f := range features {
+		if f == feature {
+			return true
+		}
+	}
+
+	return false
+}
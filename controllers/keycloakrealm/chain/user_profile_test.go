@@ -0,0 +1,220 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	keycloakAlpha "github.com/epam/edp-keycloak-operator/api/v1alpha1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestPutUserProfile_ServeRequest(t *testing.T) {
+	currentProfile := func() *adapter.UserProfileConfig {
+		return &adapter.UserProfileConfig{
+			Attributes: []adapter.UserProfileAttribute{
+				{Name: "username"},
+				{Name: "department", DisplayName: "Department"},
+			},
+		}
+	}
+
+	newRealm := func() *keycloakApi.KeycloakRealm {
+		return &keycloakApi.KeycloakRealm{
+			ObjectMeta: metav1.ObjectMeta{Name: "realm", Namespace: "ns"},
+			Spec: keycloakApi.KeycloakRealmSpec{
+				RealmName: "realm1",
+				UserProfile: &keycloakApi.UserProfile{
+					Attributes: []keycloakApi.UserProfileAttribute{
+						{
+							Name: "department",
+							Validations: map[string]map[string]apiextensionsv1.JSON{
+								"length": {"max": {Raw: []byte("64")}},
+							},
+							Permissions: &keycloakApi.UserProfileAttributePermissions{View: []string{"admin", "user"}},
+						},
+					},
+					Groups: []keycloakApi.UserProfileGroup{{Name: "work", DisplayHeader: "Work"}},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		realm     *keycloakApi.KeycloakRealm
+		setupMock func(m *adapter.Mock)
+		wantErr   require.ErrorAssertionFunc
+	}{
+		{
+			name:  "typed attributes are merged into current profile",
+			realm: newRealm(),
+			setupMock: func(m *adapter.Mock) {
+				m.On("GetServerInfo").Return(&adapter.ServerInfo{Version: "24.0.1"}, nil)
+				m.On("GetUserProfile", "realm1").Return(currentProfile(), nil)
+				m.On("UpdateUserProfile", "realm1", &adapter.UserProfileConfig{
+					Attributes: []adapter.UserProfileAttribute{
+						{Name: "username"},
+						{
+							Name:        "department",
+							Validations: map[string]map[string]interface{}{"length": {"max": float64(64)}},
+							Permissions: &adapter.UserProfileAttributePermissions{View: []string{"admin", "user"}},
+						},
+					},
+					Groups: []adapter.UserProfileGroup{{Name: "work", DisplayHeader: "Work"}},
+				}).Return(nil)
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "profile from config map is not updated if it is up to date",
+			realm: func() *keycloakApi.KeycloakRealm {
+				realm := newRealm()
+				realm.Spec.UserProfile = &keycloakApi.UserProfile{
+					ConfigMapKeyRef: &keycloakApi.KeySelector{Name: "profile", Key: "profile.json"},
+				}
+
+				return realm
+			}(),
+			setupMock: func(m *adapter.Mock) {
+				m.On("GetServerInfo").Return(&adapter.ServerInfo{Version: "25.0.0"}, nil)
+				m.On("GetUserProfile", "realm1").Return(currentProfile(), nil)
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:  "user profile is enabled in realm on Keycloak 22",
+			realm: newRealm(),
+			setupMock: func(m *adapter.Mock) {
+				m.On("GetServerInfo").Return(&adapter.ServerInfo{
+					Version:  "22.0.5",
+					Features: []string{declarativeUserProfileFeature},
+				}, nil)
+				m.On("UpdateRealmSettings", "realm1", mock.MatchedBy(func(s *adapter.RealmSettings) bool {
+					return s.UserProfileEnabled != nil && *s.UserProfileEnabled
+				})).Return(nil)
+				m.On("GetUserProfile", "realm1").Return(currentProfile(), nil)
+				m.On("UpdateUserProfile", "realm1", mock.Anything).Return(nil)
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "server info is taken from keycloak status",
+			realm: func() *keycloakApi.KeycloakRealm {
+				realm := newRealm()
+				realm.Spec.KeycloakOwner = "keycloak"
+
+				return realm
+			}(),
+			setupMock: func(m *adapter.Mock) {
+				m.On("GetUserProfile", "realm1").Return(currentProfile(), nil)
+				m.On("UpdateUserProfile", "realm1", mock.Anything).Return(nil)
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "server info is taken from cluster keycloak status",
+			realm: func() *keycloakApi.KeycloakRealm {
+				realm := newRealm()
+				realm.Spec.ClusterKeycloakRef = "cluster-keycloak"
+
+				return realm
+			}(),
+			setupMock: func(m *adapter.Mock) {
+				m.On("UpdateRealmSettings", "realm1", mock.Anything).Return(nil)
+				m.On("GetUserProfile", "realm1").Return(currentProfile(), nil)
+				m.On("UpdateUserProfile", "realm1", mock.Anything).Return(nil)
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:  "user profile fails on Keycloak 22 without feature",
+			realm: newRealm(),
+			setupMock: func(m *adapter.Mock) {
+				m.On("GetServerInfo").Return(&adapter.ServerInfo{Version: "22.0.5"}, nil)
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, "user profile requires DECLARATIVE_USER_PROFILE feature to be enabled in Keycloak 22.0.5")
+			},
+		},
+		{
+			name:  "user profile fails on Keycloak 21",
+			realm: newRealm(),
+			setupMock: func(m *adapter.Mock) {
+				m.On("GetServerInfo").Return(&adapter.ServerInfo{Version: "21.1.2"}, nil)
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, "user profile is not supported by Keycloak 21.1.2")
+			},
+		},
+		{
+			name:  "invalid version",
+			realm: newRealm(),
+			setupMock: func(m *adapter.Mock) {
+				m.On("GetServerInfo").Return(&adapter.ServerInfo{Version: "unknown"}, nil)
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, "unable to parse keycloak version")
+			},
+		},
+		{
+			name: "user profile is not set",
+			realm: func() *keycloakApi.KeycloakRealm {
+				realm := newRealm()
+				realm.Spec.UserProfile = nil
+
+				return realm
+			}(),
+			setupMock: func(m *adapter.Mock) {},
+			wantErr:   require.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sch := runtime.NewScheme()
+			require.NoError(t, corev1.AddToScheme(sch))
+			require.NoError(t, keycloakApi.AddToScheme(sch))
+			require.NoError(t, keycloakAlpha.AddToScheme(sch))
+
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "profile", Namespace: "ns"},
+				Data: map[string]string{
+					"profile.json": `{"attributes":[{"name":"username"},{"name":"department","displayName":"Department"}]}`,
+				},
+			}
+
+			kClient := new(adapter.Mock)
+			tt.setupMock(kClient)
+
+			kc := &keycloakApi.Keycloak{
+				ObjectMeta: metav1.ObjectMeta{Name: "keycloak", Namespace: "ns"},
+				Status:     keycloakApi.KeycloakStatus{Version: "24.0.1"},
+			}
+
+			clusterKc := &keycloakAlpha.ClusterKeycloak{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-keycloak"},
+				Status: keycloakAlpha.ClusterKeycloakStatus{
+					Version:  "22.0.5",
+					Features: []string{declarativeUserProfileFeature},
+				},
+			}
+
+			h := PutUserProfile{client: fake.NewClientBuilder().WithScheme(sch).WithObjects(configMap, kc, clusterKc).Build()}
+
+			tt.wantErr(t, h.ServeRequest(context.Background(), tt.realm, kClient))
+			kClient.AssertExpectations(t)
+		})
+	}
+}
@@ -7,22 +7,33 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlHandler "sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	keycloakAlpha "github.com/epam/edp-keycloak-operator/api/v1alpha1"
 	"github.com/epam/edp-keycloak-operator/controllers/helper"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain/handler"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
 )
 
-const keyCloakRealmOperatorFinalizerName = "keycloak.realm.operator.finalizer.name"
+const (
+	keyCloakRealmOperatorFinalizerName = "keycloak.realm.operator.finalizer.name"
+
+	// driftCheckInterval is the maximum interval between reconciliations of realms with security policies.
+	// Policies changed in Keycloak outside the operator are re-applied on the next reconciliation.
+	driftCheckInterval = 5 * time.Minute
+)
 
 type Helper interface {
 	SetFailureCount(fc helper.FailureCountable) time.Duration
@@ -30,6 +41,7 @@ type Helper interface {
 	TryToDelete(ctx context.Context, obj helper.Deletable, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
 	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
 	InvalidateKeycloakClientTokenSecret(ctx context.Context, namespace, rootKeycloakName string) error
+	InvalidateClusterKeycloakClientTokenSecret(ctx context.Context, clusterKeycloakName string) error
 }
 
 func NewReconcileKeycloakRealm(client client.Client, scheme *runtime.Scheme, log logr.Logger, helper Helper) *ReconcileKeycloakRealm {
@@ -58,6 +70,18 @@ func (r *ReconcileKeycloakRealm) SetupWithManager(mgr ctrl.Manager, successRecon
 
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&keycloakApi.KeycloakRealm{}, builder.WithPredicates(pred)).
+		Watches(
+			&source.Kind{Type: &keycloakApi.Keycloak{}},
+			ctrlHandler.EnqueueRequestsFromMapFunc(r.mapKeycloakToRealms),
+			builder.WithPredicates(predicate.Funcs{UpdateFunc: helper.IsConnectionUpdated}),
+		).
+		Watches(
+			&source.Kind{Type: &keycloakAlpha.ClusterKeycloak{}},
+			ctrlHandler.EnqueueRequestsFromMapFunc(r.mapClusterKeycloakToRealms),
+			builder.WithPredicates(predicate.Funcs{UpdateFunc: helper.IsConnectionUpdated}),
+		).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, ctrlHandler.EnqueueRequestsFromMapFunc(r.mapSecretToRealms)).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, ctrlHandler.EnqueueRequestsFromMapFunc(r.mapConfigMapToRealms)).
 		Complete(r)
 	if err != nil {
 		return fmt.Errorf("failed to setup KeycloakRealm controller: %w", err)
@@ -70,6 +94,7 @@ func (r *ReconcileKeycloakRealm) SetupWithManager(mgr ctrl.Manager, successRecon
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealms/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealms/finalizers,verbs=update
 //+kubebuilder:rbac:groups="",namespace=placeholder,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=configmaps,verbs=get;list;watch
 
 // Reconcile is a loop for reconciling KeycloakRealm object.
 func (r *ReconcileKeycloakRealm) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resultErr error) {
@@ -98,9 +123,8 @@ func (r *ReconcileKeycloakRealm) Reconcile(ctx context.Context, request reconcil
 		log.Error(err, "an error has occurred while handling keycloak realm", "name", request.Name)
 	} else {
 		instance.Status.Available = true
-		instance.Status.Value = helper.StatusOK
-		instance.Status.FailureCount = 0
-		result.RequeueAfter = r.successReconcileTimeout
+		helper.SetSuccessStatus(instance)
+		result.RequeueAfter = r.successRequeueTime(instance)
 	}
 
 	if err := r.helper.UpdateStatus(instance); err != nil {
@@ -133,3 +157,142 @@ func (r *ReconcileKeycloakRealm) tryReconcile(ctx context.Context, realm *keyclo
 
 	return nil
 }
+
+// successRequeueTime returns requeue time after successful reconciliation.
+// Realms with security policies are requeued at least every driftCheckInterval.
+func (r *ReconcileKeycloakRealm) successRequeueTime(realm *keycloakApi.KeycloakRealm) time.Duration {
+	if !realm.Spec.HasSecurityPolicies() {
+		return r.successReconcileTimeout
+	}
+
+	if r.successReconcileTimeout > 0 && r.successReconcileTimeout < driftCheckInterval {
+		return r.successReconcileTimeout
+	}
+
+	return driftCheckInterval
+}
+
+// mapKeycloakToRealms returns requests for realms which are owned by the Keycloak instance.
+func (r *ReconcileKeycloakRealm) mapKeycloakToRealms(kc client.Object) []reconcile.Request {
+	var list keycloakApi.KeycloakRealmList
+	if err := r.client.List(context.Background(), &list, client.InNamespace(kc.GetNamespace())); err != nil {
+		r.log.Error(err, "unable to list realms for keycloak", "keycloak", kc.GetName())
+
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for i := range list.Items {
+		realm := &list.Items[i]
+		if realm.Spec.ClusterKeycloakRef != "" || !isOwnedByKeycloak(realm, kc.GetName()) {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: realm.Namespace, Name: realm.Name},
+		})
+	}
+
+	return requests
+}
+
+// mapClusterKeycloakToRealms returns requests for realms which refer to the ClusterKeycloak instance.
+func (r *ReconcileKeycloakRealm) mapClusterKeycloakToRealms(kc client.Object) []reconcile.Request {
+	var list keycloakApi.KeycloakRealmList
+	if err := r.client.List(context.Background(), &list); err != nil {
+		r.log.Error(err, "unable to list realms for cluster keycloak", "clusterkeycloak", kc.GetName())
+
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for i := range list.Items {
+		if list.Items[i].Spec.ClusterKeycloakRef == kc.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: list.Items[i].Namespace, Name: list.Items[i].Name},
+			})
+		}
+	}
+
+	return requests
+}
+
+// mapSecretToRealms returns requests for realms which use the secret as SMTP password.
+func (r *ReconcileKeycloakRealm) mapSecretToRealms(secret client.Object) []reconcile.Request {
+	var list keycloakApi.KeycloakRealmList
+	if err := r.client.List(context.Background(), &list, client.InNamespace(secret.GetNamespace())); err != nil {
+		r.log.Error(err, "unable to list realms for secret", "secret", secret.GetName())
+
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for i := range list.Items {
+		smtp := list.Items[i].Spec.SMTP
+		if smtp != nil && smtp.PasswordSecret != nil && smtp.PasswordSecret.Name == secret.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: list.Items[i].Namespace, Name: list.Items[i].Name},
+			})
+		}
+	}
+
+	return requests
+}
+
+// mapConfigMapToRealms returns requests for realms which use the config map as localization texts or user profile.
+func (r *ReconcileKeycloakRealm) mapConfigMapToRealms(configMap client.Object) []reconcile.Request {
+	var list keycloakApi.KeycloakRealmList
+	if err := r.client.List(context.Background(), &list, client.InNamespace(configMap.GetNamespace())); err != nil {
+		r.log.Error(err, "unable to list realms for config map", "config map", configMap.GetName())
+
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for i := range list.Items {
+		if usesConfigMap(&list.Items[i], configMap.GetName()) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: list.Items[i].Namespace, Name: list.Items[i].Name},
+			})
+		}
+	}
+
+	return requests
+}
+
+func usesConfigMap(realm *keycloakApi.KeycloakRealm, configMapName string) bool {
+	if profile := realm.Spec.UserProfile; profile != nil && profile.ConfigMapKeyRef != nil &&
+		profile.ConfigMapKeyRef.Name == configMapName {
+		return true
+	}
+
+	if realm.Spec.Localization == nil {
+		return false
+	}
+
+	for _, ref := range realm.Spec.Localization.Texts {
+		if ref.ConfigMapName == configMapName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isOwnedByKeycloak(realm *keycloakApi.KeycloakRealm, keycloakName string) bool {
+	if realm.Spec.KeycloakOwner == keycloakName {
+		return true
+	}
+
+	for _, ref := range realm.GetOwnerReferences() {
+		if ref.Kind == "Keycloak" && ref.Name == keycloakName {
+			return true
+		}
+	}
+
+	return false
+}
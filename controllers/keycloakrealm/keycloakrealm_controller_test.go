@@ -7,9 +7,11 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -49,7 +51,7 @@ func TestReconcileKeycloakRealm_ReconcileWithoutOwners(t *testing.T) {
 
 	r := ReconcileKeycloakRealm{
 		client: client,
-		helper: helper.MakeHelper(client, s, mock.NewLogr()),
+		helper: helper.MakeHelper(client, s, mock.NewLogr(), ""),
 		log:    mock.NewLogr(),
 	}
 
@@ -100,7 +102,7 @@ func TestReconcileKeycloakRealm_ReconcileWithoutKeycloakOwner(t *testing.T) {
 
 	r := ReconcileKeycloakRealm{
 		client: client,
-		helper: helper.MakeHelper(client, s, mock.NewLogr()),
+		helper: helper.MakeHelper(client, s, mock.NewLogr(), ""),
 		log:    mock.NewLogr(),
 	}
 
@@ -165,7 +167,7 @@ func TestReconcileKeycloakRealm_ReconcileNotConnectedOwner(t *testing.T) {
 
 	r := ReconcileKeycloakRealm{
 		client: client,
-		helper: helper.MakeHelper(client, s, mock.NewLogr()),
+		helper: helper.MakeHelper(client, s, mock.NewLogr(), ""),
 		log:    mock.NewLogr(),
 	}
 
@@ -245,7 +247,7 @@ func TestReconcileKeycloakRealm_ReconcileInvalidOwnerCredentials(t *testing.T) {
 
 	r := ReconcileKeycloakRealm{
 		client: client,
-		helper: helper.MakeHelper(client, s, mock.NewLogr()),
+		helper: helper.MakeHelper(client, s, mock.NewLogr(), ""),
 		log:    mock.NewLogr(),
 	}
 
@@ -327,7 +329,7 @@ func TestReconcileKeycloakRealm_ReconcileWithKeycloakOwnerAndInvalidCreds(t *tes
 	// reconcile
 	r := ReconcileKeycloakRealm{
 		client: client,
-		helper: helper.MakeHelper(client, s, mock.NewLogr()),
+		helper: helper.MakeHelper(client, s, mock.NewLogr(), ""),
 		log:    mock.NewLogr(),
 	}
 
@@ -370,7 +372,7 @@ func TestReconcileKeycloakRealm_ReconcileDelete(t *testing.T) {
 	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: kRealmName, Namespace: ns}}
 	r := ReconcileKeycloakRealm{
 		client: client,
-		helper: helper.MakeHelper(client, s, mock.NewLogr()),
+		helper: helper.MakeHelper(client, s, mock.NewLogr(), ""),
 		log:    mock.NewLogr(),
 	}
 
@@ -404,7 +406,10 @@ func TestReconcileKeycloakRealm_Reconcile(t *testing.T) {
 	h.On("TryToDelete", &kr,
 		makeTerminator(kr.Spec.RealmName, kClient, logger),
 		keyCloakRealmOperatorFinalizerName).Return(false, nil)
-	h.On("UpdateStatus", &kr).Return(nil)
+	h.On("UpdateStatus", testifyMock.MatchedBy(func(obj *keycloakApi.KeycloakRealm) bool {
+		return obj.Status.Value == helper.StatusOK &&
+			meta.IsStatusConditionTrue(obj.Status.Conditions, keycloakApi.ConditionReady)
+	})).Return(nil)
 
 	ch := handler.MockRealmHandler{}
 	r := ReconcileKeycloakRealm{
@@ -424,3 +429,123 @@ func TestReconcileKeycloakRealm_Reconcile(t *testing.T) {
 		t.Fatal("success reconcile timeout is not set")
 	}
 }
+
+func TestReconcileKeycloakRealm_mapKeycloakToRealms(t *testing.T) {
+	sch := runtime.NewScheme()
+	require.NoError(t, keycloakApi.AddToScheme(sch))
+
+	ns := "security"
+	byOwnerRef := &keycloakApi.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{
+		Name: "by-owner-ref", Namespace: ns,
+		OwnerReferences: []metav1.OwnerReference{{Kind: "Keycloak", Name: "kc"}},
+	}}
+	bySpec := &keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "by-spec", Namespace: ns},
+		Spec:       keycloakApi.KeycloakRealmSpec{KeycloakOwner: "kc"},
+	}
+	byCluster := &keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "by-cluster", Namespace: "other"},
+		Spec:       keycloakApi.KeycloakRealmSpec{ClusterKeycloakRef: "kc"},
+	}
+	another := &keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "another", Namespace: ns},
+		Spec:       keycloakApi.KeycloakRealmSpec{KeycloakOwner: "another-kc"},
+	}
+
+	r := ReconcileKeycloakRealm{
+		client: fake.NewClientBuilder().WithScheme(sch).WithObjects(byOwnerRef, bySpec, byCluster, another).Build(),
+		log:    mock.NewLogr(),
+	}
+
+	requests := r.mapKeycloakToRealms(&keycloakApi.Keycloak{ObjectMeta: metav1.ObjectMeta{Name: "kc", Namespace: ns}})
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: ns, Name: "by-owner-ref"}},
+		{NamespacedName: types.NamespacedName{Namespace: ns, Name: "by-spec"}},
+	}, requests)
+
+	requests = r.mapClusterKeycloakToRealms(&keycloakApi.Keycloak{ObjectMeta: metav1.ObjectMeta{Name: "kc"}})
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "other", Name: "by-cluster"}},
+	}, requests)
+}
+
+func TestReconcileKeycloakRealm_mapSecretToRealms(t *testing.T) {
+	sch := runtime.NewScheme()
+	require.NoError(t, keycloakApi.AddToScheme(sch))
+
+	withSMTP := &keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "with-smtp", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmSpec{SMTP: &keycloakApi.RealmSMTP{
+			PasswordSecret: &keycloakApi.PasswordSecret{Name: "smtp", Key: "password"},
+		}},
+	}
+	withoutSMTP := &keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "without-smtp", Namespace: "ns"},
+	}
+
+	r := ReconcileKeycloakRealm{
+		client: fake.NewClientBuilder().WithScheme(sch).WithObjects(withSMTP, withoutSMTP).Build(),
+		log:    mock.NewLogr(),
+	}
+
+	requests := r.mapSecretToRealms(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "smtp", Namespace: "ns"}})
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "with-smtp"}},
+	}, requests)
+
+	assert.Empty(t, r.mapSecretToRealms(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "smtp", Namespace: "other"}}))
+}
+
+func TestReconcileKeycloakRealm_mapConfigMapToRealms(t *testing.T) {
+	sch := runtime.NewScheme()
+	require.NoError(t, keycloakApi.AddToScheme(sch))
+
+	withTexts := &keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "with-texts", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmSpec{Localization: &keycloakApi.RealmLocalization{
+			Texts: []keycloakApi.LocalizationTexts{{Locale: "de", ConfigMapName: "texts-de"}},
+		}},
+	}
+	withoutTexts := &keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "without-texts", Namespace: "ns"},
+	}
+	withProfile := &keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "with-profile", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmSpec{UserProfile: &keycloakApi.UserProfile{
+			ConfigMapKeyRef: &keycloakApi.KeySelector{Name: "profile", Key: "profile.json"},
+		}},
+	}
+
+	r := ReconcileKeycloakRealm{
+		client: fake.NewClientBuilder().WithScheme(sch).WithObjects(withTexts, withoutTexts, withProfile).Build(),
+		log:    mock.NewLogr(),
+	}
+
+	requests := r.mapConfigMapToRealms(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "texts-de", Namespace: "ns"}})
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "with-texts"}},
+	}, requests)
+
+	requests = r.mapConfigMapToRealms(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "profile", Namespace: "ns"}})
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "with-profile"}},
+	}, requests)
+
+	assert.Empty(t, r.mapConfigMapToRealms(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ns"}}))
+}
+
+func TestReconcileKeycloakRealm_successRequeueTime(t *testing.T) {
+	realm := &keycloakApi.KeycloakRealm{}
+	r := ReconcileKeycloakRealm{}
+
+	assert.Zero(t, r.successRequeueTime(realm))
+
+	realm.Spec.OTPPolicy = &keycloakApi.OTPPolicy{}
+	assert.Equal(t, driftCheckInterval, r.successRequeueTime(realm))
+
+	r.successReconcileTimeout = time.Minute
+	assert.Equal(t, time.Minute, r.successRequeueTime(realm))
+
+	r.successReconcileTimeout = time.Hour
+	assert.Equal(t, driftCheckInterval, r.successRequeueTime(realm))
+}
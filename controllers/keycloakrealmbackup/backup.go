@@ -0,0 +1,156 @@
+package keycloakrealmbackup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+)
+
+const (
+	backupLabel      = "v1.edp.epam.com/realm-backup"
+	realmAnnotation  = "v1.edp.epam.com/realm"
+	backupDataKey    = "realm.json.gz"
+	backupNameFormat = "20060102-150405"
+)
+
+// redactClientSecrets removes secrets of clients from the realm representation.
+func redactClientSecrets(representation []byte) ([]byte, error) {
+	realm := make(map[string]interface{})
+	if err := json.Unmarshal(representation, &realm); err != nil {
+		return nil, errors.Wrap(err, "unable to decode realm representation")
+	}
+
+	clients, _ := realm["clients"].([]interface{})
+	for _, c := range clients {
+		if cl, ok := c.(map[string]interface{}); ok {
+			delete(cl, "secret")
+		}
+	}
+
+	data, err := json.Marshal(realm)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to encode realm representation")
+	}
+
+	return data, nil
+}
+
+func compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+
+	if _, err := w.Write(data); err != nil {
+		return nil, errors.Wrap(err, "unable to compress backup")
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, errors.Wrap(err, "unable to compress backup")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// storeBackup saves gzipped realm representation into a new Secret or ConfigMap owned by the backup.
+// Backup names have one-second resolution, so an existing backup with the same name is kept.
+func storeBackup(
+	ctx context.Context,
+	k8sClient client.Client,
+	scheme *runtime.Scheme,
+	backup *keycloakApi.KeycloakRealmBackup,
+	realmName string,
+	data []byte,
+	now time.Time,
+) (string, error) {
+	meta := metav1.ObjectMeta{
+		Name:        fmt.Sprintf("%s-%s", backup.Name, now.UTC().Format(backupNameFormat)),
+		Namespace:   backup.Namespace,
+		Labels:      map[string]string{backupLabel: backup.Name},
+		Annotations: map[string]string{realmAnnotation: realmName},
+	}
+
+	var obj client.Object = &corev1.Secret{ObjectMeta: meta, Data: map[string][]byte{backupDataKey: data}}
+	if backup.Spec.Target.Kind == keycloakApi.RealmBackupTargetConfigMap {
+		obj = &corev1.ConfigMap{ObjectMeta: meta, BinaryData: map[string][]byte{backupDataKey: data}}
+	}
+
+	if err := controllerutil.SetControllerReference(backup, obj, scheme); err != nil {
+		return "", errors.Wrapf(err, "unable to set owner reference for backup %s", meta.Name)
+	}
+
+	if err := k8sClient.Create(ctx, obj); err != nil {
+		if k8sErrors.IsAlreadyExists(err) {
+			return meta.Name, nil
+		}
+
+		return "", errors.Wrapf(err, "unable to create backup %s", meta.Name)
+	}
+
+	return meta.Name, nil
+}
+
+// pruneBackups deletes the oldest backups which exceed number of generations.
+func pruneBackups(ctx context.Context, k8sClient client.Client, backup *keycloakApi.KeycloakRealmBackup) error {
+	opts := []client.ListOption{
+		client.InNamespace(backup.Namespace),
+		client.MatchingLabels{backupLabel: backup.Name},
+	}
+
+	var objects []client.Object
+
+	if backup.Spec.Target.Kind == keycloakApi.RealmBackupTargetConfigMap {
+		var list corev1.ConfigMapList
+		if err := k8sClient.List(ctx, &list, opts...); err != nil {
+			return errors.Wrap(err, "unable to list backups")
+		}
+
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	} else {
+		var list corev1.SecretList
+		if err := k8sClient.List(ctx, &list, opts...); err != nil {
+			return errors.Wrap(err, "unable to list backups")
+		}
+
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	}
+
+	generations := backup.Spec.Generations
+	if generations < 1 {
+		generations = 1
+	}
+
+	if len(objects) <= generations {
+		return nil
+	}
+
+	// Names contain backup time, so lexical order is chronological.
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].GetName() < objects[j].GetName()
+	})
+
+	for _, obj := range objects[:len(objects)-generations] {
+		if err := k8sClient.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return errors.Wrapf(err, "unable to delete backup %s", obj.GetName())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package keycloakrealmbackup
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+type Helper interface {
+	SetFailureCount(fc helper.FailureCountable) time.Duration
+	UpdateStatus(obj client.Object) error
+	GetOrCreateRealmOwnerRef(object helper.RealmChild, objectMeta *v1.ObjectMeta) (*keycloakApi.KeycloakRealm, error)
+	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
+}
+
+type Reconcile struct {
+	client client.Client
+	scheme *runtime.Scheme
+	log    logr.Logger
+	helper Helper
+	now    func() time.Time
+}
+
+func NewReconcile(client client.Client, scheme *runtime.Scheme, log logr.Logger, helper Helper) *Reconcile {
+	return &Reconcile{
+		client: client,
+		scheme: scheme,
+		helper: helper,
+		log:    log.WithName("keycloak-realm-backup"),
+		now:    time.Now,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+// Backups are scheduled by requeue, so successReconcileTimeout is not used.
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager) error {
+	pred := predicate.Funcs{
+		UpdateFunc: isSpecUpdated,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.KeycloakRealmBackup{}, builder.WithPredicates(pred)).
+		Complete(r)
+	if err != nil {
+		return fmt.Errorf("failed to setup KeycloakRealmBackup controller: %w", err)
+	}
+
+	return nil
+}
+
+func isSpecUpdated(e event.UpdateEvent) bool {
+	oo, ok := e.ObjectOld.(*keycloakApi.KeycloakRealmBackup)
+	if !ok {
+		return false
+	}
+
+	no, ok := e.ObjectNew.(*keycloakApi.KeycloakRealmBackup)
+	if !ok {
+		return false
+	}
+
+	return !reflect.DeepEqual(oo.Spec, no.Spec)
+}
+
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmbackups,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmbackups/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=secrets,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=configmaps,verbs=get;list;watch;create;delete
+
+// Reconcile is a loop for reconciling KeycloakRealmBackup object.
+func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resultErr error) {
+	log := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	log.Info("Reconciling KeycloakRealmBackup")
+
+	var instance keycloakApi.KeycloakRealmBackup
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return
+		}
+
+		resultErr = errors.Wrap(err, "unable to get keycloak realm backup from k8s")
+
+		return
+	}
+
+	if err := r.tryReconcile(ctx, &instance); err != nil {
+		instance.Status.Value = err.Error()
+		result.RequeueAfter = r.helper.SetFailureCount(&instance)
+
+		log.Error(err, "an error has occurred while handling keycloak realm backup", "name", request.Name)
+	} else {
+		helper.SetSuccessStatus(&instance)
+		result.RequeueAfter = instance.Status.NextScheduleTime.Sub(r.now())
+	}
+
+	if err := r.helper.UpdateStatus(&instance); err != nil {
+		resultErr = errors.Wrap(err, "unable to update status")
+	}
+
+	return
+}
+
+func (r *Reconcile) tryReconcile(ctx context.Context, backup *keycloakApi.KeycloakRealmBackup) error {
+	schedule, err := cron.ParseStandard(backup.Spec.Schedule)
+	if err != nil {
+		return errors.Wrapf(err, "unable to parse schedule %q", backup.Spec.Schedule)
+	}
+
+	now := r.now()
+
+	// The first backup is made right after creation, next ones follow the schedule.
+	if last := backup.Status.LastScheduleTime; last != nil {
+		if next := schedule.Next(last.Time); now.Before(next) {
+			backup.Status.NextScheduleTime = &v1.Time{Time: next}
+
+			return nil
+		}
+	}
+
+	if err = r.makeBackup(ctx, backup, now); err != nil {
+		return err
+	}
+
+	backup.Status.LastScheduleTime = &v1.Time{Time: now}
+	backup.Status.NextScheduleTime = &v1.Time{Time: schedule.Next(now)}
+
+	return nil
+}
+
+func (r *Reconcile) makeBackup(ctx context.Context, backup *keycloakApi.KeycloakRealmBackup, now time.Time) error {
+	realm, err := r.helper.GetOrCreateRealmOwnerRef(backup, &backup.ObjectMeta)
+	if err != nil {
+		return errors.Wrap(err, "unable to get realm owner ref")
+	}
+
+	kClient, err := r.helper.CreateKeycloakClientForRealm(ctx, realm)
+	if err != nil {
+		return errors.Wrap(err, "unable to create keycloak client")
+	}
+
+	representation, err := kClient.PartialExport(ctx, realm.Spec.RealmName, adapter.PartialExportOptions{
+		ExportClients:        backup.Spec.IncludeClients,
+		ExportGroupsAndRoles: backup.Spec.IncludeGroupsAndRoles,
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to export realm")
+	}
+
+	if backup.Spec.RedactClientSecrets {
+		if representation, err = redactClientSecrets(representation); err != nil {
+			return err
+		}
+	}
+
+	data, err := compress(representation)
+	if err != nil {
+		return err
+	}
+
+	name, err := storeBackup(ctx, r.client, r.scheme, backup, realm.Spec.RealmName, data, now)
+	if err != nil {
+		return err
+	}
+
+	backup.Status.LastBackup = name
+	backup.Status.LastBackupSize = len(data)
+
+	r.log.Info("Realm backup has been created", "backup", name, "size", len(data))
+
+	if err = pruneBackups(ctx, r.client, backup); err != nil {
+		return errors.Wrap(err, "unable to delete old backups")
+	}
+
+	return nil
+}
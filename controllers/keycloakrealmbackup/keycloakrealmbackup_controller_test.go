@@ -0,0 +1,243 @@
+package keycloakrealmbackup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+const export = `{"realm": "ns.realm1", "clients": [{"clientId": "app", "secret": "**********"}]}`
+
+func decompress(t *testing.T, data []byte) string {
+	t.Helper()
+
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	res, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	return string(res)
+}
+
+func TestReconcile_Reconcile(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	now := time.Date(2023, 5, 10, 2, 0, 30, 0, time.UTC)
+	realm := keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "realm1", Namespace: "ns"},
+		Spec:       keycloakApi.KeycloakRealmSpec{RealmName: "ns.realm1"},
+	}
+
+	newBackup := func(spec keycloakApi.KeycloakRealmBackupSpec, status keycloakApi.KeycloakRealmBackupStatus) *keycloakApi.KeycloakRealmBackup {
+		spec.Realm = realm.Name
+		if spec.Schedule == "" {
+			spec.Schedule = "0 2 * * *"
+		}
+
+		return &keycloakApi.KeycloakRealmBackup{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "ns"},
+			Spec:       spec,
+			Status:     status,
+		}
+	}
+
+	oldBackup := func(name string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns",
+			Labels:    map[string]string{backupLabel: "backup"},
+		}}
+	}
+
+	tests := []struct {
+		name        string
+		backup      *keycloakApi.KeycloakRealmBackup
+		objects     []client.Object
+		kClientMock func(m *adapter.Mock)
+		wantErr     string
+		wantRequeue time.Duration
+		check       func(t *testing.T, k8sClient client.Client, status keycloakApi.KeycloakRealmBackupStatus)
+	}{
+		{
+			name: "first backup to secret with redacted secrets",
+			backup: newBackup(keycloakApi.KeycloakRealmBackupSpec{
+				IncludeClients:      true,
+				RedactClientSecrets: true,
+				Generations:         2,
+			}, keycloakApi.KeycloakRealmBackupStatus{}),
+			objects: []client.Object{
+				oldBackup("backup-20230508-020000"),
+				oldBackup("backup-20230509-020000"),
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "backup-unrelated", Namespace: "ns"}},
+			},
+			kClientMock: func(m *adapter.Mock) {
+				m.On("PartialExport", realm.Spec.RealmName, adapter.PartialExportOptions{ExportClients: true}).
+					Return([]byte(export), nil)
+			},
+			wantRequeue: 24*time.Hour - 30*time.Second,
+			check: func(t *testing.T, k8sClient client.Client, status keycloakApi.KeycloakRealmBackupStatus) {
+				assert.Equal(t, helper.StatusOK, status.Value)
+				assert.Equal(t, "backup-20230510-020030", status.LastBackup)
+				assert.True(t, status.LastScheduleTime.Equal(&metav1.Time{Time: now}))
+				assert.Positive(t, status.LastBackupSize)
+
+				var secret corev1.Secret
+				require.NoError(t, k8sClient.Get(context.Background(),
+					types.NamespacedName{Namespace: "ns", Name: status.LastBackup}, &secret))
+				assert.Equal(t, "ns.realm1", secret.Annotations[realmAnnotation])
+				require.Len(t, secret.OwnerReferences, 1)
+				assert.Equal(t, "backup", secret.OwnerReferences[0].Name)
+				assert.Equal(t, "KeycloakRealmBackup", secret.OwnerReferences[0].Kind)
+				assert.JSONEq(t, `{"realm": "ns.realm1", "clients": [{"clientId": "app"}]}`,
+					decompress(t, secret.Data[backupDataKey]))
+
+				var list corev1.SecretList
+				require.NoError(t, k8sClient.List(context.Background(), &list,
+					client.MatchingLabels{backupLabel: "backup"}))
+				require.Len(t, list.Items, 2, "only 2 generations must be kept")
+				assert.Equal(t, "backup-20230509-020000", list.Items[0].Name)
+
+				require.NoError(t, k8sClient.Get(context.Background(),
+					types.NamespacedName{Namespace: "ns", Name: "backup-unrelated"}, &secret))
+			},
+		},
+		{
+			name: "scheduled backup to config map",
+			backup: newBackup(keycloakApi.KeycloakRealmBackupSpec{
+				IncludeGroupsAndRoles: true,
+				Target:                keycloakApi.RealmBackupTarget{Kind: keycloakApi.RealmBackupTargetConfigMap},
+				Generations:           3,
+			}, keycloakApi.KeycloakRealmBackupStatus{
+				LastScheduleTime: &metav1.Time{Time: now.Add(-24 * time.Hour)},
+			}),
+			kClientMock: func(m *adapter.Mock) {
+				m.On("PartialExport", realm.Spec.RealmName, adapter.PartialExportOptions{ExportGroupsAndRoles: true}).
+					Return([]byte(export), nil)
+			},
+			wantRequeue: 24*time.Hour - 30*time.Second,
+			check: func(t *testing.T, k8sClient client.Client, status keycloakApi.KeycloakRealmBackupStatus) {
+				var configMap corev1.ConfigMap
+				require.NoError(t, k8sClient.Get(context.Background(),
+					types.NamespacedName{Namespace: "ns", Name: status.LastBackup}, &configMap))
+				assert.JSONEq(t, export, decompress(t, configMap.BinaryData[backupDataKey]))
+			},
+		},
+		{
+			name:   "backup with the same name already exists",
+			backup: newBackup(keycloakApi.KeycloakRealmBackupSpec{}, keycloakApi.KeycloakRealmBackupStatus{}),
+			objects: []client.Object{
+				oldBackup("backup-20230510-020030"),
+			},
+			kClientMock: func(m *adapter.Mock) {
+				m.On("PartialExport", realm.Spec.RealmName, adapter.PartialExportOptions{}).
+					Return([]byte(export), nil)
+			},
+			wantRequeue: 24*time.Hour - 30*time.Second,
+			check: func(t *testing.T, k8sClient client.Client, status keycloakApi.KeycloakRealmBackupStatus) {
+				assert.Equal(t, helper.StatusOK, status.Value)
+				assert.Equal(t, "backup-20230510-020030", status.LastBackup)
+			},
+		},
+		{
+			name: "backup is not due yet",
+			backup: newBackup(keycloakApi.KeycloakRealmBackupSpec{}, keycloakApi.KeycloakRealmBackupStatus{
+				LastScheduleTime: &metav1.Time{Time: now.Add(-30 * time.Second)},
+				LastBackup:       "backup-20230510-020000",
+			}),
+			kClientMock: func(m *adapter.Mock) {},
+			wantRequeue: 24*time.Hour - 30*time.Second,
+			check: func(t *testing.T, k8sClient client.Client, status keycloakApi.KeycloakRealmBackupStatus) {
+				assert.Equal(t, "backup-20230510-020000", status.LastBackup)
+			},
+		},
+		{
+			name:        "invalid schedule",
+			backup:      newBackup(keycloakApi.KeycloakRealmBackupSpec{Schedule: "daily"}, keycloakApi.KeycloakRealmBackupStatus{}),
+			kClientMock: func(m *adapter.Mock) {},
+			wantErr:     `unable to parse schedule "daily": expected exactly 5 fields, found 1: [daily]`,
+		},
+		{
+			name:   "export failed",
+			backup: newBackup(keycloakApi.KeycloakRealmBackupSpec{}, keycloakApi.KeycloakRealmBackupStatus{}),
+			kClientMock: func(m *adapter.Mock) {
+				m.On("PartialExport", realm.Spec.RealmName, adapter.PartialExportOptions{}).
+					Return(nil, errors.New("forbidden"))
+			},
+			wantErr: "unable to export realm: forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			logger := mock.NewLogr()
+			hlp := helper.Mock{}
+			kClient := adapter.Mock{}
+
+			tt.kClientMock(&kClient)
+
+			k8sClient := fake.NewClientBuilder().WithScheme(sch).
+				WithObjects(append(tt.objects, tt.backup)...).Build()
+
+			hlp.On("GetOrCreateRealmOwnerRef", testifyMock.Anything, testifyMock.Anything).Return(&realm, nil)
+			hlp.On("CreateKeycloakClientForRealm", &realm).Return(&kClient, nil)
+			hlp.On("SetFailureCount", testifyMock.Anything).Return(time.Minute)
+
+			var status keycloakApi.KeycloakRealmBackupStatus
+
+			hlp.On("UpdateStatus", testifyMock.Anything).Run(func(args testifyMock.Arguments) {
+				status = args.Get(0).(*keycloakApi.KeycloakRealmBackup).Status
+			}).Return(nil)
+
+			r := NewReconcile(k8sClient, sch, logger, &hlp)
+			r.now = func() time.Time { return now }
+
+			res, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
+				Name:      tt.backup.Name,
+				Namespace: tt.backup.Namespace,
+			}})
+			require.NoError(t, err)
+
+			loggerSink, ok := logger.GetSink().(*mock.Logger)
+			require.True(t, ok, "wrong logger type")
+
+			if tt.wantErr != "" {
+				require.Error(t, loggerSink.LastError())
+				assert.Equal(t, tt.wantErr, loggerSink.LastError().Error())
+				assert.Equal(t, time.Minute, res.RequeueAfter)
+				assert.Nil(t, status.LastScheduleTime)
+
+				return
+			}
+
+			require.NoError(t, loggerSink.LastError())
+			assert.Equal(t, tt.wantRequeue, res.RequeueAfter)
+			tt.check(t, k8sClient, status)
+			kClient.AssertExpectations(t)
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/epam/edp-keycloak-operator/controllers/helper"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/secretref"
 )
 
 const finalizerName = "keycloak.realmcomponent.operator.finalizer.name"
@@ -30,6 +31,7 @@ type Helper interface {
 	UpdateStatus(obj client.Object) error
 	GetOrCreateRealmOwnerRef(object helper.RealmChild, objectMeta *v1.ObjectMeta) (*keycloakApi.KeycloakRealm, error)
 	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
+	TryToReleaseRealmChild(ctx context.Context, obj helper.Deletable, realmChild helper.RealmChild, finalizer string) (bool, error)
 	TryToDelete(ctx context.Context, obj helper.Deletable, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
 	GetParentComponent(object helper.ComponentChild) (*keycloakApi.KeycloakRealmComponent, error)
 }
@@ -38,14 +40,16 @@ type Reconcile struct {
 	client                  client.Client
 	log                     logr.Logger
 	helper                  Helper
+	secretRef               *secretref.SecretRef
 	successReconcileTimeout time.Duration
 }
 
 func NewReconcile(client client.Client, log logr.Logger, helper Helper) *Reconcile {
 	return &Reconcile{
-		client: client,
-		helper: helper,
-		log:    log.WithName("keycloak-realm-component"),
+		client:    client,
+		helper:    helper,
+		secretRef: secretref.NewSecretRef(client),
+		log:       log.WithName("keycloak-realm-component"),
 	}
 }
 
@@ -101,6 +105,21 @@ func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (r
 		return
 	}
 
+	if !instance.GetDeletionTimestamp().IsZero() {
+		released, err := r.helper.TryToReleaseRealmChild(ctx, &instance, &instance, finalizerName)
+		if err != nil {
+			resultErr = errors.Wrap(err, "unable to release keycloak realm component")
+
+			return
+		}
+
+		if released {
+			log.Info("Parent realm is retained or deleted, keycloak resource is kept")
+
+			return
+		}
+	}
+
 	if err := r.tryReconcile(ctx, &instance); err != nil {
 		instance.Status.Value = err.Error()
 		result.RequeueAfter = r.helper.SetFailureCount(&instance)
@@ -144,6 +163,10 @@ func (r *Reconcile) tryReconcile(ctx context.Context, keycloakRealmComponent *ke
 		keycloakComponent = createKeycloakComponentFromSpec(&keycloakRealmComponent.Spec)
 	}
 
+	if err = r.secretRef.MapComponentConfigSecretsRefs(ctx, keycloakComponent.Config, keycloakRealmComponent.Namespace); err != nil {
+		return fmt.Errorf("unable to map config secrets: %w", err)
+	}
+
 	cmp, err := kClient.GetComponent(ctx, realm.Spec.RealmName, keycloakRealmComponent.Spec.Name)
 	if err != nil && !adapter.IsErrNotFound(err) {
 		return errors.Wrap(err, "unable to get component, unexpected error")
@@ -172,7 +195,7 @@ func (r *Reconcile) tryReconcile(ctx context.Context, keycloakRealmComponent *ke
 func createKeycloakSubComponentFromSpec(spec *keycloakApi.KeycloakComponentSpec, parentId string) *adapter.Component {
 	return &adapter.Component{
 		Name:         spec.Name,
-		Config:       spec.Config,
+		Config:       copyConfig(spec.Config),
 		ProviderID:   spec.ProviderID,
 		ProviderType: spec.ProviderType,
 		ParentID:     parentId,
@@ -182,8 +205,22 @@ func createKeycloakSubComponentFromSpec(spec *keycloakApi.KeycloakComponentSpec,
 func createKeycloakComponentFromSpec(spec *keycloakApi.KeycloakComponentSpec) *adapter.Component {
 	return &adapter.Component{
 		Name:         spec.Name,
-		Config:       spec.Config,
+		Config:       copyConfig(spec.Config),
 		ProviderID:   spec.ProviderID,
 		ProviderType: spec.ProviderType,
 	}
 }
+
+// copyConfig copies component config, so resolved secrets are not stored in the spec.
+func copyConfig(config map[string][]string) map[string][]string {
+	if config == nil {
+		return nil
+	}
+
+	cp := make(map[string][]string, len(config))
+	for k, v := range config {
+		cp[k] = append([]string(nil), v...)
+	}
+
+	return cp
+}
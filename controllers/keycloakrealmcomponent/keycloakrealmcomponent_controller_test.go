@@ -7,8 +7,10 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -54,7 +56,10 @@ func TestReconcile_Reconcile(t *testing.T) {
 	kcAdapter.On("UpdateComponent", realm.Spec.RealmName, &testComp).Return(nil)
 	hlp.On("TryToDelete", &comp, makeTerminator(realm.Spec.RealmName, comp.Spec.Name, &kcAdapter, logger),
 		finalizerName).Return(false, nil)
-	hlp.On("UpdateStatus", &comp).Return(nil)
+	hlp.On("UpdateStatus", testifyMock.MatchedBy(func(obj *keycloakApi.KeycloakRealmComponent) bool {
+		return obj.Status.Value == helper.StatusOK &&
+			meta.IsStatusConditionTrue(obj.Status.Conditions, keycloakApi.ConditionReady)
+	})).Return(nil)
 	r := NewReconcile(client, logger, &hlp)
 
 	res, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
@@ -130,7 +135,10 @@ func TestReconcile_ReconcileSubComponent(t *testing.T) {
 	kcAdapter.On("UpdateComponent", realm.Spec.RealmName, &testComp).Return(nil)
 	hlp.On("TryToDelete", &comp, makeTerminator(realm.Spec.RealmName, comp.Spec.Name, &kcAdapter, logger),
 		finalizerName).Return(false, nil)
-	hlp.On("UpdateStatus", &comp).Return(nil)
+	hlp.On("UpdateStatus", testifyMock.MatchedBy(func(obj *keycloakApi.KeycloakRealmComponent) bool {
+		return obj.Status.Value == helper.StatusOK &&
+			meta.IsStatusConditionTrue(obj.Status.Conditions, keycloakApi.ConditionReady)
+	})).Return(nil)
 	r := NewReconcile(client, logger, &hlp)
 
 	res, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
@@ -26,6 +26,7 @@ type Helper interface {
 	SetFailureCount(fc helper.FailureCountable) time.Duration
 	UpdateStatus(obj client.Object) error
 	GetOrCreateRealmOwnerRef(object helper.RealmChild, objectMeta *v1.ObjectMeta) (*keycloakApi.KeycloakRealm, error)
+	TryToReleaseRealmChild(ctx context.Context, obj helper.Deletable, realmChild helper.RealmChild, finalizer string) (bool, error)
 	TryToDelete(ctx context.Context, obj helper.Deletable, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
 	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
 }
@@ -83,6 +84,21 @@ func (r *ReconcileKeycloakRealmGroup) Reconcile(ctx context.Context, request rec
 		return
 	}
 
+	if !instance.GetDeletionTimestamp().IsZero() {
+		released, err := r.helper.TryToReleaseRealmChild(ctx, &instance, &instance, keyCloakRealmGroupOperatorFinalizerName)
+		if err != nil {
+			resultErr = errors.Wrap(err, "unable to release keycloak realm group")
+
+			return
+		}
+
+		if released {
+			log.Info("Parent realm is retained or deleted, keycloak resource is kept")
+
+			return
+		}
+	}
+
 	if err := r.tryReconcile(ctx, &instance); err != nil {
 		instance.Status.Value = err.Error()
 		result.RequeueAfter = r.helper.SetFailureCount(&instance)
@@ -5,8 +5,10 @@ import (
 	"testing"
 	"time"
 
+	testifyMock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -58,7 +60,10 @@ func TestReconcileKeycloakRealmGroup_Reconcile(t *testing.T) {
 	kcMock.On("SyncRealmGroup", "ns.realm1", &group.Spec).Return("id11", nil)
 	h.On("TryToDelete", &group, makeTerminator(&kcMock, realm.Spec.RealmName, group.Spec.Name, logger),
 		keyCloakRealmGroupOperatorFinalizerName).Return(true, nil)
-	h.On("UpdateStatus", &group).Return(nil)
+	h.On("UpdateStatus", testifyMock.MatchedBy(func(obj *keycloakApi.KeycloakRealmGroup) bool {
+		return obj.Status.Value == helper.StatusOK &&
+			meta.IsStatusConditionTrue(obj.Status.Conditions, keycloakApi.ConditionReady)
+	})).Return(nil)
 
 	r := ReconcileKeycloakRealmGroup{
 		client:                  client,
@@ -83,3 +88,36 @@ func TestReconcileKeycloakRealmGroup_Reconcile(t *testing.T) {
 		t.Fatal("success reconcile timeout is not set")
 	}
 }
+
+func TestReconcileKeycloakRealmGroup_Reconcile_RealmRetained(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	group := keycloakApi.KeycloakRealmGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "ns",
+			Name:              "group1",
+			Finalizers:        []string{keyCloakRealmGroupOperatorFinalizerName},
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+		},
+		Spec: keycloakApi.KeycloakRealmGroupSpec{Realm: "realm1", Name: "group1"},
+	}
+
+	h := helper.Mock{}
+	h.On("TryToReleaseRealmChild", testifyMock.AnythingOfType("*v1.KeycloakRealmGroup"),
+		testifyMock.AnythingOfType("*v1.KeycloakRealmGroup"), keyCloakRealmGroupOperatorFinalizerName).Return(true, nil)
+
+	r := ReconcileKeycloakRealmGroup{
+		client: fake.NewClientBuilder().WithScheme(sch).WithObjects(&group).Build(),
+		helper: &h,
+		log:    mock.NewLogr(),
+	}
+
+	res, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: "ns",
+		Name:      "group1",
+	}})
+	require.NoError(t, err)
+	require.Equal(t, reconcile.Result{}, res)
+	h.AssertExpectations(t)
+}
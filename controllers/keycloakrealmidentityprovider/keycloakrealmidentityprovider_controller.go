@@ -21,6 +21,7 @@ import (
 	"github.com/epam/edp-keycloak-operator/controllers/helper"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/secretref"
 )
 
 const finalizerName = "keycloak.realmidp.operator.finalizer.name"
@@ -30,6 +31,7 @@ type Helper interface {
 	UpdateStatus(obj client.Object) error
 	GetOrCreateRealmOwnerRef(object helper.RealmChild, objectMeta *v1.ObjectMeta) (*keycloakApi.KeycloakRealm, error)
 	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
+	TryToReleaseRealmChild(ctx context.Context, obj helper.Deletable, realmChild helper.RealmChild, finalizer string) (bool, error)
 	TryToDelete(ctx context.Context, obj helper.Deletable, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
 }
 
@@ -37,14 +39,16 @@ type Reconcile struct {
 	client                  client.Client
 	log                     logr.Logger
 	helper                  Helper
+	secretRef               *secretref.SecretRef
 	successReconcileTimeout time.Duration
 }
 
 func NewReconcile(client client.Client, log logr.Logger, helper Helper) *Reconcile {
 	return &Reconcile{
-		client: client,
-		helper: helper,
-		log:    log.WithName("keycloak-realm-identity-provider"),
+		client:    client,
+		helper:    helper,
+		secretRef: secretref.NewSecretRef(client),
+		log:       log.WithName("keycloak-realm-identity-provider"),
 	}
 }
 
@@ -102,6 +106,21 @@ func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (r
 		return
 	}
 
+	if !instance.GetDeletionTimestamp().IsZero() {
+		released, err := r.helper.TryToReleaseRealmChild(ctx, &instance, &instance, finalizerName)
+		if err != nil {
+			resultErr = errors.Wrap(err, "unable to release keycloak realm idp")
+
+			return
+		}
+
+		if released {
+			log.Info("Parent realm is retained or deleted, keycloak resource is kept")
+
+			return
+		}
+	}
+
 	if err := r.tryReconcile(ctx, &instance); err != nil {
 		instance.Status.Value = err.Error()
 		result.RequeueAfter = r.helper.SetFailureCount(&instance)
@@ -132,6 +151,10 @@ func (r *Reconcile) tryReconcile(ctx context.Context, keycloakRealmIDP *keycloak
 
 	keycloakIDP := createKeycloakIDPFromSpec(&keycloakRealmIDP.Spec)
 
+	if err = r.secretRef.MapConfigSecretsRefs(ctx, keycloakIDP.Config, keycloakRealmIDP.Namespace); err != nil {
+		return fmt.Errorf("unable to map config secrets: %w", err)
+	}
+
 	providerExists, err := kClient.IdentityProviderExists(ctx, realm.Spec.RealmName, keycloakRealmIDP.Spec.Alias)
 	if err != nil {
 		return fmt.Errorf("failed to check if the identity provider exists: %w", err)
@@ -201,7 +224,7 @@ func createKeycloakIDPMapperFromSpec(spec *keycloakApi.IdentityProviderMapper) *
 
 func createKeycloakIDPFromSpec(spec *keycloakApi.KeycloakRealmIdentityProviderSpec) *adapter.IdentityProvider {
 	return &adapter.IdentityProvider{
-		Config:                    spec.Config,
+		Config:                    copyConfig(spec.Config),
 		ProviderID:                spec.ProviderID,
 		Alias:                     spec.Alias,
 		Enabled:                   spec.Enabled,
@@ -214,3 +237,17 @@ func createKeycloakIDPFromSpec(spec *keycloakApi.KeycloakRealmIdentityProviderSp
 		TrustEmail:                spec.TrustEmail,
 	}
 }
+
+// copyConfig copies identity provider config, so resolved secrets are not stored in the spec.
+func copyConfig(config map[string]string) map[string]string {
+	if config == nil {
+		return nil
+	}
+
+	cp := make(map[string]string, len(config))
+	for k, v := range config {
+		cp[k] = v
+	}
+
+	return cp
+}
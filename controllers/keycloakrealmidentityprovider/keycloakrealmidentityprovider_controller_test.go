@@ -7,8 +7,10 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -125,7 +127,10 @@ func TestNewReconcile(t *testing.T) {
 	hlp.On("TryToDelete", &idp,
 		makeTerminator(realm.Spec.RealmName, idp.Spec.Alias, &kcAdapter, l), finalizerName).
 		Return(false, nil)
-	hlp.On("UpdateStatus", &idp).Return(nil)
+	hlp.On("UpdateStatus", testifyMock.MatchedBy(func(obj *keycloakApi.KeycloakRealmIdentityProvider) bool {
+		return obj.Status.Value == helper.StatusOK &&
+			meta.IsStatusConditionTrue(obj.Status.Conditions, keycloakApi.ConditionReady)
+	})).Return(nil)
 
 	r := NewReconcile(fakeCl, l, &hlp)
 	_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
@@ -158,6 +163,7 @@ func TestNewReconcile(t *testing.T) {
 	r.client = fake.NewClientBuilder().WithScheme(sch).WithRuntimeObjects(&idp).Build()
 
 	hlp.On("SetFailureCount", &idp).Return(time.Second)
+	hlp.On("UpdateStatus", &idp).Return(nil)
 
 	_, err = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
 		Name:      idp.Name,
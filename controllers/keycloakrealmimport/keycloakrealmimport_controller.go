@@ -0,0 +1,300 @@
+package keycloakrealmimport
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	ctrlHandler "sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+const (
+	actionAdded       = "ADDED"
+	actionSkipped     = "SKIPPED"
+	actionOverwritten = "OVERWRITTEN"
+)
+
+type Helper interface {
+	SetFailureCount(fc helper.FailureCountable) time.Duration
+	UpdateStatus(obj client.Object) error
+	GetOrCreateRealmOwnerRef(object helper.RealmChild, objectMeta *v1.ObjectMeta) (*keycloakApi.KeycloakRealm, error)
+	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
+}
+
+type Reconcile struct {
+	client                  client.Client
+	log                     logr.Logger
+	helper                  Helper
+	successReconcileTimeout time.Duration
+}
+
+func NewReconcile(client client.Client, log logr.Logger, helper Helper) *Reconcile {
+	return &Reconcile{
+		client: client,
+		helper: helper,
+		log:    log.WithName("keycloak-realm-import"),
+	}
+}
+
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+	r.successReconcileTimeout = successReconcileTimeout
+
+	pred := predicate.Funcs{
+		UpdateFunc: isSpecUpdated,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.KeycloakRealmImport{}, builder.WithPredicates(pred)).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, ctrlHandler.EnqueueRequestsFromMapFunc(r.mapConfigMapToImports)).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, ctrlHandler.EnqueueRequestsFromMapFunc(r.mapSecretToImports)).
+		Complete(r)
+	if err != nil {
+		return fmt.Errorf("failed to setup KeycloakRealmImport controller: %w", err)
+	}
+
+	return nil
+}
+
+func isSpecUpdated(e event.UpdateEvent) bool {
+	oo, ok := e.ObjectOld.(*keycloakApi.KeycloakRealmImport)
+	if !ok {
+		return false
+	}
+
+	no, ok := e.ObjectNew.(*keycloakApi.KeycloakRealmImport)
+	if !ok {
+		return false
+	}
+
+	return !reflect.DeepEqual(oo.Spec, no.Spec)
+}
+
+// mapConfigMapToImports returns requests for imports which take realm representation from the config map.
+func (r *Reconcile) mapConfigMapToImports(configMap client.Object) []reconcile.Request {
+	return r.mapSourceToImports(configMap, func(s *keycloakApi.RealmImportSource) *keycloakApi.KeySelector {
+		return s.ConfigMapKeyRef
+	})
+}
+
+// mapSecretToImports returns requests for imports which take realm representation from the secret.
+func (r *Reconcile) mapSecretToImports(secret client.Object) []reconcile.Request {
+	return r.mapSourceToImports(secret, func(s *keycloakApi.RealmImportSource) *keycloakApi.KeySelector {
+		return s.SecretKeyRef
+	})
+}
+
+func (r *Reconcile) mapSourceToImports(
+	obj client.Object,
+	ref func(s *keycloakApi.RealmImportSource) *keycloakApi.KeySelector,
+) []reconcile.Request {
+	var list keycloakApi.KeycloakRealmImportList
+	if err := r.client.List(context.Background(), &list, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.log.Error(err, "unable to list realm imports", "source", obj.GetName())
+
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for i := range list.Items {
+		if sel := ref(&list.Items[i].Spec.Source); sel != nil && sel.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: list.Items[i].Namespace, Name: list.Items[i].Name},
+			})
+		}
+	}
+
+	return requests
+}
+
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmimports,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmimports/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=configmaps,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=secrets,verbs=get;list;watch
+
+// Reconcile is a loop for reconciling KeycloakRealmImport object.
+func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resultErr error) {
+	log := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	log.Info("Reconciling KeycloakRealmImport")
+
+	var instance keycloakApi.KeycloakRealmImport
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return
+		}
+
+		resultErr = errors.Wrap(err, "unable to get keycloak realm import from k8s")
+
+		return
+	}
+
+	if err := r.tryReconcile(ctx, &instance); err != nil {
+		instance.Status.Value = err.Error()
+		result.RequeueAfter = r.helper.SetFailureCount(&instance)
+
+		log.Error(err, "an error has occurred while handling keycloak realm import", "name", request.Name)
+	} else {
+		helper.SetSuccessStatus(&instance)
+		result.RequeueAfter = r.successReconcileTimeout
+	}
+
+	if err := r.helper.UpdateStatus(&instance); err != nil {
+		resultErr = errors.Wrap(err, "unable to update status")
+	}
+
+	return
+}
+
+func (r *Reconcile) tryReconcile(ctx context.Context, realmImport *keycloakApi.KeycloakRealmImport) error {
+	representation, err := r.getRepresentation(ctx, realmImport)
+	if err != nil {
+		return err
+	}
+
+	policy := realmImport.Spec.IfResourceExists
+	if policy == "" {
+		policy = adapter.PartialImportPolicyFail
+	}
+
+	realm, err := r.helper.GetOrCreateRealmOwnerRef(realmImport, &realmImport.ObjectMeta)
+	if err != nil {
+		return errors.Wrap(err, "unable to get realm owner ref")
+	}
+
+	hash := importHash(representation, policy, realm.Spec.RealmName)
+	if hash == realmImport.Status.ImportedHash {
+		r.log.Info("Realm representation is already imported, skip", "name", realmImport.Name)
+
+		return nil
+	}
+
+	kClient, err := r.helper.CreateKeycloakClientForRealm(ctx, realm)
+	if err != nil {
+		return errors.Wrap(err, "unable to create keycloak client")
+	}
+
+	res, err := kClient.PartialImport(ctx, realm.Spec.RealmName, representation, policy)
+	if err != nil {
+		return errors.Wrap(err, "unable to import realm resources")
+	}
+
+	realmImport.Status.ImportedHash = hash
+	realmImport.Status.Added = res.Added
+	realmImport.Status.Skipped = res.Skipped
+	realmImport.Status.Overwritten = res.Overwritten
+	realmImport.Status.Resources = resourcesStatus(res.Results)
+
+	r.log.Info("Realm resources have been imported", "name", realmImport.Name,
+		"added", res.Added, "skipped", res.Skipped, "overwritten", res.Overwritten)
+
+	return nil
+}
+
+// getRepresentation returns realm representation from the ConfigMap or Secret.
+func (r *Reconcile) getRepresentation(ctx context.Context, realmImport *keycloakApi.KeycloakRealmImport) ([]byte, error) {
+	src := realmImport.Spec.Source
+
+	if (src.ConfigMapKeyRef == nil) == (src.SecretKeyRef == nil) {
+		return nil, errors.New("exactly one of configMapKeyRef or secretKeyRef should be specified")
+	}
+
+	if src.ConfigMapKeyRef != nil {
+		var configMap corev1.ConfigMap
+		if err := r.client.Get(ctx, types.NamespacedName{
+			Namespace: realmImport.Namespace,
+			Name:      src.ConfigMapKeyRef.Name,
+		}, &configMap); err != nil {
+			return nil, errors.Wrapf(err, "unable to get config map %s", src.ConfigMapKeyRef.Name)
+		}
+
+		data, ok := configMap.Data[src.ConfigMapKeyRef.Key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in config map %s", src.ConfigMapKeyRef.Key, src.ConfigMapKeyRef.Name)
+		}
+
+		return []byte(data), nil
+	}
+
+	var secret corev1.Secret
+	if err := r.client.Get(ctx, types.NamespacedName{
+		Namespace: realmImport.Namespace,
+		Name:      src.SecretKeyRef.Name,
+	}, &secret); err != nil {
+		return nil, errors.Wrapf(err, "unable to get secret %s", src.SecretKeyRef.Name)
+	}
+
+	data, ok := secret.Data[src.SecretKeyRef.Key]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in secret %s", src.SecretKeyRef.Key, src.SecretKeyRef.Name)
+	}
+
+	return data, nil
+}
+
+// importHash identifies imported representation, so it is imported again
+// if the representation, the policy or the target realm is changed.
+func importHash(representation []byte, policy, realmName string) string {
+	h := sha256.New()
+	h.Write([]byte(realmName))
+	h.Write([]byte{0})
+	h.Write([]byte(policy))
+	h.Write([]byte{0})
+	h.Write(representation)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// resourcesStatus aggregates import results by resource type.
+func resourcesStatus(results []adapter.PartialImportResourceItem) []keycloakApi.RealmImportResourceStatus {
+	byType := make(map[string]*keycloakApi.RealmImportResourceStatus)
+
+	for _, res := range results {
+		st, ok := byType[res.ResourceType]
+		if !ok {
+			st = &keycloakApi.RealmImportResourceStatus{ResourceType: res.ResourceType}
+			byType[res.ResourceType] = st
+		}
+
+		switch res.Action {
+		case actionAdded:
+			st.Added++
+		case actionSkipped:
+			st.Skipped++
+		case actionOverwritten:
+			st.Overwritten++
+		}
+	}
+
+	statuses := make([]keycloakApi.RealmImportResourceStatus, 0, len(byType))
+	for _, st := range byType {
+		statuses = append(statuses, *st)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ResourceType < statuses[j].ResourceType
+	})
+
+	return statuses
+}
@@ -0,0 +1,266 @@
+package keycloakrealmimport
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+const representation = `{"clients": [{"clientId": "app"}]}`
+
+func TestReconcile_Reconcile(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	realm := keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "realm1", Namespace: "ns"},
+		Spec:       keycloakApi.KeycloakRealmSpec{RealmName: "ns.realm1"},
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "realm-export", Namespace: "ns"},
+		Data:       map[string]string{"realm.json": representation},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "realm-export", Namespace: "ns"},
+		Data:       map[string][]byte{"realm.json": []byte(representation)},
+	}
+
+	newImport := func(source keycloakApi.RealmImportSource, policy string, status keycloakApi.KeycloakRealmImportStatus) *keycloakApi.KeycloakRealmImport {
+		return &keycloakApi.KeycloakRealmImport{
+			ObjectMeta: metav1.ObjectMeta{Name: "import", Namespace: "ns"},
+			Spec: keycloakApi.KeycloakRealmImportSpec{
+				Realm:            realm.Name,
+				Source:           source,
+				IfResourceExists: policy,
+			},
+			Status: status,
+		}
+	}
+
+	configMapSource := keycloakApi.RealmImportSource{
+		ConfigMapKeyRef: &keycloakApi.KeySelector{Name: "realm-export", Key: "realm.json"},
+	}
+
+	tests := []struct {
+		name        string
+		realmImport *keycloakApi.KeycloakRealmImport
+		objects     []client.Object
+		kClientMock func(m *adapter.Mock)
+		wantErr     string
+		checkStatus func(t *testing.T, status keycloakApi.KeycloakRealmImportStatus)
+	}{
+		{
+			name:        "import from config map",
+			realmImport: newImport(configMapSource, adapter.PartialImportPolicySkip, keycloakApi.KeycloakRealmImportStatus{}),
+			objects:     []client.Object{configMap},
+			kClientMock: func(m *adapter.Mock) {
+				m.On("PartialImport", realm.Spec.RealmName, []byte(representation), "SKIP").
+					Return(&adapter.PartialImportResult{
+						Added:   2,
+						Skipped: 1,
+						Results: []adapter.PartialImportResourceItem{
+							{Action: "ADDED", ResourceType: "REALM_ROLE"},
+							{Action: "ADDED", ResourceType: "CLIENT"},
+							{Action: "SKIPPED", ResourceType: "CLIENT"},
+						},
+					}, nil)
+			},
+			checkStatus: func(t *testing.T, status keycloakApi.KeycloakRealmImportStatus) {
+				assert.Equal(t, helper.StatusOK, status.Value)
+				assert.Equal(t, importHash([]byte(representation), "SKIP", realm.Spec.RealmName), status.ImportedHash)
+				assert.Equal(t, 2, status.Added)
+				assert.Equal(t, 1, status.Skipped)
+				assert.Equal(t, []keycloakApi.RealmImportResourceStatus{
+					{ResourceType: "CLIENT", Added: 1, Skipped: 1},
+					{ResourceType: "REALM_ROLE", Added: 1},
+				}, status.Resources)
+			},
+		},
+		{
+			name: "import from secret with default policy",
+			realmImport: newImport(keycloakApi.RealmImportSource{
+				SecretKeyRef: &keycloakApi.KeySelector{Name: "realm-export", Key: "realm.json"},
+			}, "", keycloakApi.KeycloakRealmImportStatus{}),
+			objects: []client.Object{secret},
+			kClientMock: func(m *adapter.Mock) {
+				m.On("PartialImport", realm.Spec.RealmName, []byte(representation), "FAIL").
+					Return(&adapter.PartialImportResult{Added: 1}, nil)
+			},
+			checkStatus: func(t *testing.T, status keycloakApi.KeycloakRealmImportStatus) {
+				assert.Equal(t, 1, status.Added)
+				assert.Empty(t, status.Resources)
+			},
+		},
+		{
+			name: "already imported",
+			realmImport: newImport(configMapSource, adapter.PartialImportPolicySkip, keycloakApi.KeycloakRealmImportStatus{
+				ImportedHash: importHash([]byte(representation), "SKIP", realm.Spec.RealmName),
+				Added:        3,
+			}),
+			objects:     []client.Object{configMap},
+			kClientMock: func(m *adapter.Mock) {},
+			checkStatus: func(t *testing.T, status keycloakApi.KeycloakRealmImportStatus) {
+				assert.Equal(t, helper.StatusOK, status.Value)
+				assert.Equal(t, 3, status.Added)
+			},
+		},
+		{
+			name: "policy change triggers import",
+			realmImport: newImport(configMapSource, adapter.PartialImportPolicyOverwrite, keycloakApi.KeycloakRealmImportStatus{
+				ImportedHash: importHash([]byte(representation), "SKIP", realm.Spec.RealmName),
+			}),
+			objects: []client.Object{configMap},
+			kClientMock: func(m *adapter.Mock) {
+				m.On("PartialImport", realm.Spec.RealmName, []byte(representation), "OVERWRITE").
+					Return(&adapter.PartialImportResult{Overwritten: 1}, nil)
+			},
+			checkStatus: func(t *testing.T, status keycloakApi.KeycloakRealmImportStatus) {
+				assert.Equal(t, 1, status.Overwritten)
+			},
+		},
+		{
+			name: "target realm change triggers import",
+			realmImport: newImport(configMapSource, adapter.PartialImportPolicySkip, keycloakApi.KeycloakRealmImportStatus{
+				ImportedHash: importHash([]byte(representation), "SKIP", "ns.previous-realm"),
+			}),
+			objects: []client.Object{configMap},
+			kClientMock: func(m *adapter.Mock) {
+				m.On("PartialImport", realm.Spec.RealmName, []byte(representation), "SKIP").
+					Return(&adapter.PartialImportResult{Added: 2}, nil)
+			},
+			checkStatus: func(t *testing.T, status keycloakApi.KeycloakRealmImportStatus) {
+				assert.Equal(t, 2, status.Added)
+				assert.Equal(t, importHash([]byte(representation), "SKIP", realm.Spec.RealmName), status.ImportedHash)
+			},
+		},
+		{
+			name:        "import failed",
+			realmImport: newImport(configMapSource, adapter.PartialImportPolicyFail, keycloakApi.KeycloakRealmImportStatus{}),
+			objects:     []client.Object{configMap},
+			kClientMock: func(m *adapter.Mock) {
+				m.On("PartialImport", realm.Spec.RealmName, []byte(representation), "FAIL").
+					Return(nil, errors.New("client app already exists"))
+			},
+			wantErr: "unable to import realm resources: client app already exists",
+		},
+		{
+			name:        "config map key not found",
+			realmImport: newImport(configMapSource, "", keycloakApi.KeycloakRealmImportStatus{}),
+			objects: []client.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "realm-export", Namespace: "ns"},
+			}},
+			kClientMock: func(m *adapter.Mock) {},
+			wantErr:     "key realm.json not found in config map realm-export",
+		},
+		{
+			name:        "source is not set",
+			realmImport: newImport(keycloakApi.RealmImportSource{}, "", keycloakApi.KeycloakRealmImportStatus{}),
+			kClientMock: func(m *adapter.Mock) {},
+			wantErr:     "exactly one of configMapKeyRef or secretKeyRef should be specified",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			logger := mock.NewLogr()
+			hlp := helper.Mock{}
+			kClient := adapter.Mock{}
+
+			tt.kClientMock(&kClient)
+
+			k8sClient := fake.NewClientBuilder().WithScheme(sch).
+				WithObjects(append(tt.objects, tt.realmImport)...).Build()
+
+			hlp.On("GetOrCreateRealmOwnerRef", testifyMock.Anything, testifyMock.Anything).Return(&realm, nil)
+			hlp.On("CreateKeycloakClientForRealm", &realm).Return(&kClient, nil)
+			hlp.On("SetFailureCount", testifyMock.Anything).Return(time.Minute)
+
+			var status keycloakApi.KeycloakRealmImportStatus
+
+			hlp.On("UpdateStatus", testifyMock.Anything).Run(func(args testifyMock.Arguments) {
+				status = args.Get(0).(*keycloakApi.KeycloakRealmImport).Status
+			}).Return(nil)
+
+			r := NewReconcile(k8sClient, logger, &hlp)
+			r.successReconcileTimeout = time.Hour
+
+			res, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
+				Name:      tt.realmImport.Name,
+				Namespace: tt.realmImport.Namespace,
+			}})
+			require.NoError(t, err)
+
+			loggerSink, ok := logger.GetSink().(*mock.Logger)
+			require.True(t, ok, "wrong logger type")
+
+			if tt.wantErr != "" {
+				require.Error(t, loggerSink.LastError())
+				assert.Equal(t, tt.wantErr, loggerSink.LastError().Error())
+				assert.Equal(t, tt.wantErr, status.Value)
+				assert.Equal(t, time.Minute, res.RequeueAfter)
+
+				return
+			}
+
+			require.NoError(t, loggerSink.LastError())
+			assert.Equal(t, r.successReconcileTimeout, res.RequeueAfter)
+			tt.checkStatus(t, status)
+			kClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReconcile_mapSourceToImports(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	k8sClient := fake.NewClientBuilder().WithScheme(sch).WithObjects(
+		&keycloakApi.KeycloakRealmImport{
+			ObjectMeta: metav1.ObjectMeta{Name: "from-config-map", Namespace: "ns"},
+			Spec: keycloakApi.KeycloakRealmImportSpec{Source: keycloakApi.RealmImportSource{
+				ConfigMapKeyRef: &keycloakApi.KeySelector{Name: "export", Key: "realm.json"},
+			}},
+		},
+		&keycloakApi.KeycloakRealmImport{
+			ObjectMeta: metav1.ObjectMeta{Name: "from-secret", Namespace: "ns"},
+			Spec: keycloakApi.KeycloakRealmImportSpec{Source: keycloakApi.RealmImportSource{
+				SecretKeyRef: &keycloakApi.KeySelector{Name: "export", Key: "realm.json"},
+			}},
+		},
+	).Build()
+
+	r := NewReconcile(k8sClient, mock.NewLogr(), &helper.Mock{})
+	meta := metav1.ObjectMeta{Name: "export", Namespace: "ns"}
+
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "from-config-map"}},
+	}, r.mapConfigMapToImports(&corev1.ConfigMap{ObjectMeta: meta}))
+
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "from-secret"}},
+	}, r.mapSecretToImports(&corev1.Secret{ObjectMeta: meta}))
+
+	assert.Empty(t, r.mapSecretToImports(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ns"}}))
+}
@@ -0,0 +1,80 @@
+package keycloakrealmkey
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// keyMaterial is an rsa key pair in the format expected by keycloak rsa key provider.
+type keyMaterial struct {
+	privateKey  string
+	certificate string
+	fingerprint string
+}
+
+// loadKeyMaterial reads RSA key pair from kubernetes.io/tls Secret.
+func loadKeyMaterial(secret *corev1.Secret) (*keyMaterial, error) {
+	if secret.Type != corev1.SecretTypeTLS {
+		return nil, fmt.Errorf("secret %s has type %s, expected %s", secret.Name, secret.Type, corev1.SecretTypeTLS)
+	}
+
+	pair, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse key pair from secret %s: %w", secret.Name, err)
+	}
+
+	rsaKey, ok := pair.PrivateKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("only RSA private keys are supported")
+	}
+
+	// Keycloak expects private key in PKCS#8 format, cert-manager may issue PKCS#1 keys.
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal private key: %w", err)
+	}
+
+	// Only leaf certificate is used, the rest of the chain is ignored.
+	leaf := pair.Certificate[0]
+	sum := sha256.Sum256(leaf)
+
+	return &keyMaterial{
+		privateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})),
+		certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf})),
+		fingerprint: hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+// certificateFingerprint returns SHA-256 fingerprint of the certificate stored in the key component.
+// Keycloak stores certificates as base64 encoded DER without PEM armor, PEM is accepted as well.
+// Empty string is returned if the certificate can't be decoded.
+func certificateFingerprint(cert string) string {
+	var der []byte
+
+	if block, _ := pem.Decode([]byte(cert)); block != nil {
+		der = block.Bytes
+	} else {
+		var err error
+		if der, err = base64.StdEncoding.DecodeString(strings.Join(strings.Fields(cert), "")); err != nil {
+			return ""
+		}
+	}
+
+	if len(der) == 0 {
+		return ""
+	}
+
+	sum := sha256.Sum256(der)
+
+	return hex.EncodeToString(sum[:])
+}
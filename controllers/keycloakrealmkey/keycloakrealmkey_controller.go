@@ -0,0 +1,379 @@
+package keycloakrealmkey
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	ctrlHandler "sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+const (
+	finalizerName = "keycloak.realmkey.operator.finalizer.name"
+
+	keyProviderType = "org.keycloak.keys.KeyProvider"
+	rsaProviderID   = "rsa"
+)
+
+type Helper interface {
+	SetFailureCount(fc helper.FailureCountable) time.Duration
+	UpdateStatus(obj client.Object) error
+	GetOrCreateRealmOwnerRef(object helper.RealmChild, objectMeta *v1.ObjectMeta) (*keycloakApi.KeycloakRealm, error)
+	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
+	TryToReleaseRealmChild(ctx context.Context, obj helper.Deletable, realmChild helper.RealmChild, finalizer string) (bool, error)
+	TryToDelete(ctx context.Context, obj helper.Deletable, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
+}
+
+type Reconcile struct {
+	client                  client.Client
+	log                     logr.Logger
+	helper                  Helper
+	successReconcileTimeout time.Duration
+}
+
+func NewReconcile(client client.Client, log logr.Logger, helper Helper) *Reconcile {
+	return &Reconcile{
+		client: client,
+		helper: helper,
+		log:    log.WithName("keycloak-realm-key"),
+	}
+}
+
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+	r.successReconcileTimeout = successReconcileTimeout
+
+	pred := predicate.Funcs{
+		UpdateFunc: isSpecUpdated,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.KeycloakRealmKey{}, builder.WithPredicates(pred)).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, ctrlHandler.EnqueueRequestsFromMapFunc(r.mapSecretToKeys)).
+		Complete(r)
+	if err != nil {
+		return fmt.Errorf("failed to setup KeycloakRealmKey controller: %w", err)
+	}
+
+	return nil
+}
+
+func isSpecUpdated(e event.UpdateEvent) bool {
+	oo, ok := e.ObjectOld.(*keycloakApi.KeycloakRealmKey)
+	if !ok {
+		return false
+	}
+
+	no, ok := e.ObjectNew.(*keycloakApi.KeycloakRealmKey)
+	if !ok {
+		return false
+	}
+
+	return !reflect.DeepEqual(oo.Spec, no.Spec) ||
+		(oo.GetDeletionTimestamp().IsZero() && !no.GetDeletionTimestamp().IsZero())
+}
+
+// mapSecretToKeys returns requests for realm keys which use the secret, so the key is updated when the certificate is renewed.
+func (r *Reconcile) mapSecretToKeys(secret client.Object) []reconcile.Request {
+	var list keycloakApi.KeycloakRealmKeyList
+	if err := r.client.List(context.Background(), &list, client.InNamespace(secret.GetNamespace())); err != nil {
+		r.log.Error(err, "unable to list realm keys for secret", "secret", secret.GetName())
+
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for i := range list.Items {
+		if list.Items[i].Spec.SecretName == secret.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: list.Items[i].Namespace, Name: list.Items[i].Name},
+			})
+		}
+	}
+
+	return requests
+}
+
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmkeys,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmkeys/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmkeys/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=secrets,verbs=get;list;watch
+
+// Reconcile is a loop for reconciling KeycloakRealmKey object.
+func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resultErr error) {
+	log := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	log.Info("Reconciling KeycloakRealmKey")
+
+	var instance keycloakApi.KeycloakRealmKey
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return
+		}
+
+		resultErr = errors.Wrap(err, "unable to get keycloak realm key from k8s")
+
+		return
+	}
+
+	if !instance.GetDeletionTimestamp().IsZero() {
+		released, err := r.helper.TryToReleaseRealmChild(ctx, &instance, &instance, finalizerName)
+		if err != nil {
+			resultErr = errors.Wrap(err, "unable to release keycloak realm key")
+
+			return
+		}
+
+		if released {
+			log.Info("Parent realm is retained or deleted, keycloak resource is kept")
+
+			return
+		}
+	}
+
+	if err := r.tryReconcile(ctx, &instance); err != nil {
+		instance.Status.Value = err.Error()
+		result.RequeueAfter = r.helper.SetFailureCount(&instance)
+
+		log.Error(err, "an error has occurred while handling keycloak realm key", "name", request.Name)
+	} else {
+		helper.SetSuccessStatus(&instance)
+		result.RequeueAfter = r.successReconcileTimeout
+
+		// Previous key must be removed right after the overlap period, not on the next regular reconciliation.
+		// Zero success timeout means no periodic reconciliation, so the requeue is required in this case too.
+		if exp := instance.Status.PreviousKeyExpiration; exp != nil {
+			untilExp := time.Until(exp.Time)
+			if untilExp < 0 {
+				untilExp = 0
+			}
+
+			if result.RequeueAfter == 0 || untilExp < result.RequeueAfter {
+				result.RequeueAfter = untilExp + time.Second
+			}
+		}
+	}
+
+	if err := r.helper.UpdateStatus(&instance); err != nil {
+		resultErr = errors.Wrap(err, "unable to update status")
+	}
+
+	return
+}
+
+func (r *Reconcile) tryReconcile(ctx context.Context, realmKey *keycloakApi.KeycloakRealmKey) error {
+	realm, err := r.helper.GetOrCreateRealmOwnerRef(realmKey, &realmKey.ObjectMeta)
+	if err != nil {
+		return errors.Wrap(err, "unable to get realm owner ref")
+	}
+
+	kClient, err := r.helper.CreateKeycloakClientForRealm(ctx, realm)
+	if err != nil {
+		return errors.Wrap(err, "unable to create keycloak client")
+	}
+
+	term := makeTerminator(realm.Spec.RealmName, realmKey.Spec.Name, realmKey.PreviousKeyName(), kClient,
+		r.log.WithName("realm-key-term"))
+
+	deleted, err := r.helper.TryToDelete(ctx, realmKey, term, finalizerName)
+	if err != nil {
+		return errors.Wrap(err, "unable to tryToDelete realm key")
+	}
+
+	if deleted {
+		return nil
+	}
+
+	var secret corev1.Secret
+	if err = r.client.Get(ctx, types.NamespacedName{Namespace: realmKey.Namespace, Name: realmKey.Spec.SecretName}, &secret); err != nil {
+		return errors.Wrapf(err, "unable to get secret %s", realmKey.Spec.SecretName)
+	}
+
+	material, err := loadKeyMaterial(&secret)
+	if err != nil {
+		return errors.Wrap(err, "unable to load key from secret")
+	}
+
+	if err = r.putKey(ctx, realm.Spec.RealmName, realmKey, material, kClient); err != nil {
+		return err
+	}
+
+	realmKey.Status.CertificateFingerprint = material.fingerprint
+
+	return r.removeExpiredPreviousKey(ctx, realm.Spec.RealmName, realmKey, kClient)
+}
+
+// putKey creates or updates the key provider component.
+// When the certificate is renewed and overlap period is set, the current component is kept as passive previous key.
+// The rotation state is taken from keycloak, so a rotation interrupted by an error is completed on the next reconciliation.
+func (r *Reconcile) putKey(
+	ctx context.Context,
+	realmName string,
+	realmKey *keycloakApi.KeycloakRealmKey,
+	material *keyMaterial,
+	kClient keycloak.Client,
+) error {
+	log := r.log.WithValues("realm key", realmKey.Spec.Name)
+	keyComponent := makeKeyComponent(&realmKey.Spec, material)
+
+	current, err := getComponentIfExists(ctx, realmName, realmKey.Spec.Name, kClient)
+	if err != nil {
+		return errors.Wrap(err, "unable to get key component, unexpected error")
+	}
+
+	previous, err := getComponentIfExists(ctx, realmName, realmKey.PreviousKeyName(), kClient)
+	if err != nil {
+		return errors.Wrap(err, "unable to get previous key component, unexpected error")
+	}
+
+	overlap := time.Duration(0)
+	if realmKey.Spec.OverlapPeriod != nil {
+		overlap = realmKey.Spec.OverlapPeriod.Duration
+	}
+
+	switch {
+	case current == nil:
+		if err = kClient.CreateComponent(ctx, realmName, keyComponent); err != nil {
+			return errors.Wrap(err, "unable to create key component")
+		}
+
+		log.Info("Key component has been created")
+	case !isCertificateRenewed(realmKey, current, material) || overlap <= 0:
+		keyComponent.ID = current.ID
+
+		if err = kClient.UpdateComponent(ctx, realmName, keyComponent); err != nil {
+			return errors.Wrap(err, "unable to update key component")
+		}
+	default:
+		if previous != nil {
+			if err = deleteComponentIfExists(ctx, realmName, realmKey.PreviousKeyName(), kClient); err != nil {
+				return errors.Wrap(err, "unable to delete outdated previous key component")
+			}
+		}
+
+		// Keycloak keeps the stored private key when the masked value returned by the API is sent back.
+		current.Name = realmKey.PreviousKeyName()
+
+		if current.Config == nil {
+			current.Config = make(map[string][]string)
+		}
+
+		current.Config["active"] = []string{strconv.FormatBool(false)}
+
+		if err = kClient.UpdateComponent(ctx, realmName, current); err != nil {
+			return errors.Wrap(err, "unable to make current key component passive")
+		}
+
+		// The renamed component is the previous key now. If creation fails,
+		// the next reconciliation creates the key component and keeps this one until the overlap end.
+		previous = current
+		realmKey.Status.PreviousKeyExpiration = nil
+
+		if err = kClient.CreateComponent(ctx, realmName, keyComponent); err != nil {
+			return errors.Wrap(err, "unable to create renewed key component")
+		}
+
+		log.Info("Certificate has been renewed, previous key is kept as passive")
+	}
+
+	if previous != nil && realmKey.Status.PreviousKeyExpiration == nil {
+		expiration := v1.NewTime(time.Now().Add(overlap))
+		realmKey.Status.PreviousKeyExpiration = &expiration
+
+		log.Info("Previous key is kept until the end of overlap period", "until", expiration)
+	}
+
+	return nil
+}
+
+// isCertificateRenewed checks if the certificate stored in the key component differs from the certificate in the secret.
+// Status fingerprint is used when keycloak doesn't return the certificate.
+func isCertificateRenewed(realmKey *keycloakApi.KeycloakRealmKey, current *adapter.Component, material *keyMaterial) bool {
+	if certs := current.Config["certificate"]; len(certs) > 0 {
+		if fingerprint := certificateFingerprint(certs[0]); fingerprint != "" {
+			return fingerprint != material.fingerprint
+		}
+	}
+
+	return realmKey.Status.CertificateFingerprint != "" && realmKey.Status.CertificateFingerprint != material.fingerprint
+}
+
+// removeExpiredPreviousKey deletes the previous key component when its overlap period is over.
+func (r *Reconcile) removeExpiredPreviousKey(
+	ctx context.Context,
+	realmName string,
+	realmKey *keycloakApi.KeycloakRealmKey,
+	kClient keycloak.Client,
+) error {
+	exp := realmKey.Status.PreviousKeyExpiration
+	if exp == nil || time.Now().Before(exp.Time) {
+		return nil
+	}
+
+	if err := deleteComponentIfExists(ctx, realmName, realmKey.PreviousKeyName(), kClient); err != nil {
+		return errors.Wrap(err, "unable to delete previous key component")
+	}
+
+	realmKey.Status.PreviousKeyExpiration = nil
+
+	r.log.Info("Previous key component has been deleted", "realm key", realmKey.Spec.Name)
+
+	return nil
+}
+
+// getComponentIfExists returns nil if the component doesn't exist.
+func getComponentIfExists(ctx context.Context, realmName, componentName string, kClient keycloak.Client) (*adapter.Component, error) {
+	component, err := kClient.GetComponent(ctx, realmName, componentName)
+	if err != nil {
+		if adapter.IsErrNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("unable to get component %s: %w", componentName, err)
+	}
+
+	return component, nil
+}
+
+func deleteComponentIfExists(ctx context.Context, realmName, componentName string, kClient keycloak.Client) error {
+	if err := kClient.DeleteComponent(ctx, realmName, componentName); err != nil && !adapter.IsErrNotFound(err) {
+		return fmt.Errorf("unable to delete component %s: %w", componentName, err)
+	}
+
+	return nil
+}
+
+func makeKeyComponent(spec *keycloakApi.KeycloakRealmKeySpec, material *keyMaterial) *adapter.Component {
+	return &adapter.Component{
+		Name:         spec.Name,
+		ProviderID:   rsaProviderID,
+		ProviderType: keyProviderType,
+		Config: map[string][]string{
+			"privateKey":  {material.privateKey},
+			"certificate": {material.certificate},
+			"priority":    {strconv.FormatInt(spec.Priority, 10)},
+			"algorithm":   {spec.Algorithm},
+			"active":      {strconv.FormatBool(spec.Active)},
+			"enabled":     {strconv.FormatBool(spec.Enabled)},
+		},
+	}
+}
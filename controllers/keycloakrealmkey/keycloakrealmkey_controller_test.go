@@ -0,0 +1,371 @@
+package keycloakrealmkey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func makeTLSSecret(t *testing.T, name string) *corev1.Secret {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tpl := x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	cert, err := x509.CreateCertificate(rand.Reader, &tpl, &tpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}),
+			corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		},
+	}
+}
+
+func TestReconcile_Reconcile(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	realm := keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "realm1", Namespace: "ns"},
+		Spec:       keycloakApi.KeycloakRealmSpec{RealmName: "ns.realm1"},
+	}
+
+	newKey := func(status keycloakApi.KeycloakRealmKeyStatus, overlap *metav1.Duration) *keycloakApi.KeycloakRealmKey {
+		return &keycloakApi.KeycloakRealmKey{
+			ObjectMeta: metav1.ObjectMeta{Name: "key", Namespace: "ns"},
+			Spec: keycloakApi.KeycloakRealmKeySpec{
+				Name:          "rsa-signing",
+				Realm:         realm.Name,
+				SecretName:    "signing-tls",
+				Priority:      200,
+				Algorithm:     "RS256",
+				Active:        true,
+				Enabled:       true,
+				OverlapPeriod: overlap,
+			},
+			Status: status,
+		}
+	}
+
+	// Keycloak returns the certificate as base64 encoded DER.
+	renewedSecret := makeTLSSecret(t, "signing-tls")
+	renewedBlock, _ := pem.Decode(renewedSecret.Data[corev1.TLSCertKey])
+	renewedCertificate := base64.StdEncoding.EncodeToString(renewedBlock.Bytes)
+
+	tests := []struct {
+		name           string
+		key            *keycloakApi.KeycloakRealmKey
+		secret         *corev1.Secret
+		kClientMock    func(t *testing.T, m *adapter.Mock)
+		wantErr        string
+		checkStatus    func(t *testing.T, status keycloakApi.KeycloakRealmKeyStatus)
+		requeueOverlap bool
+		// noSuccessTimeout disables periodic reconciliation as the default operator configuration does.
+		noSuccessTimeout bool
+	}{
+		{
+			name:   "create key component",
+			key:    newKey(keycloakApi.KeycloakRealmKeyStatus{}, nil),
+			secret: makeTLSSecret(t, "signing-tls"),
+			kClientMock: func(t *testing.T, m *adapter.Mock) {
+				m.On("GetComponent", realm.Spec.RealmName, "rsa-signing").
+					Return(nil, adapter.NotFoundError("not found"))
+				m.On("GetComponent", realm.Spec.RealmName, "rsa-signing-previous").
+					Return(nil, adapter.NotFoundError("not found"))
+				m.On("CreateComponent", realm.Spec.RealmName, testifyMock.MatchedBy(func(c *adapter.Component) bool {
+					return c.Name == "rsa-signing" && c.ProviderID == rsaProviderID &&
+						c.ProviderType == keyProviderType && c.Config["priority"][0] == "200" &&
+						c.Config["active"][0] == "true" && c.Config["algorithm"][0] == "RS256"
+				})).Return(nil)
+			},
+			checkStatus: func(t *testing.T, status keycloakApi.KeycloakRealmKeyStatus) {
+				assert.Equal(t, helper.StatusOK, status.Value)
+				assert.NotEmpty(t, status.CertificateFingerprint)
+				assert.Nil(t, status.PreviousKeyExpiration)
+			},
+		},
+		{
+			name:   "renewed certificate without overlap updates component in place",
+			key:    newKey(keycloakApi.KeycloakRealmKeyStatus{CertificateFingerprint: "old"}, nil),
+			secret: makeTLSSecret(t, "signing-tls"),
+			kClientMock: func(t *testing.T, m *adapter.Mock) {
+				m.On("GetComponent", realm.Spec.RealmName, "rsa-signing").
+					Return(&adapter.Component{ID: "id1", Name: "rsa-signing"}, nil)
+				m.On("GetComponent", realm.Spec.RealmName, "rsa-signing-previous").
+					Return(nil, adapter.NotFoundError("not found"))
+				m.On("UpdateComponent", realm.Spec.RealmName, testifyMock.MatchedBy(func(c *adapter.Component) bool {
+					return c.ID == "id1" && c.Name == "rsa-signing"
+				})).Return(nil)
+			},
+			checkStatus: func(t *testing.T, status keycloakApi.KeycloakRealmKeyStatus) {
+				assert.NotEqual(t, "old", status.CertificateFingerprint)
+				assert.Nil(t, status.PreviousKeyExpiration)
+			},
+		},
+		{
+			name: "renewed certificate with overlap keeps previous key passive",
+			key: newKey(
+				keycloakApi.KeycloakRealmKeyStatus{CertificateFingerprint: "old"},
+				&metav1.Duration{Duration: time.Minute},
+			),
+			secret: makeTLSSecret(t, "signing-tls"),
+			kClientMock: func(t *testing.T, m *adapter.Mock) {
+				m.On("GetComponent", realm.Spec.RealmName, "rsa-signing").
+					Return(&adapter.Component{
+						ID:     "id1",
+						Name:   "rsa-signing",
+						Config: map[string][]string{"active": {"true"}, "privateKey": {"**********"}},
+					}, nil)
+				m.On("GetComponent", realm.Spec.RealmName, "rsa-signing-previous").
+					Return(&adapter.Component{ID: "id0", Name: "rsa-signing-previous"}, nil)
+				m.On("DeleteComponent", realm.Spec.RealmName, "rsa-signing-previous").Return(nil)
+				m.On("UpdateComponent", realm.Spec.RealmName, testifyMock.MatchedBy(func(c *adapter.Component) bool {
+					return c.ID == "id1" && c.Name == "rsa-signing-previous" && c.Config["active"][0] == "false"
+				})).Return(nil)
+				m.On("CreateComponent", realm.Spec.RealmName, testifyMock.MatchedBy(func(c *adapter.Component) bool {
+					return c.ID == "" && c.Name == "rsa-signing" && c.Config["active"][0] == "true"
+				})).Return(nil)
+			},
+			checkStatus: func(t *testing.T, status keycloakApi.KeycloakRealmKeyStatus) {
+				assert.NotEqual(t, "old", status.CertificateFingerprint)
+				require.NotNil(t, status.PreviousKeyExpiration)
+				assert.True(t, status.PreviousKeyExpiration.After(time.Now()))
+			},
+			requeueOverlap: true,
+		},
+		{
+			name: "previous key expiration is requeued without periodic reconciliation",
+			key: newKey(
+				keycloakApi.KeycloakRealmKeyStatus{
+					CertificateFingerprint: "old",
+					PreviousKeyExpiration:  &metav1.Time{Time: time.Now().Add(time.Minute)},
+				},
+				&metav1.Duration{Duration: time.Minute},
+			),
+			secret: makeTLSSecret(t, "signing-tls"),
+			kClientMock: func(t *testing.T, m *adapter.Mock) {
+				m.On("GetComponent", realm.Spec.RealmName, "rsa-signing").
+					Return(&adapter.Component{ID: "id1", Name: "rsa-signing"}, nil)
+				m.On("GetComponent", realm.Spec.RealmName, "rsa-signing-previous").
+					Return(nil, adapter.NotFoundError("not found"))
+				m.On("UpdateComponent", realm.Spec.RealmName, testifyMock.Anything).Return(nil)
+				m.On("CreateComponent", realm.Spec.RealmName, testifyMock.Anything).Return(nil)
+			},
+			checkStatus: func(t *testing.T, status keycloakApi.KeycloakRealmKeyStatus) {
+				require.NotNil(t, status.PreviousKeyExpiration)
+			},
+			requeueOverlap:   true,
+			noSuccessTimeout: true,
+		},
+		{
+			name: "expired previous key is deleted",
+			key: newKey(
+				keycloakApi.KeycloakRealmKeyStatus{
+					PreviousKeyExpiration: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+				},
+				&metav1.Duration{Duration: time.Minute},
+			),
+			secret: makeTLSSecret(t, "signing-tls"),
+			kClientMock: func(t *testing.T, m *adapter.Mock) {
+				m.On("GetComponent", realm.Spec.RealmName, "rsa-signing").
+					Return(&adapter.Component{ID: "id1", Name: "rsa-signing"}, nil)
+				m.On("GetComponent", realm.Spec.RealmName, "rsa-signing-previous").
+					Return(&adapter.Component{ID: "id0", Name: "rsa-signing-previous"}, nil)
+				m.On("UpdateComponent", realm.Spec.RealmName, testifyMock.Anything).Return(nil)
+				m.On("DeleteComponent", realm.Spec.RealmName, "rsa-signing-previous").Return(nil)
+			},
+			checkStatus: func(t *testing.T, status keycloakApi.KeycloakRealmKeyStatus) {
+				assert.Nil(t, status.PreviousKeyExpiration)
+			},
+		},
+		{
+			name: "rotation interrupted before renewed key is created",
+			key: newKey(
+				keycloakApi.KeycloakRealmKeyStatus{CertificateFingerprint: "old"},
+				&metav1.Duration{Duration: time.Minute},
+			),
+			secret: makeTLSSecret(t, "signing-tls"),
+			kClientMock: func(t *testing.T, m *adapter.Mock) {
+				m.On("GetComponent", realm.Spec.RealmName, "rsa-signing").
+					Return(nil, adapter.NotFoundError("not found"))
+				m.On("GetComponent", realm.Spec.RealmName, "rsa-signing-previous").
+					Return(&adapter.Component{ID: "id0", Name: "rsa-signing-previous"}, nil)
+				m.On("CreateComponent", realm.Spec.RealmName, testifyMock.MatchedBy(func(c *adapter.Component) bool {
+					return c.Name == "rsa-signing" && c.Config["active"][0] == "true"
+				})).Return(nil)
+			},
+			checkStatus: func(t *testing.T, status keycloakApi.KeycloakRealmKeyStatus) {
+				require.NotNil(t, status.PreviousKeyExpiration)
+				assert.True(t, status.PreviousKeyExpiration.After(time.Now()))
+			},
+			requeueOverlap: true,
+		},
+		{
+			name: "status is lost after rotation",
+			key: newKey(
+				keycloakApi.KeycloakRealmKeyStatus{CertificateFingerprint: "old"},
+				&metav1.Duration{Duration: time.Minute},
+			),
+			secret: renewedSecret,
+			kClientMock: func(t *testing.T, m *adapter.Mock) {
+				m.On("GetComponent", realm.Spec.RealmName, "rsa-signing").
+					Return(&adapter.Component{
+						ID:     "id2",
+						Name:   "rsa-signing",
+						Config: map[string][]string{"certificate": {renewedCertificate}},
+					}, nil)
+				m.On("GetComponent", realm.Spec.RealmName, "rsa-signing-previous").
+					Return(&adapter.Component{ID: "id0", Name: "rsa-signing-previous"}, nil)
+				m.On("UpdateComponent", realm.Spec.RealmName, testifyMock.MatchedBy(func(c *adapter.Component) bool {
+					return c.ID == "id2" && c.Name == "rsa-signing"
+				})).Return(nil)
+			},
+			checkStatus: func(t *testing.T, status keycloakApi.KeycloakRealmKeyStatus) {
+				require.NotNil(t, status.PreviousKeyExpiration)
+				assert.True(t, status.PreviousKeyExpiration.After(time.Now()))
+			},
+			requeueOverlap: true,
+		},
+		{
+			name: "secret is not tls",
+			key:  newKey(keycloakApi.KeycloakRealmKeyStatus{}, nil),
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "signing-tls", Namespace: "ns"},
+				Type:       corev1.SecretTypeOpaque,
+			},
+			kClientMock: func(t *testing.T, m *adapter.Mock) {},
+			wantErr:     "unable to load key from secret: secret signing-tls has type Opaque, expected kubernetes.io/tls",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			logger := mock.NewLogr()
+			hlp := helper.Mock{}
+			kClient := adapter.Mock{}
+
+			tt.kClientMock(t, &kClient)
+
+			k8sClient := fake.NewClientBuilder().WithScheme(sch).WithObjects(tt.key, tt.secret).Build()
+
+			hlp.On("GetOrCreateRealmOwnerRef", testifyMock.Anything, testifyMock.Anything).Return(&realm, nil)
+			hlp.On("CreateKeycloakClientForRealm", &realm).Return(&kClient, nil)
+			hlp.On("TryToDelete", testifyMock.Anything, testifyMock.Anything, finalizerName).Return(false, nil)
+			hlp.On("SetFailureCount", testifyMock.Anything).Return(time.Minute)
+
+			var status keycloakApi.KeycloakRealmKeyStatus
+
+			hlp.On("UpdateStatus", testifyMock.Anything).Run(func(args testifyMock.Arguments) {
+				status = args.Get(0).(*keycloakApi.KeycloakRealmKey).Status
+			}).Return(nil)
+
+			r := NewReconcile(k8sClient, logger, &hlp)
+			r.successReconcileTimeout = time.Hour
+			if tt.noSuccessTimeout {
+				r.successReconcileTimeout = 0
+			}
+
+			res, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
+				Name:      tt.key.Name,
+				Namespace: tt.key.Namespace,
+			}})
+			require.NoError(t, err)
+
+			loggerSink, ok := logger.GetSink().(*mock.Logger)
+			require.True(t, ok, "wrong logger type")
+
+			if tt.wantErr != "" {
+				require.Error(t, loggerSink.LastError())
+				assert.Equal(t, tt.wantErr, loggerSink.LastError().Error())
+				assert.Equal(t, time.Minute, res.RequeueAfter)
+
+				return
+			}
+
+			require.NoError(t, loggerSink.LastError())
+			tt.checkStatus(t, status)
+
+			if tt.requeueOverlap {
+				assert.Positive(t, res.RequeueAfter)
+				assert.LessOrEqual(t, res.RequeueAfter, time.Minute+time.Second)
+			} else {
+				assert.Equal(t, r.successReconcileTimeout, res.RequeueAfter)
+			}
+
+			kClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReconcile_mapSecretToKeys(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	k8sClient := fake.NewClientBuilder().WithScheme(sch).WithObjects(
+		&keycloakApi.KeycloakRealmKey{
+			ObjectMeta: metav1.ObjectMeta{Name: "key1", Namespace: "ns"},
+			Spec:       keycloakApi.KeycloakRealmKeySpec{SecretName: "tls"},
+		},
+		&keycloakApi.KeycloakRealmKey{
+			ObjectMeta: metav1.ObjectMeta{Name: "key2", Namespace: "ns"},
+			Spec:       keycloakApi.KeycloakRealmKeySpec{SecretName: "other-tls"},
+		},
+		&keycloakApi.KeycloakRealmKey{
+			ObjectMeta: metav1.ObjectMeta{Name: "key3", Namespace: "ns2"},
+			Spec:       keycloakApi.KeycloakRealmKeySpec{SecretName: "tls"},
+		},
+	).Build()
+
+	r := NewReconcile(k8sClient, mock.NewLogr(), &helper.Mock{})
+
+	requests := r.mapSecretToKeys(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "ns"}})
+
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "key1"}},
+	}, requests)
+}
+
+func TestIsSpecUpdated(t *testing.T) {
+	key := keycloakApi.KeycloakRealmKey{}
+
+	if isSpecUpdated(event.UpdateEvent{ObjectNew: &key, ObjectOld: &key}) {
+		t.Fatal("spec is updated")
+	}
+}
@@ -0,0 +1,49 @@
+package keycloakrealmkey
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+)
+
+type terminator struct {
+	realmName       string
+	keyName         string
+	previousKeyName string
+	kClient         keycloak.Client
+	log             logr.Logger
+}
+
+func makeTerminator(realmName, keyName, previousKeyName string, kClient keycloak.Client, log logr.Logger) *terminator {
+	return &terminator{
+		realmName:       realmName,
+		keyName:         keyName,
+		previousKeyName: previousKeyName,
+		kClient:         kClient,
+		log:             log,
+	}
+}
+
+func (t *terminator) DeleteResource(ctx context.Context) error {
+	log := t.log.WithValues("keycloak realm key name", t.keyName)
+	log.Info("Start deleting keycloak realm key...")
+
+	if err := deleteComponentIfExists(ctx, t.realmName, t.previousKeyName, t.kClient); err != nil {
+		return errors.Wrap(err, "unable to delete previous realm key")
+	}
+
+	if err := t.kClient.DeleteComponent(ctx, t.realmName, t.keyName); err != nil {
+		return errors.Wrap(err, "unable to delete realm key")
+	}
+
+	log.Info("realm key deletion done")
+
+	return nil
+}
+
+func (t *terminator) GetLogger() logr.Logger {
+	return t.log
+}
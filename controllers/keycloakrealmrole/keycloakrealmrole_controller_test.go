@@ -7,8 +7,10 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -59,7 +61,10 @@ func TestReconcileKeycloakRealmRole_Reconcile(t *testing.T) {
 	h := helper.Mock{}
 	h.On("GetOrCreateRealmOwnerRef", &role, &role.ObjectMeta).Return(&realm, nil)
 	h.On("CreateKeycloakClientForRealm", &realm).Return(kClient, nil)
-	h.On("UpdateStatus", &role).Return(nil)
+	h.On("UpdateStatus", testifyMock.MatchedBy(func(obj *keycloakApi.KeycloakRealmRole) bool {
+		return obj.Status.Value == helper.StatusOK &&
+			meta.IsStatusConditionTrue(obj.Status.Conditions, keycloakApi.ConditionReady)
+	})).Return(nil)
 	h.On("TryToDelete", &role, makeTerminator(realm.Spec.RealmName, role.Spec.Name, kClient, logger),
 		keyCloakRealmRoleOperatorFinalizerName).Return(true, nil)
 
@@ -139,14 +144,6 @@ func TestReconcileRoleMarkDuplicated(t *testing.T) {
 		Status:   keycloakApi.KeycloakRealmRoleStatus{},
 	}
 
-	duplicatedRole := keycloakApi.KeycloakRealmRole{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: ns,
-		ResourceVersion: "999",
-		OwnerReferences: []metav1.OwnerReference{{Name: "test", Kind: "KeycloakRealm"}}},
-		TypeMeta: metav1.TypeMeta{Kind: "KeycloakRealmRole", APIVersion: "v1.edp.epam.com/v1"},
-		Spec:     keycloakApi.KeycloakRealmRoleSpec{Name: "test"},
-		Status:   keycloakApi.KeycloakRealmRoleStatus{Value: keycloakApi.StatusDuplicated},
-	}
-
 	realm := keycloakApi.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: ns,
 		OwnerReferences: []metav1.OwnerReference{{Name: "test", Kind: "Keycloak"}}},
 		TypeMeta: metav1.TypeMeta{Kind: "KeycloakRealm", APIVersion: "v1.edp.epam.com/v1"},
@@ -167,7 +164,10 @@ func TestReconcileRoleMarkDuplicated(t *testing.T) {
 	h.On("CreateKeycloakClientForRealm", &realm).Return(kClient, nil)
 	h.On("GetOrCreateRealmOwnerRef", &role, &role.ObjectMeta).Return(&realm, nil)
 
-	h.On("UpdateStatus", &duplicatedRole).Return(nil)
+	h.On("UpdateStatus", testifyMock.MatchedBy(func(obj *keycloakApi.KeycloakRealmRole) bool {
+		return obj.Status.Value == keycloakApi.StatusDuplicated &&
+			meta.IsStatusConditionFalse(obj.Status.Conditions, keycloakApi.ConditionReady)
+	})).Return(nil)
 
 	rkr := ReconcileKeycloakRealmRole{
 		log:    logger,
@@ -25,6 +25,7 @@ import (
 const keyCloakRealmRoleBatchOperatorFinalizerName = "keycloak.realmrolebatch.operator.finalizer.name"
 
 type Helper interface {
+	TryToReleaseRealmChild(ctx context.Context, obj helper.Deletable, realmChild helper.RealmChild, finalizer string) (bool, error)
 	TryToDelete(ctx context.Context, obj helper.Deletable, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
 	GetOrCreateRealmOwnerRef(object helper.RealmChild, objectMeta *metav1.ObjectMeta) (*keycloakApi.KeycloakRealm, error)
 	IsOwner(slave client.Object, master client.Object) bool
@@ -85,6 +86,21 @@ func (r *ReconcileKeycloakRealmRoleBatch) Reconcile(ctx context.Context, request
 		return
 	}
 
+	if !instance.GetDeletionTimestamp().IsZero() {
+		released, err := r.helper.TryToReleaseRealmChild(ctx, &instance, &instance, keyCloakRealmRoleBatchOperatorFinalizerName)
+		if err != nil {
+			resultErr = errors.Wrap(err, "unable to release keycloak realm role batch")
+
+			return
+		}
+
+		if released {
+			log.Info("Parent realm is retained or deleted, keycloak resource is kept")
+
+			return
+		}
+	}
+
 	if err := r.tryReconcile(ctx, &instance); err != nil {
 		instance.Status.Value = err.Error()
 		result.RequeueAfter = r.helper.SetFailureCount(&instance)
@@ -168,23 +184,36 @@ func (r *ReconcileKeycloakRealmRoleBatch) putRoles(ctx context.Context, batch *k
 			return nil, errors.New("one of batch role already exists")
 		}
 
+		ownerReferences := []metav1.OwnerReference{
+			{Name: batch.Name, Kind: batch.Kind, BlockOwnerDeletion: gocloak.BoolP(true), UID: batch.UID,
+				APIVersion: batch.APIVersion},
+		}
+
+		realmName := ""
+
+		// ClusterKeycloakRealm is cluster-scoped, so it can't be set as an owner of namespaced role.
+		if batch.Spec.ClusterRealmRef == "" {
+			realmName = realm.Name
+			ownerReferences = append([]metav1.OwnerReference{
+				{Name: realm.Name, Kind: realm.Kind, BlockOwnerDeletion: gocloak.BoolP(true), UID: realm.UID,
+					APIVersion: realm.APIVersion},
+			}, ownerReferences...)
+		}
+
 		newRole := keycloakApi.KeycloakRealmRole{
 			ObjectMeta: metav1.ObjectMeta{Name: roleName,
-				Namespace: batch.Namespace,
-				OwnerReferences: []metav1.OwnerReference{
-					{Name: realm.Name, Kind: realm.Kind, BlockOwnerDeletion: gocloak.BoolP(true), UID: realm.UID,
-						APIVersion: realm.APIVersion},
-					{Name: batch.Name, Kind: batch.Kind, BlockOwnerDeletion: gocloak.BoolP(true), UID: batch.UID,
-						APIVersion: batch.APIVersion},
-				}},
+				Namespace:       batch.Namespace,
+				OwnerReferences: ownerReferences,
+			},
 			Spec: keycloakApi.KeycloakRealmRoleSpec{
-				Name:        role.Name,
-				Realm:       realm.Name,
-				Composite:   role.Composite,
-				Composites:  role.Composites,
-				Description: role.Description,
-				Attributes:  role.Attributes,
-				IsDefault:   role.IsDefault,
+				Name:            role.Name,
+				Realm:           realmName,
+				ClusterRealmRef: batch.Spec.ClusterRealmRef,
+				Composite:       role.Composite,
+				Composites:      role.Composites,
+				Description:     role.Description,
+				Attributes:      role.Attributes,
+				IsDefault:       role.IsDefault,
 			}}
 		if err := r.client.Create(ctx, &newRole); err != nil {
 			return nil, errors.Wrap(err, "unable to create child role from batch")
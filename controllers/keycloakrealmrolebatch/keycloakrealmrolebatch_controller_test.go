@@ -55,7 +55,7 @@ func TestReconcileKeycloakRealmRoleBatch_ReconcileDelete(t *testing.T) {
 	log := mock.NewLogr()
 	rkr := ReconcileKeycloakRealmRoleBatch{
 		client: client,
-		helper: helper.MakeHelper(client, scheme, log),
+		helper: helper.MakeHelper(client, scheme, log, ""),
 		log:    log,
 	}
 
@@ -111,7 +111,7 @@ func TestReconcileKeycloakRealmRoleBatch_Reconcile(t *testing.T) {
 
 	rkr := ReconcileKeycloakRealmRoleBatch{
 		client:                  client,
-		helper:                  helper.MakeHelper(client, sch, logger),
+		helper:                  helper.MakeHelper(client, sch, logger, ""),
 		log:                     logger,
 		successReconcileTimeout: time.Hour,
 	}
@@ -210,7 +210,7 @@ func TestReconcileKeycloakRealmRoleBatch_ReconcileFailure(t *testing.T) {
 	logger := mock.NewLogr()
 	rkr := ReconcileKeycloakRealmRoleBatch{
 		client: client,
-		helper: helper.MakeHelper(client, scheme, logger),
+		helper: helper.MakeHelper(client, scheme, logger, ""),
 		log:    logger,
 	}
 
@@ -5,9 +5,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	coreV1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -97,7 +99,10 @@ func (e *TestControllerSuite) TestNewReconcile() {
 	}
 
 	e.kClient.On("SyncRealmUser", e.realmName, e.adapterUser, false).Return(nil)
-	e.helper.On("UpdateStatus", e.kcRealmUser).Return(nil)
+	e.helper.On("UpdateStatus", testifyMock.MatchedBy(func(obj *keycloakApi.KeycloakRealmUser) bool {
+		return obj.Status.Value == helper.StatusOK &&
+			meta.IsStatusConditionTrue(obj.Status.Conditions, keycloakApi.ConditionReady)
+	})).Return(nil)
 
 	_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
 		Namespace: e.namespace,
@@ -123,7 +128,10 @@ func (e *TestControllerSuite) TestReconcileKeep() {
 	e.helper.On("TryToDelete", e.kcRealmUser,
 		makeTerminator(e.realmName, e.kcRealmUser.Spec.Username, e.kClient, logger), finalizer).
 		Return(false, nil)
-	e.helper.On("UpdateStatus", e.kcRealmUser).Return(nil)
+	e.helper.On("UpdateStatus", testifyMock.MatchedBy(func(obj *keycloakApi.KeycloakRealmUser) bool {
+		return obj.Status.Value == helper.StatusOK &&
+			meta.IsStatusConditionTrue(obj.Status.Conditions, keycloakApi.ConditionReady)
+	})).Return(nil)
 
 	r := Reconcile{
 		helper: e.helper,
@@ -19,21 +19,26 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	buildInfo "github.com/epam/edp-common/pkg/config"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
 	keycloakApi1alpha1 "github.com/epam/edp-keycloak-operator/api/v1alpha1"
 	"github.com/epam/edp-keycloak-operator/controllers/clusterkeycloak"
+	"github.com/epam/edp-keycloak-operator/controllers/clusterkeycloakrealm"
 	"github.com/epam/edp-keycloak-operator/controllers/helper"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloak"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakauthflow"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakclient"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakclientscope"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmbackup"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmcomponent"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmgroup"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmidentityprovider"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmimport"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmkey"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmrole"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmrolebatch"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmuser"
@@ -95,6 +100,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	operatorNs, err := util.GetOperatorNamespace()
+	if err != nil {
+		setupLog.Error(err, "unable to get operator namespace")
+		os.Exit(1)
+	}
+
 	cfg := ctrl.GetConfigOrDie()
 
 	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
@@ -121,7 +132,9 @@ func main() {
 	}
 
 	ctrlLog := ctrl.Log.WithName("controllers")
-	h := helper.MakeHelper(mgr.GetClient(), mgr.GetScheme(), ctrlLog)
+	h := helper.MakeHelper(mgr.GetClient(), mgr.GetScheme(), ctrlLog, operatorNs)
+
+	metrics.Registry.MustRegister(helper.RequestQueueDepth)
 
 	keycloakCtrl := keycloak.NewReconcileKeycloak(mgr.GetClient(), mgr.GetScheme(), ctrlLog, h)
 	if err := keycloakCtrl.SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
@@ -183,18 +196,41 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := keycloakrealmkey.NewReconcile(mgr.GetClient(), ctrlLog, h).
+		SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
+		setupLog.Error(err, "unable to create keycloak-realm-key controller")
+		os.Exit(1)
+	}
+
+	if err := keycloakrealmimport.NewReconcile(mgr.GetClient(), ctrlLog, h).
+		SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
+		setupLog.Error(err, "unable to create keycloak-realm-import controller")
+		os.Exit(1)
+	}
+
+	if err := keycloakrealmbackup.NewReconcile(mgr.GetClient(), mgr.GetScheme(), ctrlLog, h).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create keycloak-realm-backup controller")
+		os.Exit(1)
+	}
+
 	if err := keycloakrealmidentityprovider.NewReconcile(mgr.GetClient(), ctrlLog, h).
 		SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
 		setupLog.Error(err, "unable to create keycloak-realm-identity-provider controller")
 		os.Exit(1)
 	}
 
-	if err := clusterkeycloak.NewReconcile(mgr.GetClient(), mgr.GetScheme(), ctrlLog, h).
+	if err := clusterkeycloak.NewReconcile(mgr.GetClient(), mgr.GetScheme(), ctrlLog, h, operatorNs).
 		SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
 		setupLog.Error(err, "unable to create clusterkeycloak controller")
 		os.Exit(1)
 	}
 
+	if err := clusterkeycloakrealm.NewReconcile(mgr.GetClient(), ctrlLog, h).
+		SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
+		setupLog.Error(err, "unable to create clusterkeycloakrealm controller")
+		os.Exit(1)
+	}
+
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
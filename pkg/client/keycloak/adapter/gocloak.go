@@ -10,6 +10,8 @@ import (
 type GoCloak interface {
 	RestyClient() *resty.Client
 	LoginAdmin(ctx context.Context, username, password, realm string) (*gocloak.JWT, error)
+	RefreshToken(ctx context.Context, refreshToken, clientID, clientSecret, realm string) (*gocloak.JWT, error)
+	GetToken(ctx context.Context, realm string, options gocloak.TokenOptions) (*gocloak.JWT, error)
 
 	GoCloakRealms
 	GoCloakClients
@@ -2,7 +2,6 @@ package adapter
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -14,6 +13,7 @@ import (
 	"github.com/Nerzal/gocloak/v12"
 	"github.com/go-logr/logr"
 	"github.com/go-resty/resty/v2"
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/pkg/errors"
 
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/api"
@@ -60,6 +60,21 @@ const (
 	getUserRealmRoleMappings        = "/admin/realms/{realm}/users/{id}/role-mappings/realm"
 	getUserGroupMappings            = "/admin/realms/{realm}/users/{id}/groups"
 	manageUserGroups                = "/admin/realms/{realm}/users/{userID}/groups/{groupID}"
+	realmLocalizations              = "/admin/realms/{realm}/localization"
+	realmLocalization               = "/admin/realms/{realm}/localization/{locale}"
+	realmLocalizationText           = "/admin/realms/{realm}/localization/{locale}/{key}"
+	requiredActions                 = "/admin/realms/{realm}/authentication/required-actions"
+	requiredAction                  = "/admin/realms/{realm}/authentication/required-actions/{alias}"
+	unregisteredRequiredActions     = "/admin/realms/{realm}/authentication/unregistered-required-actions"
+	registerRequiredAction          = "/admin/realms/{realm}/authentication/register-required-action"
+	realmUserProfile                = "/admin/realms/{realm}/users/profile"
+	realmClientProfiles             = "/admin/realms/{realm}/client-policies/profiles"
+	realmClientPolicies             = "/admin/realms/{realm}/client-policies/policies"
+	realmDefaultGroups              = "/admin/realms/{realm}/default-groups"
+	realmDefaultGroup               = "/admin/realms/{realm}/default-groups/{id}"
+	roleByIDComposites              = "/admin/realms/{realm}/roles-by-id/{id}/composites"
+	realmPartialImport              = "/admin/realms/{realm}/partialImport"
+	realmPartialExport              = "/admin/realms/{realm}/partial-export"
 	logClientDTO                    = "client dto"
 )
 
@@ -69,6 +84,8 @@ const (
 	keycloakApiParamRealm         = "realm"
 	keycloakApiParamAlias         = "alias"
 	keycloakApiParamClientScopeId = "clientScopeID"
+	keycloakApiParamLocale        = "locale"
+	keycloakApiParamKey           = "key"
 )
 
 const (
@@ -94,6 +111,28 @@ type GoCloakAdapter struct {
 	log        logr.Logger
 	basePath   string
 	legacyMode bool
+
+	// clientID, clientSecret and realm are used for the admin token refresh.
+	clientID     string
+	clientSecret string
+	realm        string
+
+	// signingMethod and signingKey are used to sign client assertion for the admin token refresh
+	// if the adapter was created with private key JWT.
+	signingMethod jwt.SigningMethod
+	signingKey    interface{}
+}
+
+// TokenCredentials are credentials of the client which issued the persisted admin token.
+// They are used to refresh the token.
+type TokenCredentials struct {
+	// Realm is the realm the token was issued in. Defaults to master.
+	Realm string
+	// ClientID is the client the token was issued to. Defaults to admin-cli.
+	ClientID     string
+	ClientSecret string
+	// PrivateKey is PEM encoded private key which is used to sign client assertion instead of ClientSecret.
+	PrivateKey string
 }
 
 type JWTPayload struct {
@@ -104,145 +143,160 @@ func (a GoCloakAdapter) GetGoCloak() GoCloak {
 	return a.client
 }
 
-func MakeFromToken(url string, tokenData []byte, log logr.Logger) (*GoCloakAdapter, error) {
+// MakeFromToken creates adapter from the persisted admin token.
+// servedPath is the path Keycloak is served at as reported by the server info, it is empty if unknown.
+func MakeFromToken(url, servedPath string, tokenData []byte, creds TokenCredentials, log logr.Logger,
+	restyClient *resty.Client,
+) (*GoCloakAdapter, error) {
 	var token gocloak.JWT
 	if err := json.Unmarshal(tokenData, &token); err != nil {
 		return nil, errors.Wrapf(err, "unable decode json data")
 	}
 
-	const requiredTokenParts = 3
-
-	tokenParts := strings.Split(token.AccessToken, ".")
+	expiresAt, err := parseTokenExpiration(token.AccessToken)
+	if err != nil {
+		return nil, err
+	}
 
-	if len(tokenParts) < requiredTokenParts {
-		return nil, errors.New("wrong JWT token structure")
+	if expiresAt.Before(time.Now()) {
+		return nil, TokenExpiredError("token is expired")
 	}
 
-	tokenPayload, err := base64.RawURLEncoding.DecodeString(tokenParts[1])
+	kcCl, legacyMode, err := loginWithMode(url, servedPath, restyClient, func(kcCl *gocloak.GoCloak, _ bool) error {
+		_, err := kcCl.GetRealms(context.Background(), token.AccessToken)
+
+		return err //nolint:wrapcheck // error is wrapped by the caller
+	})
 	if err != nil {
-		return nil, errors.Wrap(err, "wrong JWT token base64 encoding")
+		return nil, fmt.Errorf("failed to make new keycloak client: %w", err)
 	}
 
-	var tokenPayloadDecoded JWTPayload
-	if err = json.Unmarshal(tokenPayload, &tokenPayloadDecoded); err != nil {
-		return nil, errors.Wrap(err, "unable to decode JWT payload json")
+	a := &GoCloakAdapter{
+		client:       kcCl,
+		token:        &token,
+		log:          log,
+		basePath:     url,
+		legacyMode:   legacyMode,
+		clientID:     creds.ClientID,
+		clientSecret: creds.ClientSecret,
+		realm:        creds.Realm,
 	}
 
-	if tokenPayloadDecoded.Exp < time.Now().Unix() {
-		return nil, TokenExpiredError("token is expired")
+	if a.clientID == "" {
+		a.clientID = adminCliClientID
 	}
 
-	kcCl, legacyMode, err := makeClientFromToken(url, token.AccessToken)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make new keycloak client: %w", err)
+	if a.realm == "" {
+		a.realm = masterRealm
 	}
 
-	return &GoCloakAdapter{
-		client:     kcCl,
-		token:      &token,
-		log:        log,
-		basePath:   url,
-		legacyMode: legacyMode,
-	}, nil
+	if creds.PrivateKey != "" {
+		a.signingMethod, a.signingKey, err = parseSigningKey(creds.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return a, nil
 }
 
-// makeClientFromToken returns Keycloak client, a bool flag indicating whether it was created in legacy mode and an error.
-func makeClientFromToken(url, token string) (*gocloak.GoCloak, bool, error) {
-	restyClient := resty.New()
+// newGoCloakClient returns gocloak client working in the current or legacy mode.
+func newGoCloakClient(url string, legacyMode bool, restyClient *resty.Client) *gocloak.GoCloak {
+	var kcCl *gocloak.GoCloak
+
+	if legacyMode {
+		kcCl = gocloak.NewClient(url, gocloak.SetLegacyWildFlySupport())
+	} else {
+		kcCl = gocloak.NewClient(url)
+	}
+
+	if restyClient == nil {
+		restyClient = resty.New()
+	}
 
-	kcCl := gocloak.NewClient(url)
 	kcCl.SetRestyClient(restyClient)
 
-	_, err := kcCl.GetRealms(context.Background(), token)
+	return kcCl
+}
+
+// loginWithMode logs in using gocloak client in the mode matching the path Keycloak is served at.
+// If the served path is unknown, the current mode is tried first and the legacy mode is tried on 404 response.
+// It returns the client and a flag indicating whether the client works in legacy mode.
+func loginWithMode(url, servedPath string, restyClient *resty.Client,
+	login func(kcCl *gocloak.GoCloak, legacyMode bool) error,
+) (*gocloak.GoCloak, bool, error) {
+	if legacyMode, ok := legacyModeForPath(url, servedPath); ok {
+		kcCl := newGoCloakClient(url, legacyMode, restyClient)
+		if err := login(kcCl, legacyMode); err != nil {
+			return nil, false, err
+		}
+
+		return kcCl, legacyMode, nil
+	}
+
+	kcCl := newGoCloakClient(url, false, restyClient)
+
+	err := login(kcCl, false)
 	if err == nil {
 		return kcCl, false, nil
 	}
 
 	if !strings.Contains(err.Error(), "404 Not Found") {
-		return nil, false, fmt.Errorf("unexpected error received while trying to get realms using the modern client: %w", err)
+		return nil, false, fmt.Errorf("unexpected error received while trying to login using the modern client: %w", err)
 	}
 
-	kcCl = gocloak.NewClient(url, gocloak.SetLegacyWildFlySupport())
-	kcCl.SetRestyClient(restyClient)
+	kcCl = newGoCloakClient(url, true, restyClient)
 
-	if _, err := kcCl.GetRealms(context.Background(), token); err != nil {
-		return nil, false, fmt.Errorf("failed to create both current and legacy clients: %w", err)
+	if err = login(kcCl, true); err != nil {
+		return nil, false, fmt.Errorf("failed to login using both current and legacy clients: %w", err)
 	}
 
 	return kcCl, true, nil
 }
 
+// MakeFromServiceAccount creates adapter using client_credentials grant type.
+// servedPath is the path Keycloak is served at as reported by the server info, it is empty if unknown.
 func MakeFromServiceAccount(ctx context.Context,
-	url, clientID, clientSecret, realm string,
+	url, servedPath, clientID, clientSecret, realm string,
 	log logr.Logger, restyClient *resty.Client,
 ) (*GoCloakAdapter, error) {
-	if restyClient == nil {
-		restyClient = resty.New()
-	}
-
-	kcCl := gocloak.NewClient(url)
-	kcCl.SetRestyClient(restyClient)
+	var token *gocloak.JWT
 
-	token, err := kcCl.LoginClient(ctx, clientID, clientSecret, realm)
-	if err == nil {
-		return &GoCloakAdapter{
-			client:     kcCl,
-			token:      token,
-			log:        log,
-			basePath:   url,
-			legacyMode: false,
-		}, nil
-	}
-
-	if !strings.Contains(err.Error(), "404 Not Found") {
-		return nil, fmt.Errorf("unexpected error received while trying to get realms using the modern client: %w", err)
-	}
+	kcCl, legacyMode, err := loginWithMode(url, servedPath, restyClient, func(kcCl *gocloak.GoCloak, _ bool) error {
+		var err error
+		token, err = kcCl.LoginClient(ctx, clientID, clientSecret, realm)
 
-	kcCl = gocloak.NewClient(url, gocloak.SetLegacyWildFlySupport())
-	kcCl.SetRestyClient(restyClient)
-
-	token, err = kcCl.LoginClient(ctx, clientID, clientSecret, realm)
+		return err //nolint:wrapcheck // error is wrapped by the caller
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to login with client creds on both current and legacy clients - "+
-			"clientID: %s, realm: %s: %w", clientID, realm, err)
+		return nil, fmt.Errorf("failed to login with client creds - clientID: %s, realm: %s: %w", clientID, realm, err)
 	}
 
 	return &GoCloakAdapter{
-		client:     kcCl,
-		token:      token,
-		log:        log,
-		basePath:   url,
-		legacyMode: true,
+		client:       kcCl,
+		token:        token,
+		log:          log,
+		basePath:     url,
+		legacyMode:   legacyMode,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		realm:        realm,
 	}, nil
 }
 
-func Make(ctx context.Context, url, user, password string, log logr.Logger, restyClient *resty.Client) (*GoCloakAdapter, error) {
-	if restyClient == nil {
-		restyClient = resty.New()
-	}
-
-	kcCl := gocloak.NewClient(url)
-	kcCl.SetRestyClient(restyClient)
-
-	token, err := kcCl.LoginAdmin(ctx, user, password, "master")
-	if err == nil {
-		return &GoCloakAdapter{
-			client:     kcCl,
-			token:      token,
-			log:        log,
-			basePath:   url,
-			legacyMode: false,
-		}, nil
-	}
-
-	if !strings.Contains(err.Error(), "404 Not Found") {
-		return nil, fmt.Errorf("unexpected error received while trying to get realms using the modern client: %w", err)
-	}
+// Make creates adapter using admin user credentials.
+// servedPath is the path Keycloak is served at as reported by the server info, it is empty if unknown.
+func Make(ctx context.Context, url, servedPath, user, password, realm string, log logr.Logger,
+	restyClient *resty.Client,
+) (*GoCloakAdapter, error) {
+	var token *gocloak.JWT
 
-	kcCl = gocloak.NewClient(url, gocloak.SetLegacyWildFlySupport())
-	kcCl.SetRestyClient(restyClient)
+	kcCl, legacyMode, err := loginWithMode(url, servedPath, restyClient, func(kcCl *gocloak.GoCloak, _ bool) error {
+		var err error
+		token, err = kcCl.LoginAdmin(ctx, user, password, realm)
 
-	token, err = kcCl.LoginAdmin(ctx, user, password, "master")
+		return err //nolint:wrapcheck // error is wrapped by the caller
+	})
 	if err != nil {
 		return nil, errors.Wrapf(err, "cannot login to keycloak server with user: %s", user)
 	}
@@ -252,7 +306,9 @@ func Make(ctx context.Context, url, user, password string, log logr.Logger, rest
 		token:      token,
 		log:        log,
 		basePath:   url,
-		legacyMode: true,
+		legacyMode: legacyMode,
+		clientID:   adminCliClientID,
+		realm:      realm,
 	}, nil
 }
 
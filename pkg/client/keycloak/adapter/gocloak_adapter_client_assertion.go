@@ -0,0 +1,147 @@
+package adapter
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/go-logr/logr"
+	"github.com/go-resty/resty/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	clientAssertionTypeJWTBearer = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+	clientAssertionLifetime      = time.Minute
+)
+
+// MakeFromPrivateKeyJWT creates adapter using client_credentials grant type
+// with JWT client assertion signed by the given PEM encoded RSA or EC private key.
+// servedPath is the path Keycloak is served at as reported by the server info, it is empty if unknown.
+func MakeFromPrivateKeyJWT(ctx context.Context,
+	url, servedPath, clientID, privateKeyPEM, realm string,
+	log logr.Logger, restyClient *resty.Client,
+) (*GoCloakAdapter, error) {
+	signingMethod, key, err := parseSigningKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	var token *gocloak.JWT
+
+	kcCl, legacyMode, err := loginWithMode(url, servedPath, restyClient, func(kcCl *gocloak.GoCloak, legacyMode bool) error {
+		var err error
+		token, err = loginClientAssertion(ctx, kcCl, realmTokenURL(url, realm, legacyMode), clientID, realm, signingMethod, key)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to login with client assertion - clientID: %s, realm: %s: %w", clientID, realm, err)
+	}
+
+	return &GoCloakAdapter{
+		client:        kcCl,
+		token:         token,
+		log:           log,
+		basePath:      url,
+		legacyMode:    legacyMode,
+		clientID:      clientID,
+		realm:         realm,
+		signingMethod: signingMethod,
+		signingKey:    key,
+	}, nil
+}
+
+func loginClientAssertion(ctx context.Context, kcCl *gocloak.GoCloak, audience, clientID, realm string,
+	signingMethod jwt.SigningMethod, key interface{},
+) (*gocloak.JWT, error) {
+	assertion, err := signClientAssertion(audience, clientID, signingMethod, key)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := kcCl.GetToken(ctx, realm, gocloak.TokenOptions{
+		ClientID:            &clientID,
+		GrantType:           gocloak.StringP("client_credentials"),
+		ClientAssertionType: gocloak.StringP(clientAssertionTypeJWTBearer),
+		ClientAssertion:     &assertion,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get token: %w", err)
+	}
+
+	return token, nil
+}
+
+func signClientAssertion(audience, clientID string, signingMethod jwt.SigningMethod, key interface{}) (string, error) {
+	now := time.Now()
+
+	assertion, err := jwt.NewWithClaims(signingMethod, jwt.RegisteredClaims{
+		Issuer:    clientID,
+		Subject:   clientID,
+		Audience:  jwt.ClaimStrings{audience},
+		ID:        uuid.New().String(),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(clientAssertionLifetime)),
+	}).SignedString(key)
+	if err != nil {
+		return "", fmt.Errorf("unable to sign client assertion: %w", err)
+	}
+
+	return assertion, nil
+}
+
+// realmTokenURL returns token endpoint of the realm which is used as the client assertion audience.
+func realmTokenURL(url, realm string, legacyMode bool) string {
+	base := strings.TrimRight(url, "/")
+	if legacyMode {
+		base += "/auth"
+	}
+
+	return fmt.Sprintf("%s/realms/%s/protocol/openid-connect/token", base, realm)
+}
+
+// parseSigningKey parses PEM encoded RSA or EC private key and returns the corresponding JWT signing method.
+func parseSigningKey(privateKeyPEM string) (jwt.SigningMethod, interface{}, error) {
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return nil, nil, errors.New("unable to decode PEM private key")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		if rsaKey, rsaErr := x509.ParsePKCS1PrivateKey(block.Bytes); rsaErr == nil {
+			key = rsaKey
+		} else if ecKey, ecErr := x509.ParseECPrivateKey(block.Bytes); ecErr == nil {
+			key = ecKey
+		} else {
+			return nil, nil, errors.Wrap(err, "unable to parse private key")
+		}
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return jwt.SigningMethodRS256, k, nil
+	case *ecdsa.PrivateKey:
+		switch k.Curve.Params().BitSize {
+		case 256:
+			return jwt.SigningMethodES256, k, nil
+		case 384:
+			return jwt.SigningMethodES384, k, nil
+		case 521:
+			return jwt.SigningMethodES512, k, nil
+		}
+
+		return nil, nil, fmt.Errorf("unsupported EC curve %s", k.Curve.Params().Name)
+	default:
+		return nil, nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+}
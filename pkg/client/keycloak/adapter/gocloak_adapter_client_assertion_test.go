@@ -0,0 +1,96 @@
+package adapter
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func TestMakeFromPrivateKeyJWT(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	var server *httptest.Server
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/realms/admins/protocol/openid-connect/token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.Form.Get("grant_type"))
+		assert.Equal(t, "operator", r.Form.Get("client_id"))
+		assert.Equal(t, clientAssertionTypeJWTBearer, r.Form.Get("client_assertion_type"))
+
+		claims := jwt.RegisteredClaims{}
+		_, err := jwt.ParseWithClaims(r.Form.Get("client_assertion"), &claims, func(token *jwt.Token) (interface{}, error) {
+			return &key.PublicKey, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "operator", claims.Subject)
+		assert.True(t, claims.VerifyAudience(server.URL+"/realms/admins/protocol/openid-connect/token", true))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"token"}`))
+	}))
+	defer server.Close()
+
+	a, err := MakeFromPrivateKeyJWT(context.Background(), server.URL, "", "operator", string(keyPEM), "admins",
+		mock.NewLogr(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "token", a.token.AccessToken)
+	assert.Equal(t, "admins", a.realm)
+	assert.False(t, a.legacyMode)
+}
+
+func TestParseSigningKey(t *testing.T) {
+	t.Parallel()
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(t, err)
+
+	method, _, err := parseSigningKey(string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER})))
+	require.NoError(t, err)
+	assert.Equal(t, jwt.SigningMethodES384, method)
+
+	pkcs8DER, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	require.NoError(t, err)
+
+	method, _, err = parseSigningKey(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8DER})))
+	require.NoError(t, err)
+	assert.Equal(t, jwt.SigningMethodES384, method)
+
+	_, _, err = parseSigningKey("not a key")
+	require.Error(t, err)
+
+	_, _, err = parseSigningKey(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("invalid")})))
+	require.Error(t, err)
+}
+
+func TestRealmTokenURL(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "https://kc/realms/master/protocol/openid-connect/token", realmTokenURL("https://kc/", "master", false))
+	assert.Equal(t, "https://kc/auth/realms/master/protocol/openid-connect/token", realmTokenURL("https://kc", "master", true))
+}
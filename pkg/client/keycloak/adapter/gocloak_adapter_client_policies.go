@@ -0,0 +1,175 @@
+package adapter
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// ClientProfile is a client profile of the realm.
+type ClientProfile struct {
+	Name        string                  `json:"name"`
+	Description string                  `json:"description,omitempty"`
+	Executors   []ClientProfileExecutor `json:"executors"`
+}
+
+type ClientProfileExecutor struct {
+	Executor      string                 `json:"executor"`
+	Configuration map[string]interface{} `json:"configuration,omitempty"`
+}
+
+// ClientPolicy is a client policy of the realm.
+type ClientPolicy struct {
+	Name        string                  `json:"name"`
+	Description string                  `json:"description,omitempty"`
+	Enabled     bool                    `json:"enabled"`
+	Conditions  []ClientPolicyCondition `json:"conditions"`
+	Profiles    []string                `json:"profiles"`
+}
+
+type ClientPolicyCondition struct {
+	Condition     string                 `json:"condition"`
+	Configuration map[string]interface{} `json:"configuration,omitempty"`
+}
+
+// clientProfiles is used for realm profiles only, global profiles are read-only.
+type clientProfiles struct {
+	Profiles []ClientProfile `json:"profiles"`
+}
+
+// clientPolicies is used for realm policies only, global policies are read-only.
+type clientPolicies struct {
+	Policies []ClientPolicy `json:"policies"`
+}
+
+// SyncRealmClientProfiles puts client profiles of the realm.
+// Profiles are matched by name, realm profiles which are not in profiles are kept.
+func (a GoCloakAdapter) SyncRealmClientProfiles(ctx context.Context, realmName string, profiles []ClientProfile) error {
+	current := clientProfiles{}
+	if err := a.getRealmResource(ctx, realmName, realmClientProfiles, &current); err != nil {
+		return errors.Wrap(err, "unable to get client profiles")
+	}
+
+	desired := clientProfiles{Profiles: append([]ClientProfile(nil), current.Profiles...)}
+
+	for _, profile := range profiles {
+		desired.Profiles = putByName(desired.Profiles, profile, func(p ClientProfile) string { return p.Name })
+	}
+
+	if reflect.DeepEqual(normalizeClientProfiles(current.Profiles), normalizeClientProfiles(desired.Profiles)) {
+		return nil
+	}
+
+	if err := a.putRealmResource(ctx, realmName, realmClientProfiles, desired); err != nil {
+		return errors.Wrap(err, "unable to update client profiles")
+	}
+
+	return nil
+}
+
+// SyncRealmClientPolicies puts client policies of the realm.
+// Policies are matched by name, realm policies which are not in policies are kept.
+func (a GoCloakAdapter) SyncRealmClientPolicies(ctx context.Context, realmName string, policies []ClientPolicy) error {
+	current := clientPolicies{}
+	if err := a.getRealmResource(ctx, realmName, realmClientPolicies, &current); err != nil {
+		return errors.Wrap(err, "unable to get client policies")
+	}
+
+	desired := clientPolicies{Policies: append([]ClientPolicy(nil), current.Policies...)}
+
+	for _, policy := range policies {
+		desired.Policies = putByName(desired.Policies, policy, func(p ClientPolicy) string { return p.Name })
+	}
+
+	if reflect.DeepEqual(normalizeClientPolicies(current.Policies), normalizeClientPolicies(desired.Policies)) {
+		return nil
+	}
+
+	if err := a.putRealmResource(ctx, realmName, realmClientPolicies, desired); err != nil {
+		return errors.Wrap(err, "unable to update client policies")
+	}
+
+	return nil
+}
+
+// normalizeClientProfiles returns a copy of profiles where empty executors and configurations are nil.
+// Keycloak returns empty configuration as {}, while it is nil if it is not specified in the custom resource.
+func normalizeClientProfiles(profiles []ClientProfile) []ClientProfile {
+	if len(profiles) == 0 {
+		return nil
+	}
+
+	out := make([]ClientProfile, len(profiles))
+
+	for i, p := range profiles {
+		out[i] = p
+		out[i].Executors = nil
+
+		for _, e := range p.Executors {
+			if len(e.Configuration) == 0 {
+				e.Configuration = nil
+			}
+
+			out[i].Executors = append(out[i].Executors, e)
+		}
+	}
+
+	return out
+}
+
+// normalizeClientPolicies returns a copy of policies where empty conditions, profiles and configurations are nil.
+func normalizeClientPolicies(policies []ClientPolicy) []ClientPolicy {
+	if len(policies) == 0 {
+		return nil
+	}
+
+	out := make([]ClientPolicy, len(policies))
+
+	for i, p := range policies {
+		out[i] = p
+		out[i].Conditions = nil
+
+		for _, c := range p.Conditions {
+			if len(c.Configuration) == 0 {
+				c.Configuration = nil
+			}
+
+			out[i].Conditions = append(out[i].Conditions, c)
+		}
+
+		if len(p.Profiles) == 0 {
+			out[i].Profiles = nil
+		}
+	}
+
+	return out
+}
+
+func (a GoCloakAdapter) getRealmResource(ctx context.Context, realmName, path string, result interface{}) error {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+	}).SetResult(result).Get(a.buildPath(path))
+
+	return a.checkError(err, rsp)
+}
+
+func (a GoCloakAdapter) putRealmResource(ctx context.Context, realmName, path string, body interface{}) error {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+	}).SetBody(body).Put(a.buildPath(path))
+
+	return a.checkError(err, rsp)
+}
+
+// putByName replaces the item with the same name or appends it.
+func putByName[T any](items []T, item T, name func(T) string) []T {
+	for i := range items {
+		if name(items[i]) == name(item) {
+			items[i] = item
+			return items
+		}
+	}
+
+	return append(items, item)
+}
@@ -0,0 +1,127 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoCloakAdapter_SyncRealmClientProfiles(t *testing.T) {
+	a, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/client-policies/profiles",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, json.RawMessage(`{
+			"profiles": [
+				{"name": "manual", "executors": [{"executor": "holder-of-key-enforcer"}]},
+				{"name": "pkce", "executors": [{"executor": "pkce-enforcer", "configuration": {"auto-configure": false}}]}
+			],
+			"globalProfiles": [{"name": "fapi-1-baseline", "executors": []}]
+		}`)))
+
+	var updated clientProfiles
+
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm1/client-policies/profiles",
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&updated); err != nil {
+				return nil, err
+			}
+
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	pkce := ClientProfile{
+		Name: "pkce",
+		Executors: []ClientProfileExecutor{
+			{Executor: "pkce-enforcer", Configuration: map[string]interface{}{"auto-configure": true}},
+		},
+	}
+
+	require.NoError(t, a.SyncRealmClientProfiles(context.Background(), "realm1", []ClientProfile{pkce}))
+	require.Len(t, updated.Profiles, 2)
+	assert.Equal(t, "manual", updated.Profiles[0].Name, "profiles which are not managed must be kept")
+	assert.Equal(t, pkce, updated.Profiles[1])
+
+	updated = clientProfiles{}
+	pkce.Executors[0].Configuration["auto-configure"] = false
+
+	require.NoError(t, a.SyncRealmClientProfiles(context.Background(), "realm1", []ClientProfile{pkce}))
+	assert.Empty(t, updated.Profiles, "up to date profiles must not be updated")
+}
+
+func TestGoCloakAdapter_SyncRealmClientPolicies(t *testing.T) {
+	a, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/client-policies/policies",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, json.RawMessage(`{"policies": []}`)))
+
+	var updated clientPolicies
+
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm1/client-policies/policies",
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&updated); err != nil {
+				return nil, err
+			}
+
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	policy := ClientPolicy{
+		Name:    "confidential-clients",
+		Enabled: true,
+		Conditions: []ClientPolicyCondition{
+			{Condition: "client-access-type", Configuration: map[string]interface{}{"type": []interface{}{"confidential"}}},
+		},
+		Profiles: []string{"pkce"},
+	}
+
+	require.NoError(t, a.SyncRealmClientPolicies(context.Background(), "realm1", []ClientPolicy{policy}))
+	assert.Equal(t, []ClientPolicy{policy}, updated.Policies)
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm2/client-policies/policies",
+		httpmock.NewStringResponder(http.StatusForbidden, "forbidden"))
+
+	err := a.SyncRealmClientPolicies(context.Background(), "realm2", []ClientPolicy{policy})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to get client policies")
+}
+
+func TestGoCloakAdapter_SyncRealmClientPolicies_EmptyConfiguration(t *testing.T) {
+	a, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/client-policies/profiles",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, json.RawMessage(`{
+			"profiles": [{"name": "holder", "executors": [{"executor": "holder-of-key-enforcer", "configuration": {}}]}]
+		}`)))
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/client-policies/policies",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, json.RawMessage(`{
+			"policies": [{
+				"name": "all-clients",
+				"enabled": true,
+				"conditions": [{"condition": "any-client", "configuration": {}}],
+				"profiles": []
+			}]
+		}`)))
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm1/client-policies/profiles",
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm1/client-policies/policies",
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	require.NoError(t, a.SyncRealmClientProfiles(context.Background(), "realm1", []ClientProfile{{
+		Name:      "holder",
+		Executors: []ClientProfileExecutor{{Executor: "holder-of-key-enforcer"}},
+	}}))
+	require.NoError(t, a.SyncRealmClientPolicies(context.Background(), "realm1", []ClientPolicy{{
+		Name:       "all-clients",
+		Enabled:    true,
+		Conditions: []ClientPolicyCondition{{Condition: "any-client"}},
+	}}))
+
+	info := httpmock.GetCallCountInfo()
+	assert.Zero(t, info["PUT /admin/realms/realm1/client-policies/profiles"], "up to date profiles must not be updated")
+	assert.Zero(t, info["PUT /admin/realms/realm1/client-policies/policies"], "up to date policies must not be updated")
+}
@@ -0,0 +1,116 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// GetRealmLocalizationTexts returns localization texts of the realm locale.
+func (a GoCloakAdapter) GetRealmLocalizationTexts(ctx context.Context, realmName, locale string) (map[string]string, error) {
+	texts := make(map[string]string)
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm:  realmName,
+		keycloakApiParamLocale: locale,
+	}).SetResult(&texts).Get(a.buildPath(realmLocalization))
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, errors.Wrapf(err, "unable to get localization texts of locale %s", locale)
+	}
+
+	return texts, nil
+}
+
+// SyncRealmLocalizationTexts puts localization texts of the realm locale.
+// Only changed texts are updated. If addOnly is false, texts which are absent in texts are deleted.
+func (a GoCloakAdapter) SyncRealmLocalizationTexts(ctx context.Context, realmName, locale string, texts map[string]string, addOnly bool) error {
+	current, err := a.GetRealmLocalizationTexts(ctx, realmName, locale)
+	if err != nil {
+		return err
+	}
+
+	for key, text := range texts {
+		if currentText, ok := current[key]; ok && currentText == text {
+			continue
+		}
+
+		if err := a.putRealmLocalizationText(ctx, realmName, locale, key, text); err != nil {
+			return err
+		}
+	}
+
+	if addOnly {
+		return nil
+	}
+
+	for key := range current {
+		if _, ok := texts[key]; ok {
+			continue
+		}
+
+		if err := a.deleteRealmLocalizationText(ctx, realmName, locale, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteRealmLocalizationTextsExcept deletes localization texts of the realm locales which are not in locales.
+func (a GoCloakAdapter) DeleteRealmLocalizationTextsExcept(ctx context.Context, realmName string, locales []string) error {
+	var current []string
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+	}).SetResult(&current).Get(a.buildPath(realmLocalizations))
+	if err = a.checkError(err, rsp); err != nil {
+		return errors.Wrap(err, "unable to get localization locales")
+	}
+
+	keep := make(map[string]struct{}, len(locales))
+	for _, locale := range locales {
+		keep[locale] = struct{}{}
+	}
+
+	for _, locale := range current {
+		if _, ok := keep[locale]; ok {
+			continue
+		}
+
+		rsp, err = a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+			keycloakApiParamRealm:  realmName,
+			keycloakApiParamLocale: locale,
+		}).Delete(a.buildPath(realmLocalization))
+		if err = a.checkError(err, rsp); err != nil {
+			return errors.Wrapf(err, "unable to delete localization texts of locale %s", locale)
+		}
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) putRealmLocalizationText(ctx context.Context, realmName, locale, key, text string) error {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm:  realmName,
+		keycloakApiParamLocale: locale,
+		keycloakApiParamKey:    key,
+	}).SetHeader(contentTypeHeader, contentTypeText).SetBody(text).Put(a.buildPath(realmLocalizationText))
+	if err = a.checkError(err, rsp); err != nil {
+		return errors.Wrapf(err, "unable to put localization text %s of locale %s", key, locale)
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) deleteRealmLocalizationText(ctx context.Context, realmName, locale, key string) error {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm:  realmName,
+		keycloakApiParamLocale: locale,
+		keycloakApiParamKey:    key,
+	}).Delete(a.buildPath(realmLocalizationText))
+	if err = a.checkError(err, rsp); err != nil {
+		return errors.Wrapf(err, "unable to delete localization text %s of locale %s", key, locale)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoCloakAdapter_SyncRealmLocalizationTexts(t *testing.T) {
+	a, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/localization/de",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]string{
+			"loginTitle": "Anmelden",
+			"doLogIn":    "Einloggen",
+			"obsolete":   "Veraltet",
+		}))
+
+	var putBody string
+
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm1/localization/de/doLogIn",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, contentTypeText, req.Header.Get(contentTypeHeader))
+
+			body := make([]byte, req.ContentLength)
+			_, _ = req.Body.Read(body)
+			putBody = string(body)
+
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm1/localization/de/doRegister",
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+	httpmock.RegisterResponder(http.MethodDelete, "/admin/realms/realm1/localization/de/obsolete",
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	texts := map[string]string{
+		"loginTitle": "Anmelden",
+		"doLogIn":    "Anmelden jetzt",
+		"doRegister": "Registrieren",
+	}
+
+	require.NoError(t, a.SyncRealmLocalizationTexts(context.Background(), "realm1", "de", texts, true))
+	assert.Equal(t, "Anmelden jetzt", putBody)
+
+	info := httpmock.GetCallCountInfo()
+	assert.Zero(t, info["PUT /admin/realms/realm1/localization/de/loginTitle"], "unchanged text must not be updated")
+	assert.Zero(t, info["DELETE /admin/realms/realm1/localization/de/obsolete"], "addOnly must not delete texts")
+
+	require.NoError(t, a.SyncRealmLocalizationTexts(context.Background(), "realm1", "de", texts, false))
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["DELETE /admin/realms/realm1/localization/de/obsolete"])
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/localization/fr",
+		httpmock.NewStringResponder(http.StatusInternalServerError, "fatal"))
+
+	err := a.SyncRealmLocalizationTexts(context.Background(), "realm1", "fr", texts, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to get localization texts of locale fr")
+}
+
+func TestGoCloakAdapter_DeleteRealmLocalizationTextsExcept(t *testing.T) {
+	a, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/localization",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, []string{"de", "fr"}))
+	httpmock.RegisterResponder(http.MethodDelete, "/admin/realms/realm1/localization/fr",
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	require.NoError(t, a.DeleteRealmLocalizationTextsExcept(context.Background(), "realm1", []string{"de"}))
+
+	info := httpmock.GetCallCountInfo()
+	assert.Equal(t, 1, info["DELETE /admin/realms/realm1/localization/fr"])
+	assert.Zero(t, info["DELETE /admin/realms/realm1/localization/de"], "texts of used locales must be kept")
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm2/localization",
+		httpmock.NewStringResponder(http.StatusForbidden, "forbidden"))
+
+	err := a.DeleteRealmLocalizationTextsExcept(context.Background(), "realm2", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to get localization locales")
+}
@@ -0,0 +1,82 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	PartialImportPolicyFail      = "FAIL"
+	PartialImportPolicySkip      = "SKIP"
+	PartialImportPolicyOverwrite = "OVERWRITE"
+)
+
+// PartialImportResult is a result of realm partial import.
+type PartialImportResult struct {
+	Overwritten int                         `json:"overwritten"`
+	Added       int                         `json:"added"`
+	Skipped     int                         `json:"skipped"`
+	Results     []PartialImportResourceItem `json:"results,omitempty"`
+}
+
+// PartialImportResourceItem is an outcome of import of a single resource.
+type PartialImportResourceItem struct {
+	Action       string `json:"action"`
+	ResourceType string `json:"resourceType"`
+	ResourceName string `json:"resourceName"`
+	ID           string `json:"id"`
+}
+
+// PartialImport imports resources from realm representation into the existing realm.
+// ifResourceExists defines what to do with resources which already exist: FAIL, SKIP or OVERWRITE.
+func (a GoCloakAdapter) PartialImport(
+	ctx context.Context,
+	realmName string,
+	representation []byte,
+	ifResourceExists string,
+) (*PartialImportResult, error) {
+	body := make(map[string]interface{})
+	if err := json.Unmarshal(representation, &body); err != nil {
+		return nil, errors.Wrap(err, "unable to decode realm representation")
+	}
+
+	body["ifResourceExists"] = ifResourceExists
+
+	result := &PartialImportResult{}
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+	}).SetBody(body).SetResult(result).Post(a.buildPath(realmPartialImport))
+
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, errors.Wrap(err, "unable to import realm resources")
+	}
+
+	return result, nil
+}
+
+// PartialExportOptions defines which resources are included into realm partial export.
+type PartialExportOptions struct {
+	ExportClients        bool
+	ExportGroupsAndRoles bool
+}
+
+// PartialExport exports realm representation.
+// Keycloak masks secrets in the exported representation.
+func (a GoCloakAdapter) PartialExport(ctx context.Context, realmName string, opts PartialExportOptions) ([]byte, error) {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+	}).SetQueryParams(map[string]string{
+		"exportClients":        strconv.FormatBool(opts.ExportClients),
+		"exportGroupsAndRoles": strconv.FormatBool(opts.ExportGroupsAndRoles),
+	}).Post(a.buildPath(realmPartialExport))
+
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, errors.Wrap(err, "unable to export realm")
+	}
+
+	return rsp.Body(), nil
+}
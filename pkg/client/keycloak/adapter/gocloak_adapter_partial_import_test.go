@@ -0,0 +1,78 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoCloakAdapter_PartialImport(t *testing.T) {
+	a, _, _ := initAdapter()
+
+	var body map[string]interface{}
+
+	httpmock.RegisterResponder(http.MethodPost, "/admin/realms/realm1/partialImport",
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			return httpmock.NewJsonResponse(http.StatusOK, json.RawMessage(`{
+				"overwritten": 0, "added": 1, "skipped": 1,
+				"results": [
+					{"action": "ADDED", "resourceType": "CLIENT", "resourceName": "app", "id": "1"},
+					{"action": "SKIPPED", "resourceType": "REALM_ROLE", "resourceName": "admin", "id": "2"}
+				]
+			}`))
+		})
+
+	res, err := a.PartialImport(context.Background(), "realm1",
+		[]byte(`{"realm": "realm1", "clients": [{"clientId": "app"}]}`), PartialImportPolicySkip)
+	require.NoError(t, err)
+
+	assert.Equal(t, "SKIP", body["ifResourceExists"])
+	assert.Equal(t, "realm1", body["realm"])
+	assert.Equal(t, 1, res.Added)
+	assert.Equal(t, 1, res.Skipped)
+	require.Len(t, res.Results, 2)
+	assert.Equal(t, "CLIENT", res.Results[0].ResourceType)
+
+	_, err = a.PartialImport(context.Background(), "realm1", []byte(`not json`), PartialImportPolicySkip)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to decode realm representation")
+
+	httpmock.RegisterResponder(http.MethodPost, "/admin/realms/realm2/partialImport",
+		httpmock.NewStringResponder(http.StatusConflict, `{"errorMessage": "Client app already exists"}`))
+
+	_, err = a.PartialImport(context.Background(), "realm2", []byte(`{}`), PartialImportPolicyFail)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to import realm resources")
+}
+
+func TestGoCloakAdapter_PartialExport(t *testing.T) {
+	a, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodPost, "/admin/realms/realm1/partial-export",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "true", req.URL.Query().Get("exportClients"))
+			assert.Equal(t, "false", req.URL.Query().Get("exportGroupsAndRoles"))
+
+			return httpmock.NewStringResponse(http.StatusOK, `{"realm": "realm1"}`), nil
+		})
+
+	data, err := a.PartialExport(context.Background(), "realm1", PartialExportOptions{ExportClients: true})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"realm": "realm1"}`, string(data))
+
+	httpmock.RegisterResponder(http.MethodPost, "/admin/realms/realm2/partial-export",
+		httpmock.NewStringResponder(http.StatusForbidden, "forbidden"))
+
+	_, err = a.PartialExport(context.Background(), "realm2", PartialExportOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to export realm")
+}
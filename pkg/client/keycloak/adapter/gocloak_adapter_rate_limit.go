@@ -0,0 +1,144 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+// RequestLimiter limits requests to Keycloak.
+type RequestLimiter interface {
+	// Wait blocks until the request is allowed or ctx is done.
+	// The returned function must be called when the request is finished.
+	Wait(ctx context.Context) (release func(), err error)
+}
+
+// RateLimitPolicy defines client-side limits for requests to Keycloak.
+type RateLimitPolicy struct {
+	// RequestsPerSecond is a maximum average number of requests per second. Zero means no limit.
+	RequestsPerSecond int
+	// Burst is a maximum number of requests sent at once above the rate. Defaults to RequestsPerSecond.
+	Burst int
+	// MaxConcurrentRequests is a maximum number of in-flight requests. Zero means no limit.
+	MaxConcurrentRequests int
+}
+
+// RateLimiter is a token bucket rate limiter combined with a cap on in-flight requests.
+type RateLimiter struct {
+	policy     RateLimitPolicy
+	limiter    *rate.Limiter
+	slots      chan struct{}
+	queueDepth prometheus.Gauge
+}
+
+// NewRateLimiter creates RateLimiter. The number of requests waiting for the limiter is reported to queueDepth.
+func NewRateLimiter(policy RateLimitPolicy, queueDepth prometheus.Gauge) *RateLimiter {
+	l := &RateLimiter{
+		policy:     policy,
+		queueDepth: queueDepth,
+	}
+
+	if policy.RequestsPerSecond > 0 {
+		burst := policy.Burst
+		if burst <= 0 {
+			burst = policy.RequestsPerSecond
+		}
+
+		l.limiter = rate.NewLimiter(rate.Limit(policy.RequestsPerSecond), burst)
+	}
+
+	if policy.MaxConcurrentRequests > 0 {
+		l.slots = make(chan struct{}, policy.MaxConcurrentRequests)
+	}
+
+	return l
+}
+
+// Policy returns the policy the limiter was created with.
+func (l *RateLimiter) Policy() RateLimitPolicy {
+	return l.policy
+}
+
+// Wait blocks until the rate limit allows the request and a concurrency slot is free.
+func (l *RateLimiter) Wait(ctx context.Context) (func(), error) {
+	l.queueDepth.Inc()
+	defer l.queueDepth.Dec()
+
+	if l.limiter != nil {
+		if err := l.limiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("rate limit wait failed: %w", err)
+		}
+	}
+
+	if l.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		return func() { <-l.slots }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("concurrency limit wait failed: %w", ctx.Err())
+	}
+}
+
+// ConfigureRateLimit wraps transport of the resty client with the limiter.
+// The same resty client is used by gocloak client, so the limit is applied to all adapter requests.
+// It must be called after TLS settings are applied to the client.
+func ConfigureRateLimit(restyClient *resty.Client, limiter RequestLimiter) *resty.Client {
+	next := restyClient.GetClient().Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return restyClient.SetTransport(&rateLimitedTransport{
+		next:    next,
+		limiter: limiter,
+	})
+}
+
+type rateLimitedTransport struct {
+	next    http.RoundTripper
+	limiter RequestLimiter
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, err := t.limiter.Wait(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("request is not allowed by limiter: %w", err)
+	}
+
+	rsp, err := t.next.RoundTrip(req)
+	if err != nil {
+		release()
+
+		return nil, err
+	}
+
+	// the request is in flight until the response body is read
+	rsp.Body = &releasingBody{ReadCloser: rsp.Body, release: release}
+
+	return rsp, nil
+}
+
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	defer b.once.Do(b.release)
+
+	if err := b.ReadCloser.Close(); err != nil {
+		return fmt.Errorf("unable to close response body: %w", err)
+	}
+
+	return nil
+}
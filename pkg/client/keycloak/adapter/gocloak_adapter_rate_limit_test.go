@@ -0,0 +1,85 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureRateLimit_MaxConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	var inFlight, maxInFlight int32
+
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+
+		<-release
+	}))
+	defer server.Close()
+
+	queueDepth := prometheus.NewGauge(prometheus.GaugeOpts{Name: "queue_depth"})
+	limiter := NewRateLimiter(RateLimitPolicy{MaxConcurrentRequests: 2}, queueDepth)
+	cl := ConfigureRateLimit(resty.New(), limiter)
+
+	const requests = 5
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := cl.R().Get(server.URL)
+			assert.NoError(t, err)
+		}()
+	}
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&inFlight) == 2 && testutil.ToFloat64(queueDepth) == requests-2
+	}, time.Second, time.Millisecond)
+
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxInFlight))
+	assert.Zero(t, testutil.ToFloat64(queueDepth))
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewRateLimiter(RateLimitPolicy{RequestsPerSecond: 1}, prometheus.NewGauge(prometheus.GaugeOpts{Name: "queue_depth"}))
+
+	release, err := limiter.Wait(context.Background())
+	require.NoError(t, err)
+	release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = limiter.Wait(ctx)
+	require.Error(t, err, "burst is exhausted, so the second request must wait longer than the context deadline")
+}
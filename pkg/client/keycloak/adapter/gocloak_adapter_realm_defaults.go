@@ -0,0 +1,255 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/pkg/errors"
+)
+
+const accountClientID = "account"
+
+// builtInDefaultRealmRoles are realm roles which Keycloak makes default on realm creation.
+var builtInDefaultRealmRoles = []string{"offline_access", "uma_authorization"}
+
+// builtInDefaultAccountRoles are roles of the account client which Keycloak makes default on realm creation.
+var builtInDefaultAccountRoles = map[string]struct{}{
+	"view-profile":   {},
+	"manage-account": {},
+}
+
+// DefaultRoles contains default roles of the realm.
+type DefaultRoles struct {
+	RealmRoles []string
+	// ClientRoles is a map of client ID to client role names.
+	ClientRoles map[string][]string
+	// PreservedRealmRoles is a list of realm roles which are not removed from default roles if they are not claimed,
+	// e.g. roles made default by KeycloakRealmRole resources. Built-in default roles are always preserved.
+	PreservedRealmRoles []string
+}
+
+// SyncRealmDefaultGroups puts default groups of the realm.
+// If addOnly is false, default groups which are not in groups are removed.
+func (a GoCloakAdapter) SyncRealmDefaultGroups(ctx context.Context, realmName string, groups []string, addOnly bool) error {
+	var current []gocloak.Group
+	if err := a.getRealmResource(ctx, realmName, realmDefaultGroups, &current); err != nil {
+		return errors.Wrap(err, "unable to get default groups")
+	}
+
+	currentGroups := make(map[string]string, len(current))
+
+	for _, g := range current {
+		if g.Name != nil && g.ID != nil {
+			currentGroups[*g.Name] = *g.ID
+		}
+	}
+
+	claimedGroups := make(map[string]struct{}, len(groups))
+
+	for _, name := range groups {
+		claimedGroups[name] = struct{}{}
+
+		if _, ok := currentGroups[name]; ok {
+			continue
+		}
+
+		group, err := a.getGroup(realmName, name)
+		if err != nil {
+			return errors.Wrapf(err, "unable to get group %s", name)
+		}
+
+		rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+			keycloakApiParamRealm: realmName,
+			keycloakApiParamId:    *group.ID,
+		}).Put(a.buildPath(realmDefaultGroup))
+		if err = a.checkError(err, rsp); err != nil {
+			return errors.Wrapf(err, "unable to add default group %s", name)
+		}
+	}
+
+	if addOnly {
+		return nil
+	}
+
+	for name, id := range currentGroups {
+		if _, ok := claimedGroups[name]; ok {
+			continue
+		}
+
+		rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+			keycloakApiParamRealm: realmName,
+			keycloakApiParamId:    id,
+		}).Delete(a.buildPath(realmDefaultGroup))
+		if err = a.checkError(err, rsp); err != nil {
+			return errors.Wrapf(err, "unable to remove default group %s", name)
+		}
+	}
+
+	return nil
+}
+
+// SyncRealmDefaultRoles puts default roles of the realm as composites of the realm default role.
+// If addOnly is false, default roles which are not in roles are removed,
+// except built-in default roles and roles from roles.PreservedRealmRoles.
+func (a GoCloakAdapter) SyncRealmDefaultRoles(ctx context.Context, realmName string, roles *DefaultRoles, addOnly bool) error {
+	realm, err := a.client.GetRealm(ctx, a.token.AccessToken, realmName)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get realm: %s", realmName)
+	}
+
+	if realm.DefaultRole == nil || realm.DefaultRole.ID == nil || realm.DefaultRole.Name == nil {
+		return errors.New("realm default role is not found")
+	}
+
+	var current []gocloak.Role
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+		keycloakApiParamId:    *realm.DefaultRole.ID,
+	}).SetResult(&current).Get(a.buildPath(roleByIDComposites))
+	if err = a.checkError(err, rsp); err != nil {
+		return errors.Wrap(err, "unable to get default roles")
+	}
+
+	claimed, err := a.getDefaultRoles(ctx, realmName, roles)
+	if err != nil {
+		return err
+	}
+
+	toAdd, toDelete := diffRoles(current, claimed)
+
+	if len(toAdd) > 0 {
+		if err := a.client.AddRealmRoleComposite(ctx, a.token.AccessToken, realmName, *realm.DefaultRole.Name, toAdd); err != nil {
+			return errors.Wrap(err, "unable to add default roles")
+		}
+	}
+
+	if addOnly {
+		return nil
+	}
+
+	toDelete, err = a.skipPreservedRoles(realmName, toDelete, roles.PreservedRealmRoles)
+	if err != nil {
+		return err
+	}
+
+	if len(toDelete) > 0 {
+		if err := a.client.DeleteRealmRoleComposite(ctx, a.token.AccessToken, realmName, *realm.DefaultRole.Name, toDelete); err != nil {
+			return errors.Wrap(err, "unable to remove default roles")
+		}
+	}
+
+	return nil
+}
+
+// skipPreservedRoles returns roles without built-in default roles and realm roles from preserved.
+func (a GoCloakAdapter) skipPreservedRoles(realmName string, roles []gocloak.Role, preserved []string) ([]gocloak.Role, error) {
+	preservedRealmRoles := make(map[string]struct{}, len(builtInDefaultRealmRoles)+len(preserved))
+
+	for _, name := range builtInDefaultRealmRoles {
+		preservedRealmRoles[name] = struct{}{}
+	}
+
+	for _, name := range preserved {
+		preservedRealmRoles[name] = struct{}{}
+	}
+
+	var (
+		idOfAccount string
+		result      []gocloak.Role
+	)
+
+	for _, r := range roles {
+		name := gocloak.PString(r.Name)
+
+		if !gocloak.PBool(r.ClientRole) {
+			if _, ok := preservedRealmRoles[name]; !ok {
+				result = append(result, r)
+			}
+
+			continue
+		}
+
+		if _, ok := builtInDefaultAccountRoles[name]; ok {
+			if idOfAccount == "" {
+				id, err := a.GetClientID(accountClientID, realmName)
+				if err != nil && !IsErrNotFound(err) {
+					return nil, errors.Wrapf(err, "unable to get client %s", accountClientID)
+				}
+
+				idOfAccount = id
+			}
+
+			if idOfAccount != "" && gocloak.PString(r.ContainerID) == idOfAccount {
+				continue
+			}
+		}
+
+		result = append(result, r)
+	}
+
+	return result, nil
+}
+
+func (a GoCloakAdapter) getDefaultRoles(ctx context.Context, realmName string, roles *DefaultRoles) ([]gocloak.Role, error) {
+	claimed := make([]gocloak.Role, 0, len(roles.RealmRoles))
+
+	for _, name := range roles.RealmRoles {
+		role, err := a.client.GetRealmRole(ctx, a.token.AccessToken, realmName, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to get realm role %s", name)
+		}
+
+		claimed = append(claimed, *role)
+	}
+
+	for clientID, names := range roles.ClientRoles {
+		idOfClient, err := a.GetClientID(clientID, realmName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to get client %s", clientID)
+		}
+
+		for _, name := range names {
+			role, err := a.client.GetClientRole(ctx, a.token.AccessToken, realmName, idOfClient, name)
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to get role %s of client %s", name, clientID)
+			}
+
+			claimed = append(claimed, *role)
+		}
+	}
+
+	return claimed, nil
+}
+
+// diffRoles returns claimed roles which are not in current and current roles which are not claimed.
+// Roles are compared by ID, so realm and client roles with the same name are distinguished.
+func diffRoles(current, claimed []gocloak.Role) (toAdd, toDelete []gocloak.Role) {
+	currentIDs := make(map[string]struct{}, len(current))
+	for _, r := range current {
+		currentIDs[gocloak.PString(r.ID)] = struct{}{}
+	}
+
+	claimedIDs := make(map[string]struct{}, len(claimed))
+
+	for _, r := range claimed {
+		id := gocloak.PString(r.ID)
+		if _, ok := claimedIDs[id]; ok {
+			continue
+		}
+
+		claimedIDs[id] = struct{}{}
+
+		if _, ok := currentIDs[id]; !ok {
+			toAdd = append(toAdd, r)
+		}
+	}
+
+	for _, r := range current {
+		if _, ok := claimedIDs[gocloak.PString(r.ID)]; !ok {
+			toDelete = append(toDelete, r)
+		}
+	}
+
+	return toAdd, toDelete
+}
@@ -0,0 +1,106 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoCloakAdapter_SyncRealmDefaultGroups(t *testing.T) {
+	a, mockClient, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/default-groups",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, []gocloak.Group{
+			{ID: gocloak.StringP("id-users"), Name: gocloak.StringP("users")},
+			{ID: gocloak.StringP("id-legacy"), Name: gocloak.StringP("legacy")},
+		}))
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm1/default-groups/id-developers",
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+	httpmock.RegisterResponder(http.MethodDelete, "/admin/realms/realm1/default-groups/id-legacy",
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	mockClient.On("GetGroups", "realm1", gocloak.GetGroupsParams{Search: gocloak.StringP("developers")}).
+		Return([]*gocloak.Group{{ID: gocloak.StringP("id-developers"), Name: gocloak.StringP("developers")}}, nil)
+
+	groups := []string{"users", "developers"}
+
+	require.NoError(t, a.SyncRealmDefaultGroups(context.Background(), "realm1", groups, true))
+
+	info := httpmock.GetCallCountInfo()
+	assert.Equal(t, 1, info["PUT /admin/realms/realm1/default-groups/id-developers"])
+	assert.Zero(t, info["DELETE /admin/realms/realm1/default-groups/id-legacy"], "addOnly must not remove groups")
+
+	require.NoError(t, a.SyncRealmDefaultGroups(context.Background(), "realm1", groups, false))
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["DELETE /admin/realms/realm1/default-groups/id-legacy"])
+}
+
+func TestGoCloakAdapter_SyncRealmDefaultRoles(t *testing.T) {
+	a, mockClient, _ := initAdapter()
+
+	offlineAccess := gocloak.Role{ID: gocloak.StringP("id-offline"), Name: gocloak.StringP("offline_access")}
+	developer := gocloak.Role{ID: gocloak.StringP("id-developer"), Name: gocloak.StringP("developer")}
+	legacy := gocloak.Role{ID: gocloak.StringP("id-legacy"), Name: gocloak.StringP("legacy")}
+	tester := gocloak.Role{ID: gocloak.StringP("id-tester"), Name: gocloak.StringP("tester")}
+	manageAccount := gocloak.Role{
+		ID:          gocloak.StringP("id-manage-account"),
+		Name:        gocloak.StringP("manage-account"),
+		ClientRole:  gocloak.BoolP(true),
+		ContainerID: gocloak.StringP("id-account"),
+	}
+	otherManageAccount := gocloak.Role{
+		ID:          gocloak.StringP("id-other-manage-account"),
+		Name:        gocloak.StringP("manage-account"),
+		ClientRole:  gocloak.BoolP(true),
+		ContainerID: gocloak.StringP("id-other"),
+	}
+	viewProfile := gocloak.Role{
+		ID:          gocloak.StringP("id-view-profile"),
+		Name:        gocloak.StringP("view-profile"),
+		ClientRole:  gocloak.BoolP(true),
+		ContainerID: gocloak.StringP("id-account"),
+	}
+
+	mockClient.On("GetRealm", "token", "realm1").Return(&gocloak.RealmRepresentation{
+		DefaultRole: &gocloak.Role{ID: gocloak.StringP("id-default"), Name: gocloak.StringP("default-roles-realm1")},
+	}, nil)
+	mockClient.On("GetRealmRole", "realm1", "developer").Return(&developer, nil)
+	mockClient.On("GetClients", "realm1", gocloak.GetClientsParams{ClientID: gocloak.StringP("account")}).
+		Return([]*gocloak.Client{{ID: gocloak.StringP("id-account"), ClientID: gocloak.StringP("account")}}, nil)
+	mockClient.On("GetClientRole", "realm1", "id-account", "view-profile").Return(&viewProfile, nil)
+	mockClient.On("AddRealmRoleComposite", "realm1", "default-roles-realm1", []gocloak.Role{developer}).Return(nil)
+	mockClient.On("DeleteRealmRoleComposite", "realm1", "default-roles-realm1", []gocloak.Role{legacy, otherManageAccount}).
+		Return(nil).Once()
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/roles-by-id/id-default/composites",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, []gocloak.Role{
+			offlineAccess, legacy, tester, viewProfile, manageAccount, otherManageAccount,
+		}))
+
+	roles := &DefaultRoles{
+		RealmRoles:          []string{"developer"},
+		ClientRoles:         map[string][]string{"account": {"view-profile"}},
+		PreservedRealmRoles: []string{"tester"},
+	}
+
+	require.NoError(t, a.SyncRealmDefaultRoles(context.Background(), "realm1", roles, true))
+	mockClient.AssertNotCalled(t, "DeleteRealmRoleComposite", mock.Anything, mock.Anything, mock.Anything)
+
+	require.NoError(t, a.SyncRealmDefaultRoles(context.Background(), "realm1", roles, false))
+	mockClient.AssertExpectations(t)
+}
+
+func TestGoCloakAdapter_SyncRealmDefaultRoles_NoDefaultRole(t *testing.T) {
+	a, mockClient, _ := initAdapter()
+
+	mockClient.On("GetRealm", "token", "realm1").Return(&gocloak.RealmRepresentation{}, nil)
+
+	err := a.SyncRealmDefaultRoles(context.Background(), "realm1", &DefaultRoles{}, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "realm default role is not found")
+}
@@ -1,9 +1,12 @@
 package adapter
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/Nerzal/gocloak/v12"
@@ -17,6 +20,97 @@ type RealmSettings struct {
 	BrowserSecurityHeaders *map[string]string
 	PasswordPolicies       []PasswordPolicy
 	FrontendURL            string
+	TokenSettings          *TokenSettings
+	Sessions               *RealmSessions
+	Login                  *RealmLogin
+	BruteForceProtection   *BruteForceProtection
+	OTPPolicy              *OTPPolicy
+	WebAuthnPolicy         *WebAuthnPolicy
+	Localization           *RealmLocalization
+	// UserProfileEnabled enables declarative user profile on Keycloak 22 and 23.
+	UserProfileEnabled *bool
+}
+
+// RealmLocalization contains supported locales of the realm.
+type RealmLocalization struct {
+	SupportedLocales []string
+	DefaultLocale    string
+}
+
+// BruteForceProtection contains brute force detection settings. Nil fields are not changed.
+type BruteForceProtection struct {
+	Enabled                      *bool
+	PermanentLockout             *bool
+	MaxLoginFailures             *int
+	WaitIncrementSeconds         *int
+	MaxFailureWaitSeconds        *int
+	MaxDeltaTimeSeconds          *int
+	QuickLoginCheckMilliSeconds  *int64
+	MinimumQuickLoginWaitSeconds *int
+}
+
+// OTPPolicy contains one-time password policy. Nil fields are not changed.
+type OTPPolicy struct {
+	Type            *string
+	Algorithm       *string
+	Digits          *int
+	Period          *int
+	LookAheadWindow *int
+	InitialCounter  *int
+}
+
+// WebAuthnPolicy contains WebAuthn policy. Nil fields are not changed.
+type WebAuthnPolicy struct {
+	RpEntityName                    *string
+	RpID                            *string
+	SignatureAlgorithms             []string
+	AttestationConveyancePreference *string
+	AuthenticatorAttachment         *string
+	RequireResidentKey              *string
+	UserVerificationRequirement     *string
+	CreateTimeout                   *int
+	AvoidSameAuthenticatorRegister  *bool
+	AcceptableAaguids               []string
+}
+
+// RealmLogin contains login and registration settings. Nil fields are not changed.
+type RealmLogin struct {
+	RegistrationAllowed         *bool
+	RegistrationEmailAsUsername *bool
+	RememberMe                  *bool
+	VerifyEmail                 *bool
+	LoginWithEmailAllowed       *bool
+	DuplicateEmailsAllowed      *bool
+	ResetPasswordAllowed        *bool
+	EditUsernameAllowed         *bool
+}
+
+// TokenSettings contains lifespans of tokens and codes in seconds. Nil fields are not changed.
+type TokenSettings struct {
+	AccessTokenLifespan                 *int
+	AccessTokenLifespanForImplicitFlow  *int
+	AccessCodeLifespan                  *int
+	AccessCodeLifespanLogin             *int
+	AccessCodeLifespanUserAction        *int
+	ActionTokenGeneratedByUserLifespan  *int
+	ActionTokenGeneratedByAdminLifespan *int
+	RevokeRefreshToken                  *bool
+	RefreshTokenMaxReuse                *int
+}
+
+// RealmSessions contains session timeouts in seconds. Nil fields are not changed.
+type RealmSessions struct {
+	SSOSessionIdleTimeout            *int
+	SSOSessionMaxLifespan            *int
+	SSOSessionIdleTimeoutRememberMe  *int
+	SSOSessionMaxLifespanRememberMe  *int
+	OfflineSessionIdleTimeout        *int
+	OfflineSessionMaxLifespanEnabled *bool
+	OfflineSessionMaxLifespan        *int
+	ClientSessionIdleTimeout         *int
+	ClientSessionMaxLifespan         *int
+	ClientOfflineSessionIdleTimeout  *int
+	ClientOfflineSessionMaxLifespan  *int
 }
 
 type PasswordPolicy struct {
@@ -32,14 +126,35 @@ type RealmThemes struct {
 	InternationalizationEnabled *bool
 }
 
+// UpdateRealmSettings applies settings to the realm.
+// The realm is updated only if the settings differ from the current realm state,
+// so settings changed outside the operator are detected and re-applied.
 func (a GoCloakAdapter) UpdateRealmSettings(realmName string, realmSettings *RealmSettings) error {
 	realm, err := a.client.GetRealm(context.Background(), a.token.AccessToken, realmName)
 	if err != nil {
 		return errors.Wrapf(err, "unable to realm: %s", realmName)
 	}
 
+	current, err := json.Marshal(realm)
+	if err != nil {
+		return fmt.Errorf("unable to marshal realm %s: %w", realmName, err)
+	}
+
 	setRealmSettings(realm, realmSettings)
 
+	desired, err := json.Marshal(realm)
+	if err != nil {
+		return fmt.Errorf("unable to marshal realm %s: %w", realmName, err)
+	}
+
+	if bytes.Equal(current, desired) {
+		a.log.Info("Realm settings are up to date", logKeyRealm, realmName)
+
+		return nil
+	}
+
+	a.log.Info("Realm settings drift detected, updating realm", logKeyRealm, realmName)
+
 	if err := a.client.UpdateRealm(context.Background(), a.token.AccessToken, *realm); err != nil {
 		return errors.Wrap(err, "unable to update realm")
 	}
@@ -86,6 +201,216 @@ func setRealmSettings(realm *gocloak.RealmRepresentation, realmSettings *RealmSe
 
 		(*realm.Attributes)["frontendUrl"] = realmSettings.FrontendURL
 	}
+
+	if realmSettings.TokenSettings != nil {
+		setRealmTokenSettings(realm, realmSettings.TokenSettings)
+	}
+
+	if realmSettings.Sessions != nil {
+		setRealmSessions(realm, realmSettings.Sessions)
+	}
+
+	if realmSettings.Login != nil {
+		setRealmLogin(realm, realmSettings.Login)
+	}
+
+	if realmSettings.BruteForceProtection != nil {
+		setRealmBruteForceProtection(realm, realmSettings.BruteForceProtection)
+	}
+
+	if realmSettings.OTPPolicy != nil {
+		setRealmOTPPolicy(realm, realmSettings.OTPPolicy)
+	}
+
+	if realmSettings.WebAuthnPolicy != nil {
+		setRealmWebAuthnPolicy(realm, realmSettings.WebAuthnPolicy)
+	}
+
+	if realmSettings.Localization != nil {
+		setRealmLocalization(realm, realmSettings.Localization)
+	}
+
+	if realmSettings.UserProfileEnabled != nil {
+		if realm.Attributes == nil {
+			realm.Attributes = &map[string]string{}
+		}
+
+		(*realm.Attributes)["userProfileEnabled"] = strconv.FormatBool(*realmSettings.UserProfileEnabled)
+	}
+}
+
+func setRealmLocalization(realm *gocloak.RealmRepresentation, localization *RealmLocalization) {
+	if len(localization.SupportedLocales) > 0 {
+		locales := localization.SupportedLocales
+		realm.SupportedLocales = &locales
+		realm.InternationalizationEnabled = gocloak.BoolP(true)
+	}
+
+	if localization.DefaultLocale != "" {
+		realm.DefaultLocale = gocloak.StringP(localization.DefaultLocale)
+	}
+}
+
+func setRealmBruteForceProtection(realm *gocloak.RealmRepresentation, protection *BruteForceProtection) {
+	setIfNotNil(&realm.BruteForceProtected, protection.Enabled)
+	setIfNotNil(&realm.PermanentLockout, protection.PermanentLockout)
+	setIfNotNil(&realm.FailureFactor, protection.MaxLoginFailures)
+	setIfNotNil(&realm.WaitIncrementSeconds, protection.WaitIncrementSeconds)
+	setIfNotNil(&realm.MaxFailureWaitSeconds, protection.MaxFailureWaitSeconds)
+	setIfNotNil(&realm.MaxDeltaTimeSeconds, protection.MaxDeltaTimeSeconds)
+	setIfNotNil(&realm.QuickLoginCheckMilliSeconds, protection.QuickLoginCheckMilliSeconds)
+	setIfNotNil(&realm.MinimumQuickLoginWaitSeconds, protection.MinimumQuickLoginWaitSeconds)
+}
+
+func setRealmOTPPolicy(realm *gocloak.RealmRepresentation, policy *OTPPolicy) {
+	setIfNotNil(&realm.OtpPolicyType, policy.Type)
+	setIfNotNil(&realm.OtpPolicyAlgorithm, policy.Algorithm)
+	setIfNotNil(&realm.OtpPolicyDigits, policy.Digits)
+	setIfNotNil(&realm.OtpPolicyPeriod, policy.Period)
+	setIfNotNil(&realm.OtpPolicyLookAheadWindow, policy.LookAheadWindow)
+	setIfNotNil(&realm.OtpPolicyInitialCounter, policy.InitialCounter)
+}
+
+func setRealmWebAuthnPolicy(realm *gocloak.RealmRepresentation, policy *WebAuthnPolicy) {
+	setIfNotNil(&realm.WebAuthnPolicyRpEntityName, policy.RpEntityName)
+	setIfNotNil(&realm.WebAuthnPolicyRpID, policy.RpID)
+	setIfNotNil(&realm.WebAuthnPolicyAttestationConveyancePreference, policy.AttestationConveyancePreference)
+	setIfNotNil(&realm.WebAuthnPolicyAuthenticatorAttachment, policy.AuthenticatorAttachment)
+	setIfNotNil(&realm.WebAuthnPolicyRequireResidentKey, policy.RequireResidentKey)
+	setIfNotNil(&realm.WebAuthnPolicyUserVerificationRequirement, policy.UserVerificationRequirement)
+	setIfNotNil(&realm.WebAuthnPolicyCreateTimeout, policy.CreateTimeout)
+	setIfNotNil(&realm.WebAuthnPolicyAvoidSameAuthenticatorRegister, policy.AvoidSameAuthenticatorRegister)
+
+	if policy.SignatureAlgorithms != nil {
+		algorithms := policy.SignatureAlgorithms
+		realm.WebAuthnPolicySignatureAlgorithms = &algorithms
+	}
+
+	if policy.AcceptableAaguids != nil {
+		aaguids := policy.AcceptableAaguids
+		realm.WebAuthnPolicyAcceptableAaguids = &aaguids
+	}
+}
+
+func setRealmLogin(realm *gocloak.RealmRepresentation, login *RealmLogin) {
+	setIfNotNil(&realm.RegistrationAllowed, login.RegistrationAllowed)
+	setIfNotNil(&realm.RegistrationEmailAsUsername, login.RegistrationEmailAsUsername)
+	setIfNotNil(&realm.RememberMe, login.RememberMe)
+	setIfNotNil(&realm.VerifyEmail, login.VerifyEmail)
+	setIfNotNil(&realm.LoginWithEmailAllowed, login.LoginWithEmailAllowed)
+	setIfNotNil(&realm.DuplicateEmailsAllowed, login.DuplicateEmailsAllowed)
+	setIfNotNil(&realm.ResetPasswordAllowed, login.ResetPasswordAllowed)
+	setIfNotNil(&realm.EditUsernameAllowed, login.EditUsernameAllowed)
+}
+
+func setRealmTokenSettings(realm *gocloak.RealmRepresentation, settings *TokenSettings) {
+	setIfNotNil(&realm.AccessTokenLifespan, settings.AccessTokenLifespan)
+	setIfNotNil(&realm.AccessTokenLifespanForImplicitFlow, settings.AccessTokenLifespanForImplicitFlow)
+	setIfNotNil(&realm.AccessCodeLifespan, settings.AccessCodeLifespan)
+	setIfNotNil(&realm.AccessCodeLifespanLogin, settings.AccessCodeLifespanLogin)
+	setIfNotNil(&realm.AccessCodeLifespanUserAction, settings.AccessCodeLifespanUserAction)
+	setIfNotNil(&realm.ActionTokenGeneratedByUserLifespan, settings.ActionTokenGeneratedByUserLifespan)
+	setIfNotNil(&realm.ActionTokenGeneratedByAdminLifespan, settings.ActionTokenGeneratedByAdminLifespan)
+	setIfNotNil(&realm.RevokeRefreshToken, settings.RevokeRefreshToken)
+	setIfNotNil(&realm.RefreshTokenMaxReuse, settings.RefreshTokenMaxReuse)
+}
+
+func setRealmSessions(realm *gocloak.RealmRepresentation, sessions *RealmSessions) {
+	setIfNotNil(&realm.SsoSessionIdleTimeout, sessions.SSOSessionIdleTimeout)
+	setIfNotNil(&realm.SsoSessionMaxLifespan, sessions.SSOSessionMaxLifespan)
+	setIfNotNil(&realm.SsoSessionIdleTimeoutRememberMe, sessions.SSOSessionIdleTimeoutRememberMe)
+	setIfNotNil(&realm.SsoSessionMaxLifespanRememberMe, sessions.SSOSessionMaxLifespanRememberMe)
+	setIfNotNil(&realm.OfflineSessionIdleTimeout, sessions.OfflineSessionIdleTimeout)
+	setIfNotNil(&realm.OfflineSessionMaxLifespanEnabled, sessions.OfflineSessionMaxLifespanEnabled)
+	setIfNotNil(&realm.OfflineSessionMaxLifespan, sessions.OfflineSessionMaxLifespan)
+
+	// gocloak RealmRepresentation doesn't have client session fields,
+	// Keycloak stores them as realm attributes.
+	clientSessions := map[string]*int{
+		"clientSessionIdleTimeout":        sessions.ClientSessionIdleTimeout,
+		"clientSessionMaxLifespan":        sessions.ClientSessionMaxLifespan,
+		"clientOfflineSessionIdleTimeout": sessions.ClientOfflineSessionIdleTimeout,
+		"clientOfflineSessionMaxLifespan": sessions.ClientOfflineSessionMaxLifespan,
+	}
+
+	for attr, value := range clientSessions {
+		if value == nil {
+			continue
+		}
+
+		if realm.Attributes == nil {
+			realm.Attributes = &map[string]string{}
+		}
+
+		(*realm.Attributes)[attr] = strconv.Itoa(*value)
+	}
+}
+
+// setIfNotNil sets dst to src only if src is not nil.
+func setIfNotNil[T any](dst **T, src *T) {
+	if src != nil {
+		*dst = src
+	}
+}
+
+// SMTPServer contains SMTP server settings of the realm.
+type SMTPServer struct {
+	Host            string
+	Port            int
+	From            string
+	FromDisplayName string
+	ReplyTo         string
+	EnvelopeFrom    string
+	SSL             bool
+	StartTLS        bool
+	User            string
+	Password        string
+}
+
+// UpdateRealmSMTPServer replaces SMTP server settings of the realm.
+func (a GoCloakAdapter) UpdateRealmSMTPServer(ctx context.Context, realmName string, smtp *SMTPServer) error {
+	realm, err := a.client.GetRealm(ctx, a.token.AccessToken, realmName)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get realm: %s", realmName)
+	}
+
+	realm.SMTPServer = smtp.toRepresentation()
+
+	if err := a.client.UpdateRealm(ctx, a.token.AccessToken, *realm); err != nil {
+		return errors.Wrap(err, "unable to update realm smtp server")
+	}
+
+	return nil
+}
+
+func (s *SMTPServer) toRepresentation() *map[string]string {
+	smtp := map[string]string{
+		"host":     s.Host,
+		"from":     s.From,
+		"ssl":      strconv.FormatBool(s.SSL),
+		"starttls": strconv.FormatBool(s.StartTLS),
+		"auth":     strconv.FormatBool(s.User != ""),
+	}
+
+	if s.Port != 0 {
+		smtp["port"] = strconv.Itoa(s.Port)
+	}
+
+	optional := map[string]string{
+		"fromDisplayName": s.FromDisplayName,
+		"replyTo":         s.ReplyTo,
+		"envelopeFrom":    s.EnvelopeFrom,
+		"user":            s.User,
+		"password":        s.Password,
+	}
+
+	for k, v := range optional {
+		if v != "" {
+			smtp[k] = v
+		}
+	}
+
+	return &smtp
 }
 
 func (a GoCloakAdapter) ExistRealm(realmName string) (bool, error) {
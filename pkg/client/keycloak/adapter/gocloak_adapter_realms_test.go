@@ -56,6 +56,153 @@ func TestGoCloakAdapter_UpdateRealmSettings(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGoCloakAdapter_UpdateRealmSettings_TokenSettingsAndSessions(t *testing.T) {
+	adapter, mockClient, _ := initAdapter()
+
+	settings := RealmSettings{
+		TokenSettings: &TokenSettings{
+			AccessTokenLifespan: gocloak.IntP(300),
+			RevokeRefreshToken:  gocloak.BoolP(true),
+		},
+		Sessions: &RealmSessions{
+			SSOSessionIdleTimeout:            gocloak.IntP(1800),
+			OfflineSessionMaxLifespanEnabled: gocloak.BoolP(true),
+			ClientSessionIdleTimeout:         gocloak.IntP(600),
+		},
+	}
+	realmName := "realm"
+
+	realm := gocloak.RealmRepresentation{
+		AccessTokenLifespan:   gocloak.IntP(60),
+		SsoSessionMaxLifespan: gocloak.IntP(36000),
+	}
+	mockClient.On("GetRealm", adapter.token.AccessToken, realmName).Return(&realm, nil)
+
+	updateRealm := gocloak.RealmRepresentation{
+		AccessTokenLifespan:              gocloak.IntP(300),
+		RevokeRefreshToken:               gocloak.BoolP(true),
+		SsoSessionIdleTimeout:            gocloak.IntP(1800),
+		SsoSessionMaxLifespan:            gocloak.IntP(36000),
+		OfflineSessionMaxLifespanEnabled: gocloak.BoolP(true),
+		Attributes: &map[string]string{
+			"clientSessionIdleTimeout": "600",
+		},
+	}
+	mockClient.On("UpdateRealm", updateRealm).Return(nil)
+
+	err := adapter.UpdateRealmSettings(realmName, &settings)
+	require.NoError(t, err)
+}
+
+func TestGoCloakAdapter_UpdateRealmSettings_Login(t *testing.T) {
+	adapter, mockClient, _ := initAdapter()
+
+	settings := RealmSettings{
+		Login: &RealmLogin{
+			RegistrationAllowed: gocloak.BoolP(true),
+			VerifyEmail:         gocloak.BoolP(false),
+		},
+	}
+	realmName := "realm"
+
+	realm := gocloak.RealmRepresentation{
+		VerifyEmail: gocloak.BoolP(true),
+		RememberMe:  gocloak.BoolP(true),
+	}
+	mockClient.On("GetRealm", adapter.token.AccessToken, realmName).Return(&realm, nil)
+
+	updateRealm := gocloak.RealmRepresentation{
+		RegistrationAllowed: gocloak.BoolP(true),
+		VerifyEmail:         gocloak.BoolP(false),
+		RememberMe:          gocloak.BoolP(true),
+	}
+	mockClient.On("UpdateRealm", updateRealm).Return(nil)
+
+	err := adapter.UpdateRealmSettings(realmName, &settings)
+	require.NoError(t, err)
+}
+
+func TestGoCloakAdapter_UpdateRealmSMTPServer(t *testing.T) {
+	adapter, mockClient, _ := initAdapter()
+
+	realmName := "realm"
+	realm := gocloak.RealmRepresentation{
+		Realm: gocloak.StringP(realmName),
+	}
+	mockClient.On("GetRealm", adapter.token.AccessToken, realmName).Return(&realm, nil)
+
+	updateRealm := gocloak.RealmRepresentation{
+		Realm: gocloak.StringP(realmName),
+		SMTPServer: &map[string]string{
+			"host":     "smtp.example.com",
+			"port":     "465",
+			"from":     "noreply@example.com",
+			"ssl":      "true",
+			"starttls": "false",
+			"auth":     "true",
+			"user":     "user",
+			"password": "secret",
+		},
+	}
+	mockClient.On("UpdateRealm", updateRealm).Return(nil)
+
+	err := adapter.UpdateRealmSMTPServer(context.Background(), realmName, &SMTPServer{
+		Host:     "smtp.example.com",
+		Port:     465,
+		From:     "noreply@example.com",
+		SSL:      true,
+		User:     "user",
+		Password: "secret",
+	})
+	require.NoError(t, err)
+}
+
+func TestGoCloakAdapter_UpdateRealmSettings_SecurityPolicies(t *testing.T) {
+	adapter, mockClient, _ := initAdapter()
+
+	settings := RealmSettings{
+		BruteForceProtection: &BruteForceProtection{
+			Enabled:          gocloak.BoolP(true),
+			MaxLoginFailures: gocloak.IntP(5),
+		},
+		OTPPolicy: &OTPPolicy{
+			Type:   gocloak.StringP("totp"),
+			Digits: gocloak.IntP(8),
+		},
+		WebAuthnPolicy: &WebAuthnPolicy{
+			RpEntityName:        gocloak.StringP("keycloak"),
+			SignatureAlgorithms: []string{"ES256"},
+		},
+	}
+	realmName := "realm"
+
+	driftedRealm := gocloak.RealmRepresentation{
+		BruteForceProtected: gocloak.BoolP(false),
+		FailureFactor:       gocloak.IntP(30),
+		OtpPolicyType:       gocloak.StringP("totp"),
+		OtpPolicyDigits:     gocloak.IntP(6),
+	}
+	mockClient.On("GetRealm", adapter.token.AccessToken, realmName).Return(&driftedRealm, nil).Once()
+
+	updateRealm := gocloak.RealmRepresentation{
+		BruteForceProtected:               gocloak.BoolP(true),
+		FailureFactor:                     gocloak.IntP(5),
+		OtpPolicyType:                     gocloak.StringP("totp"),
+		OtpPolicyDigits:                   gocloak.IntP(8),
+		WebAuthnPolicyRpEntityName:        gocloak.StringP("keycloak"),
+		WebAuthnPolicySignatureAlgorithms: &[]string{"ES256"},
+	}
+	mockClient.On("UpdateRealm", updateRealm).Return(nil).Once()
+
+	require.NoError(t, adapter.UpdateRealmSettings(realmName, &settings))
+
+	upToDateRealm := updateRealm
+	mockClient.On("GetRealm", adapter.token.AccessToken, realmName).Return(&upToDateRealm, nil).Once()
+
+	require.NoError(t, adapter.UpdateRealmSettings(realmName, &settings))
+	mockClient.AssertNumberOfCalls(t, "UpdateRealm", 1)
+}
+
 func TestGoCloakAdapter_SyncRealmIdentityProviderMappers(t *testing.T) {
 	adapter, mockClient, restyClient := initAdapter()
 	httpmock.ActivateNonDefault(restyClient.GetClient())
@@ -144,3 +291,27 @@ func TestGoCloakAdapter_DeleteRealm(t *testing.T) {
 		t.Fatalf("wrong error returned: %s", err.Error())
 	}
 }
+
+func TestGoCloakAdapter_UpdateRealmSettings_Localization(t *testing.T) {
+	adapter, mockClient, _ := initAdapter()
+
+	settings := RealmSettings{
+		Localization: &RealmLocalization{
+			SupportedLocales: []string{"en", "de"},
+			DefaultLocale:    "en",
+		},
+	}
+	realmName := "realm"
+
+	mockClient.On("GetRealm", adapter.token.AccessToken, realmName).Return(&gocloak.RealmRepresentation{}, nil)
+
+	updateRealm := gocloak.RealmRepresentation{
+		InternationalizationEnabled: gocloak.BoolP(true),
+		SupportedLocales:            &[]string{"en", "de"},
+		DefaultLocale:               gocloak.StringP("en"),
+	}
+	mockClient.On("UpdateRealm", updateRealm).Return(nil)
+
+	err := adapter.UpdateRealmSettings(realmName, &settings)
+	require.NoError(t, err)
+}
@@ -0,0 +1,178 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/pkg/errors"
+)
+
+// RequiredAction contains settings of the realm required action.
+type RequiredAction struct {
+	Alias         string
+	Name          string
+	ProviderID    string
+	Enabled       bool
+	DefaultAction bool
+	Priority      *int
+	Config        map[string]string
+}
+
+type unregisteredRequiredAction struct {
+	ProviderID string `json:"providerId"`
+	Name       string `json:"name"`
+}
+
+// SyncRealmRequiredActions configures required actions of the realm.
+// Actions which are not registered in the realm are registered from the unregistered provider actions.
+// Required actions which are not in actions are left unchanged.
+func (a GoCloakAdapter) SyncRealmRequiredActions(ctx context.Context, realmName string, actions []RequiredAction) error {
+	current, err := a.getRequiredActions(ctx, realmName)
+	if err != nil {
+		return err
+	}
+
+	registered := false
+
+	for i := range actions {
+		if findRequiredAction(current, &actions[i]) != nil {
+			continue
+		}
+
+		if err = a.registerRequiredAction(ctx, realmName, &actions[i]); err != nil {
+			return err
+		}
+
+		registered = true
+	}
+
+	if registered {
+		if current, err = a.getRequiredActions(ctx, realmName); err != nil {
+			return err
+		}
+	}
+
+	for i := range actions {
+		action := findRequiredAction(current, &actions[i])
+		if action == nil {
+			return fmt.Errorf("required action %s is not found after registration", actions[i].Alias)
+		}
+
+		updated := mergeRequiredAction(action, &actions[i])
+		if reflect.DeepEqual(action, updated) {
+			continue
+		}
+
+		if err = a.updateRequiredAction(ctx, realmName, updated); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findRequiredAction returns the registered required action by alias.
+// Keycloak registers provider actions with alias equal to the provider ID,
+// so if the provider ID is set, the action is also looked up by it.
+func findRequiredAction(registered []*gocloak.RequiredActionProviderRepresentation,
+	action *RequiredAction,
+) *gocloak.RequiredActionProviderRepresentation {
+	for _, r := range registered {
+		if r.Alias != nil && *r.Alias == action.Alias {
+			return r
+		}
+	}
+
+	if action.ProviderID == "" {
+		return nil
+	}
+
+	for _, r := range registered {
+		if r.ProviderID != nil && *r.ProviderID == action.ProviderID {
+			return r
+		}
+	}
+
+	return nil
+}
+
+func mergeRequiredAction(current *gocloak.RequiredActionProviderRepresentation, action *RequiredAction) *gocloak.RequiredActionProviderRepresentation {
+	updated := *current
+	updated.Enabled = gocloak.BoolP(action.Enabled)
+	updated.DefaultAction = gocloak.BoolP(action.DefaultAction)
+
+	if action.Name != "" {
+		updated.Name = gocloak.StringP(action.Name)
+	}
+
+	if action.Priority != nil {
+		updated.Priority = gocloak.Int32P(int32(*action.Priority))
+	}
+
+	if action.Config != nil {
+		config := action.Config
+		updated.Config = &config
+	}
+
+	return &updated
+}
+
+func (a GoCloakAdapter) getRequiredActions(ctx context.Context, realmName string) ([]*gocloak.RequiredActionProviderRepresentation, error) {
+	var actions []*gocloak.RequiredActionProviderRepresentation
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+	}).SetResult(&actions).Get(a.buildPath(requiredActions))
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, errors.Wrap(err, "unable to get required actions")
+	}
+
+	return actions, nil
+}
+
+func (a GoCloakAdapter) registerRequiredAction(ctx context.Context, realmName string, action *RequiredAction) error {
+	var unregistered []unregisteredRequiredAction
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+	}).SetResult(&unregistered).Get(a.buildPath(unregisteredRequiredActions))
+	if err = a.checkError(err, rsp); err != nil {
+		return errors.Wrap(err, "unable to get unregistered required actions")
+	}
+
+	providerID := action.ProviderID
+	if providerID == "" {
+		providerID = action.Alias
+	}
+
+	for _, provider := range unregistered {
+		if provider.ProviderID != providerID {
+			continue
+		}
+
+		rsp, err = a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+			keycloakApiParamRealm: realmName,
+		}).SetBody(provider).Post(a.buildPath(registerRequiredAction))
+		if err = a.checkError(err, rsp); err != nil {
+			return errors.Wrapf(err, "unable to register required action %s", providerID)
+		}
+
+		return nil
+	}
+
+	return NotFoundError(fmt.Sprintf("required action provider %s is not found", providerID))
+}
+
+func (a GoCloakAdapter) updateRequiredAction(ctx context.Context, realmName string, action *gocloak.RequiredActionProviderRepresentation) error {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+		keycloakApiParamAlias: *action.Alias,
+	}).SetBody(action).Put(a.buildPath(requiredAction))
+	if err = a.checkError(err, rsp); err != nil {
+		return errors.Wrapf(err, "unable to update required action %s", *action.Alias)
+	}
+
+	return nil
+}
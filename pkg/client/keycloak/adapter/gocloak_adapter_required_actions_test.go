@@ -0,0 +1,107 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoCloakAdapter_SyncRealmRequiredActions(t *testing.T) {
+	a, _, _ := initAdapter()
+
+	registered := false
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/authentication/required-actions",
+		func(req *http.Request) (*http.Response, error) {
+			actions := []gocloak.RequiredActionProviderRepresentation{
+				{
+					Alias:         gocloak.StringP("CONFIGURE_TOTP"),
+					Name:          gocloak.StringP("Configure OTP"),
+					Enabled:       gocloak.BoolP(true),
+					DefaultAction: gocloak.BoolP(false),
+					Priority:      gocloak.Int32P(10),
+				},
+				{
+					Alias:         gocloak.StringP("UPDATE_PASSWORD"),
+					Enabled:       gocloak.BoolP(true),
+					DefaultAction: gocloak.BoolP(false),
+					Priority:      gocloak.Int32P(30),
+				},
+			}
+
+			if registered {
+				actions = append(actions, gocloak.RequiredActionProviderRepresentation{
+					Alias:         gocloak.StringP("custom-action"),
+					ProviderID:    gocloak.StringP("custom-action"),
+					Enabled:       gocloak.BoolP(true),
+					DefaultAction: gocloak.BoolP(false),
+					Priority:      gocloak.Int32P(100),
+				})
+			}
+
+			return httpmock.NewJsonResponse(http.StatusOK, actions)
+		})
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/authentication/unregistered-required-actions",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, []unregisteredRequiredAction{
+			{ProviderID: "custom-action", Name: "Custom action"},
+		}))
+	httpmock.RegisterResponder(http.MethodPost, "/admin/realms/realm1/authentication/register-required-action",
+		func(req *http.Request) (*http.Response, error) {
+			registered = true
+
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	var updated []gocloak.RequiredActionProviderRepresentation
+
+	httpmock.RegisterResponder(http.MethodPut, `=~^/admin/realms/realm1/authentication/required-actions/.+`,
+		func(req *http.Request) (*http.Response, error) {
+			var action gocloak.RequiredActionProviderRepresentation
+			if err := json.NewDecoder(req.Body).Decode(&action); err != nil {
+				return nil, err
+			}
+
+			updated = append(updated, action)
+
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	priority := 5
+
+	err := a.SyncRealmRequiredActions(context.Background(), "realm1", []RequiredAction{
+		{Alias: "CONFIGURE_TOTP", Enabled: true, DefaultAction: true, Priority: &priority},
+		{Alias: "UPDATE_PASSWORD", Enabled: true},
+		{Alias: "custom-action", Enabled: false},
+	})
+	require.NoError(t, err)
+	assert.True(t, registered)
+
+	require.Len(t, updated, 2, "unchanged UPDATE_PASSWORD must not be updated")
+	assert.Equal(t, "CONFIGURE_TOTP", *updated[0].Alias)
+	assert.Equal(t, "Configure OTP", *updated[0].Name)
+	assert.True(t, *updated[0].DefaultAction)
+	assert.Equal(t, int32(5), *updated[0].Priority)
+	assert.Equal(t, "custom-action", *updated[1].Alias)
+	assert.False(t, *updated[1].Enabled)
+
+	updated = nil
+
+	err = a.SyncRealmRequiredActions(context.Background(), "realm1", []RequiredAction{
+		{Alias: "custom", ProviderID: "custom-action", Enabled: false},
+	})
+	require.NoError(t, err, "action registered with provider ID as alias must be found")
+	require.Len(t, updated, 1)
+	assert.Equal(t, "custom-action", *updated[0].Alias)
+
+	err = a.SyncRealmRequiredActions(context.Background(), "realm1", []RequiredAction{
+		{Alias: "missing", Enabled: true},
+	})
+	require.Error(t, err)
+	assert.True(t, IsErrNotFound(err))
+}
@@ -0,0 +1,95 @@
+package adapter
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	defaultRetryCount       = 3
+	defaultRetryWaitTime    = 500 * time.Millisecond
+	defaultRetryMaxWaitTime = 10 * time.Second
+
+	retryAfterHeader = "Retry-After"
+)
+
+// RetryPolicy defines how failed requests to Keycloak are retried.
+type RetryPolicy struct {
+	// MaxRetries is a maximum number of retries. Zero disables retries.
+	MaxRetries int
+	// WaitTime is an initial wait time between retries. It grows exponentially with jitter.
+	WaitTime time.Duration
+	// MaxWaitTime caps the wait time between retries, including the one requested by Retry-After header.
+	MaxWaitTime time.Duration
+}
+
+// DefaultRetryPolicy returns retry policy which is used when it is not configured in Keycloak CR.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:  defaultRetryCount,
+		WaitTime:    defaultRetryWaitTime,
+		MaxWaitTime: defaultRetryMaxWaitTime,
+	}
+}
+
+// ConfigureRetry enables retries with exponential backoff on the resty client.
+// The same resty client is used by gocloak client, so the policy is applied to all adapter requests.
+// Only idempotent GET, PUT and DELETE requests are retried on connection errors,
+// 429 Too Many Requests and 5xx responses. Retry-After header is honored.
+func ConfigureRetry(restyClient *resty.Client, policy RetryPolicy) *resty.Client {
+	return restyClient.
+		SetRetryCount(policy.MaxRetries).
+		SetRetryWaitTime(policy.WaitTime).
+		SetRetryMaxWaitTime(policy.MaxWaitTime).
+		SetRetryAfter(retryAfter).
+		AddRetryCondition(isRetryable)
+}
+
+func isRetryable(rsp *resty.Response, err error) bool {
+	if rsp == nil || rsp.Request == nil {
+		return false
+	}
+
+	switch rsp.Request.Method {
+	case http.MethodGet, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	status := rsp.StatusCode()
+
+	return status == http.StatusTooManyRequests ||
+		(status >= http.StatusInternalServerError && status != http.StatusNotImplemented)
+}
+
+// retryAfter returns wait time requested by Retry-After header.
+// Zero duration means that the default backoff should be used.
+func retryAfter(_ *resty.Client, rsp *resty.Response) (time.Duration, error) {
+	value := rsp.Header().Get(retryAfterHeader)
+	if value == "" {
+		return 0, nil
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0, nil
+		}
+
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait, nil
+		}
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,124 @@
+package adapter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureRetry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		method    string
+		responses []int
+		header    string
+		wantCalls int32
+		wantCode  int
+	}{
+		{
+			name:      "GET is retried on 502",
+			method:    http.MethodGet,
+			responses: []int{http.StatusBadGateway, http.StatusOK},
+			wantCalls: 2,
+			wantCode:  http.StatusOK,
+		},
+		{
+			name:      "PUT is retried on 429 with Retry-After",
+			method:    http.MethodPut,
+			responses: []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusNoContent},
+			header:    "1",
+			wantCalls: 3,
+			wantCode:  http.StatusNoContent,
+		},
+		{
+			name:      "DELETE gives up after max retries",
+			method:    http.MethodDelete,
+			responses: []int{http.StatusServiceUnavailable},
+			wantCalls: 3,
+			wantCode:  http.StatusServiceUnavailable,
+		},
+		{
+			name:      "POST is not retried",
+			method:    http.MethodPost,
+			responses: []int{http.StatusServiceUnavailable, http.StatusCreated},
+			wantCalls: 1,
+			wantCode:  http.StatusServiceUnavailable,
+		},
+		{
+			name:      "client error is not retried",
+			method:    http.MethodGet,
+			responses: []int{http.StatusNotFound, http.StatusOK},
+			wantCalls: 1,
+			wantCode:  http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&calls, 1)
+				code := tt.responses[len(tt.responses)-1]
+
+				if int(n) <= len(tt.responses) {
+					code = tt.responses[n-1]
+				}
+
+				if tt.header != "" {
+					w.Header().Set(retryAfterHeader, tt.header)
+				}
+
+				w.WriteHeader(code)
+			}))
+			defer server.Close()
+
+			cl := ConfigureRetry(resty.New(), RetryPolicy{
+				MaxRetries:  2,
+				WaitTime:    time.Millisecond,
+				MaxWaitTime: 10 * time.Millisecond,
+			})
+
+			rsp, err := cl.R().Execute(tt.method, server.URL)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCode, rsp.StatusCode())
+			assert.Equal(t, tt.wantCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	rsp := &resty.Response{RawResponse: &http.Response{Header: http.Header{}}}
+
+	wait, err := retryAfter(nil, rsp)
+	require.NoError(t, err)
+	assert.Zero(t, wait)
+
+	rsp.RawResponse.Header.Set(retryAfterHeader, "5")
+	wait, err = retryAfter(nil, rsp)
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, wait)
+
+	rsp.RawResponse.Header.Set(retryAfterHeader, time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	wait, err = retryAfter(nil, rsp)
+	require.NoError(t, err)
+	assert.InDelta(t, time.Hour, wait, float64(time.Minute))
+
+	rsp.RawResponse.Header.Set(retryAfterHeader, "invalid")
+	wait, err = retryAfter(nil, rsp)
+	require.NoError(t, err)
+	assert.Zero(t, wait)
+}
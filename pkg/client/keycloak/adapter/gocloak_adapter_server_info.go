@@ -0,0 +1,164 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	serverInfo = "/admin/serverinfo"
+
+	basePathDefault = "/"
+)
+
+// ServerInfo contains information about Keycloak server.
+type ServerInfo struct {
+	// Version of the Keycloak server.
+	Version string
+	// BasePath is a path where Keycloak is served, e.g. /auth for legacy WildFly distributions.
+	// It is taken from the URL which answered the server info request.
+	BasePath string
+	// Features is a sorted list of enabled features.
+	// Keycloak versions before 22 report only enabled preview and experimental features.
+	Features []string
+}
+
+type serverInfoRepresentation struct {
+	SystemInfo struct {
+		Version string `json:"version"`
+	} `json:"systemInfo"`
+	// Features are returned by Keycloak 22+.
+	Features []struct {
+		Name    string `json:"name"`
+		Enabled bool   `json:"enabled"`
+	} `json:"features"`
+	// ProfileInfo is used by older Keycloak versions.
+	ProfileInfo struct {
+		DisabledFeatures     []string `json:"disabledFeatures"`
+		PreviewFeatures      []string `json:"previewFeatures"`
+		ExperimentalFeatures []string `json:"experimentalFeatures"`
+	} `json:"profileInfo"`
+}
+
+// GetServerInfo returns Keycloak server version, base path and enabled features.
+// If the server info is not found in the current client mode, the path of the other mode is tried.
+// The base path is taken from the URL which answered the request.
+func (a GoCloakAdapter) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	info, rsp, err := a.getServerInfo(ctx, a.buildPath(serverInfo))
+	if err != nil && rsp != nil && rsp.StatusCode() == http.StatusNotFound {
+		other := a
+		other.legacyMode = !a.legacyMode
+
+		var otherErr error
+		if info, rsp, otherErr = a.getServerInfo(ctx, other.buildPath(serverInfo)); otherErr == nil {
+			err = nil
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServerInfo{
+		Version:  info.SystemInfo.Version,
+		BasePath: servedBasePath(rsp),
+		Features: info.enabledFeatures(),
+	}, nil
+}
+
+func (a GoCloakAdapter) getServerInfo(ctx context.Context, path string) (*serverInfoRepresentation, *resty.Response, error) {
+	var info serverInfoRepresentation
+
+	rsp, err := a.startRestyRequest().
+		SetContext(ctx).
+		SetResult(&info).
+		Get(path)
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, rsp, fmt.Errorf("unable to get server info: %w", err)
+	}
+
+	return &info, rsp, nil
+}
+
+// servedBasePath returns the path Keycloak is served at, based on the URL which answered the server info request.
+func servedBasePath(rsp *resty.Response) string {
+	var requestURL *url.URL
+
+	if rsp.RawResponse != nil && rsp.RawResponse.Request != nil {
+		requestURL = rsp.RawResponse.Request.URL
+	} else if parsed, err := url.Parse(rsp.Request.URL); err == nil {
+		requestURL = parsed
+	}
+
+	if requestURL == nil {
+		return basePathDefault
+	}
+
+	basePath := strings.TrimRight(strings.TrimSuffix(requestURL.Path, serverInfo), "/")
+	if basePath == "" {
+		return basePathDefault
+	}
+
+	return basePath
+}
+
+// legacyModeForPath reports whether Keycloak served at servedPath requires the legacy client mode.
+// The second return value is false if the served path is unknown or doesn't correspond to the Keycloak URL.
+func legacyModeForPath(keycloakURL, servedPath string) (legacyMode, ok bool) {
+	if servedPath == "" {
+		return false, false
+	}
+
+	parsed, err := url.Parse(keycloakURL)
+	if err != nil {
+		return false, false
+	}
+
+	urlPath := strings.TrimRight(parsed.Path, "/")
+
+	switch strings.TrimRight(servedPath, "/") {
+	case urlPath:
+		return false, true
+	case urlPath + "/auth":
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+func (info *serverInfoRepresentation) enabledFeatures() []string {
+	features := make([]string, 0)
+
+	if len(info.Features) > 0 {
+		for _, f := range info.Features {
+			if f.Enabled {
+				features = append(features, f.Name)
+			}
+		}
+
+		sort.Strings(features)
+
+		return features
+	}
+
+	disabled := make(map[string]struct{}, len(info.ProfileInfo.DisabledFeatures))
+	for _, f := range info.ProfileInfo.DisabledFeatures {
+		disabled[f] = struct{}{}
+	}
+
+	for _, f := range append(info.ProfileInfo.PreviewFeatures, info.ProfileInfo.ExperimentalFeatures...) {
+		if _, ok := disabled[f]; !ok {
+			features = append(features, f)
+		}
+	}
+
+	sort.Strings(features)
+
+	return features
+}
@@ -0,0 +1,161 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoCloakAdapter_GetServerInfo(t *testing.T) {
+	tests := []struct {
+		name       string
+		legacyMode bool
+		baseURL    string
+		// notFoundPath responds with 404, e.g. because the path of the other client mode is used.
+		notFoundPath string
+		path         string
+		response     string
+		status       int
+		want         *ServerInfo
+		wantErr      require.ErrorAssertionFunc
+	}{
+		{
+			name:   "keycloak 22+ features",
+			path:   "/admin/serverinfo",
+			status: http.StatusOK,
+			response: `{"systemInfo":{"version":"22.0.5"},"features":[
+				{"name":"token-exchange","enabled":true},
+				{"name":"admin-fine-grained-authz","enabled":false},
+				{"name":"account-api","enabled":true}]}`,
+			want: &ServerInfo{
+				Version:  "22.0.5",
+				BasePath: "/",
+				Features: []string{"account-api", "token-exchange"},
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:       "legacy profile info",
+			legacyMode: true,
+			path:       "/auth/admin/serverinfo",
+			status:     http.StatusOK,
+			response: `{"systemInfo":{"version":"18.0.2"},"profileInfo":{
+				"disabledFeatures":["scripts","token-exchange"],
+				"previewFeatures":["scripts","token-exchange","declarative-user-profile"],
+				"experimentalFeatures":["client-secret-rotation"]}}`,
+			want: &ServerInfo{
+				Version:  "18.0.2",
+				BasePath: "/auth",
+				Features: []string{"client-secret-rotation", "declarative-user-profile"},
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:         "served under /auth in modern client mode",
+			notFoundPath: "/admin/serverinfo",
+			path:         "/auth/admin/serverinfo",
+			status:       http.StatusOK,
+			response:     `{"systemInfo":{"version":"17.0.1"}}`,
+			want: &ServerInfo{
+				Version:  "17.0.1",
+				BasePath: "/auth",
+				Features: []string{},
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:         "served at root in legacy client mode",
+			legacyMode:   true,
+			notFoundPath: "/auth/admin/serverinfo",
+			path:         "/admin/serverinfo",
+			status:       http.StatusOK,
+			response:     `{"systemInfo":{"version":"21.1.2"}}`,
+			want: &ServerInfo{
+				Version:  "21.1.2",
+				BasePath: "/",
+				Features: []string{},
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:     "context path is a part of url",
+			baseURL:  "https://keycloak.example.com/sso",
+			path:     "https://keycloak.example.com/sso/admin/serverinfo",
+			status:   http.StatusOK,
+			response: `{"systemInfo":{"version":"22.0.5"}}`,
+			want: &ServerInfo{
+				Version:  "22.0.5",
+				BasePath: "/sso",
+				Features: []string{},
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:         "not found in both client modes",
+			notFoundPath: "/admin/serverinfo",
+			path:         "/auth/admin/serverinfo",
+			status:       http.StatusNotFound,
+			response:     `{"error":"not found"}`,
+			wantErr:      require.Error,
+		},
+		{
+			name:     "forbidden",
+			path:     "/admin/serverinfo",
+			status:   http.StatusForbidden,
+			response: `{"error":"forbidden"}`,
+			wantErr:  require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			a, _, _ := initAdapter()
+			a.legacyMode = tt.legacyMode
+			a.basePath = tt.baseURL
+
+			if tt.notFoundPath != "" {
+				httpmock.RegisterResponder(http.MethodGet, tt.notFoundPath,
+					httpmock.NewStringResponder(http.StatusNotFound, "not found"))
+			}
+
+			httpmock.RegisterResponder(http.MethodGet, tt.path,
+				httpmock.NewJsonResponderOrPanic(tt.status, json.RawMessage(tt.response)))
+
+			got, err := a.GetServerInfo(context.Background())
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLegacyModeForPath(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		servedPath string
+		wantLegacy bool
+		wantOK     bool
+	}{
+		{name: "unknown", url: "https://keycloak", servedPath: "", wantOK: false},
+		{name: "current", url: "https://keycloak", servedPath: "/", wantLegacy: false, wantOK: true},
+		{name: "legacy", url: "https://keycloak", servedPath: "/auth", wantLegacy: true, wantOK: true},
+		{name: "current with url path", url: "https://host/keycloak/", servedPath: "/keycloak", wantLegacy: false, wantOK: true},
+		{name: "legacy with url path", url: "https://host/keycloak", servedPath: "/keycloak/auth", wantLegacy: true, wantOK: true},
+		{name: "path of another url", url: "https://host/other", servedPath: "/keycloak/auth", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			legacy, ok := legacyModeForPath(tt.url, tt.servedPath)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantLegacy, legacy)
+		})
+	}
+}
@@ -66,6 +66,7 @@ func (e *AdapterTestSuite) TestMakeFromServiceAccount() {
 	tests := []struct {
 		name       string
 		mockServer fakehttp.Server
+		servedPath string
 		wantErr    require.ErrorAssertionFunc
 	}{
 		{
@@ -82,6 +83,24 @@ func (e *AdapterTestSuite) TestMakeFromServiceAccount() {
 				BuildAndStart(),
 			wantErr: require.NoError,
 		},
+		{
+			name: "should succeed with known legacy path",
+			mockServer: fakehttp.NewServerBuilder().
+				AddStringResponder("/auth"+realmsEndpoint, "{}").
+				BuildAndStart(),
+			servedPath: "/auth",
+			wantErr:    require.NoError,
+		},
+		{
+			name: "should not fall back to legacy endpoint if current path is known",
+			mockServer: fakehttp.NewServerBuilder().
+				AddStringResponder("/auth"+realmsEndpoint, "{}").
+				BuildAndStart(),
+			servedPath: "/",
+			wantErr: func(t require.TestingT, err error, _ ...interface{}) {
+				require.ErrorContains(t, err, "404")
+			},
+		},
 		{
 			name: "should fail on status bad request",
 			mockServer: fakehttp.NewServerBuilder().
@@ -89,7 +108,7 @@ func (e *AdapterTestSuite) TestMakeFromServiceAccount() {
 				BuildAndStart(),
 			wantErr: func(t require.TestingT, err error, _ ...interface{}) {
 				require.Error(t, err)
-				require.EqualError(t, err, "failed to login with client creds on both current and legacy clients - clientID: k-cl-id, realm: master: 400 Bad Request")
+				require.EqualError(t, err, "failed to login with client creds - clientID: k-cl-id, realm: master: failed to login using both current and legacy clients: 400 Bad Request")
 			},
 		},
 	}
@@ -102,7 +121,7 @@ func (e *AdapterTestSuite) TestMakeFromServiceAccount() {
 
 			defer tt.mockServer.Close()
 
-			_, err := MakeFromServiceAccount(context.Background(), tt.mockServer.GetURL(),
+			_, err := MakeFromServiceAccount(context.Background(), tt.mockServer.GetURL(), tt.servedPath,
 				"k-cl-id", "k-secret", "master", mock.NewLogr(), resty.New())
 			tt.wantErr(t, err)
 		})
@@ -168,7 +187,7 @@ func (e *AdapterTestSuite) TestMake() {
 				defer tt.mockServer.Close()
 			}
 
-			_, err := Make(context.Background(), url, "bar", "baz", mock.NewLogr(), resty.New())
+			_, err := Make(context.Background(), url, "", "bar", "baz", "master", mock.NewLogr(), resty.New())
 			tt.wantErr(t, err)
 		})
 	}
@@ -945,7 +964,7 @@ func TestMakeFromToken(t *testing.T) {
 				defer tt.mockServer.Close()
 			}
 
-			cl, err := MakeFromToken(url, token, mock.NewLogr())
+			cl, err := MakeFromToken(url, "", token, TokenCredentials{}, mock.NewLogr(), nil)
 			tt.wantErr(t, err, cl)
 		})
 	}
@@ -954,7 +973,7 @@ func TestMakeFromToken(t *testing.T) {
 func TestMakeFromToken_invalidJSON(t *testing.T) {
 	t.Parallel()
 
-	_, err := MakeFromToken("test_url", []byte("qwdqwdwq"), mock.NewLogr())
+	_, err := MakeFromToken("test_url", "", []byte("qwdqwdwq"), TokenCredentials{}, mock.NewLogr(), nil)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "invalid character")
 }
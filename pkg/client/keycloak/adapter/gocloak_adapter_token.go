@@ -0,0 +1,99 @@
+package adapter
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/pkg/errors"
+)
+
+const (
+	adminCliClientID = "admin-cli"
+	masterRealm      = "master"
+)
+
+// TokenExpiresAt returns expiration time of the admin access token.
+func (a GoCloakAdapter) TokenExpiresAt() (time.Time, error) {
+	if a.token == nil {
+		return time.Time{}, errors.New("token is not set")
+	}
+
+	return parseTokenExpiration(a.token.AccessToken)
+}
+
+// RefreshToken returns a copy of the adapter with the admin token refreshed using the refresh_token grant.
+// The original adapter is left untouched, so it can be safely used concurrently.
+func (a GoCloakAdapter) RefreshToken(ctx context.Context) (*GoCloakAdapter, error) {
+	if a.token == nil || a.token.RefreshToken == "" {
+		return nil, errors.New("refresh token is not available")
+	}
+
+	var (
+		token *gocloak.JWT
+		err   error
+	)
+
+	if a.signingKey != nil {
+		token, err = a.refreshTokenWithClientAssertion(ctx)
+	} else {
+		token, err = a.client.RefreshToken(ctx, a.token.RefreshToken, a.clientID, a.clientSecret, a.realm)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to refresh token: %w", err)
+	}
+
+	refreshed := a
+	refreshed.token = token
+
+	return &refreshed, nil
+}
+
+// refreshTokenWithClientAssertion refreshes the admin token authenticating the client with signed JWT.
+func (a GoCloakAdapter) refreshTokenWithClientAssertion(ctx context.Context) (*gocloak.JWT, error) {
+	assertion, err := signClientAssertion(realmTokenURL(a.basePath, a.realm, a.legacyMode), a.clientID,
+		a.signingMethod, a.signingKey)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := a.client.GetToken(ctx, a.realm, gocloak.TokenOptions{
+		ClientID:            &a.clientID,
+		GrantType:           gocloak.StringP("refresh_token"),
+		RefreshToken:        &a.token.RefreshToken,
+		ClientAssertionType: gocloak.StringP(clientAssertionTypeJWTBearer),
+		ClientAssertion:     &assertion,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get token: %w", err)
+	}
+
+	return token, nil
+}
+
+func parseTokenExpiration(accessToken string) (time.Time, error) {
+	const requiredTokenParts = 3
+
+	tokenParts := strings.Split(accessToken, ".")
+
+	if len(tokenParts) < requiredTokenParts {
+		return time.Time{}, errors.New("wrong JWT token structure")
+	}
+
+	tokenPayload, err := base64.RawURLEncoding.DecodeString(tokenParts[1])
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "wrong JWT token base64 encoding")
+	}
+
+	var tokenPayloadDecoded JWTPayload
+	if err = json.Unmarshal(tokenPayload, &tokenPayloadDecoded); err != nil {
+		return time.Time{}, errors.Wrap(err, "unable to decode JWT payload json")
+	}
+
+	return time.Unix(tokenPayloadDecoded.Exp, 0), nil
+}
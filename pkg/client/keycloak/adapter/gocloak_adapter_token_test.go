@@ -0,0 +1,181 @@
+package adapter
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func makeTestAccessToken(t *testing.T, exp time.Time) string {
+	t.Helper()
+
+	payload, err := json.Marshal(JWTPayload{Exp: exp.Unix()})
+	require.NoError(t, err)
+
+	return "header." + base64.RawURLEncoding.EncodeToString(payload) + ".signature"
+}
+
+func TestGoCloakAdapter_TokenExpiresAt(t *testing.T) {
+	t.Parallel()
+
+	exp := time.Now().Add(time.Minute).Truncate(time.Second)
+
+	a := GoCloakAdapter{token: &gocloak.JWT{AccessToken: makeTestAccessToken(t, exp)}}
+
+	expiresAt, err := a.TokenExpiresAt()
+	require.NoError(t, err)
+	assert.True(t, exp.Equal(expiresAt))
+
+	_, err = GoCloakAdapter{}.TokenExpiresAt()
+	require.Error(t, err)
+
+	_, err = GoCloakAdapter{token: &gocloak.JWT{AccessToken: "foo.bar"}}.TokenExpiresAt()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "wrong JWT token structure")
+}
+
+func TestGoCloakAdapter_RefreshToken(t *testing.T) {
+	t.Parallel()
+
+	mockClient := new(MockGoCloakClient)
+	newToken := &gocloak.JWT{AccessToken: "new", RefreshToken: "new-refresh"}
+
+	mockClient.On("RefreshToken", "refresh", adminCliClientID, "", masterRealm).Return(newToken, nil).Once()
+
+	a := GoCloakAdapter{
+		client:   mockClient,
+		token:    &gocloak.JWT{AccessToken: "old", RefreshToken: "refresh"},
+		clientID: adminCliClientID,
+		realm:    masterRealm,
+	}
+
+	refreshed, err := a.RefreshToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, newToken, refreshed.token)
+	assert.Equal(t, "old", a.token.AccessToken)
+
+	mockClient.On("RefreshToken", "new-refresh", adminCliClientID, "", masterRealm).
+		Return(nil, errors.New("invalid grant")).Once()
+
+	_, err = refreshed.RefreshToken(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid grant")
+
+	_, err = GoCloakAdapter{token: &gocloak.JWT{AccessToken: "token"}}.RefreshToken(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "refresh token is not available")
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestMakeFromToken_RefreshToken(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+
+	tests := []struct {
+		name      string
+		creds     TokenCredentials
+		checkForm func(t *testing.T, serverURL string, r *http.Request)
+	}{
+		{
+			name:  "admin user in non-master realm",
+			creds: TokenCredentials{Realm: "admins"},
+			checkForm: func(t *testing.T, serverURL string, r *http.Request) {
+				assert.Equal(t, adminCliClientID, r.Form.Get("client_id"))
+
+				_, _, ok := r.BasicAuth()
+				assert.False(t, ok)
+			},
+		},
+		{
+			name:  "service account",
+			creds: TokenCredentials{Realm: "admins", ClientID: "operator", ClientSecret: "secret"},
+			checkForm: func(t *testing.T, serverURL string, r *http.Request) {
+				user, password, ok := r.BasicAuth()
+				assert.True(t, ok)
+				assert.Equal(t, "operator", user)
+				assert.Equal(t, "secret", password)
+			},
+		},
+		{
+			name:  "private key JWT",
+			creds: TokenCredentials{Realm: "admins", ClientID: "operator", PrivateKey: keyPEM},
+			checkForm: func(t *testing.T, serverURL string, r *http.Request) {
+				form := r.Form
+				assert.Equal(t, "operator", form.Get("client_id"))
+				assert.Equal(t, clientAssertionTypeJWTBearer, form.Get("client_assertion_type"))
+
+				claims := jwt.RegisteredClaims{}
+				_, err := jwt.ParseWithClaims(form.Get("client_assertion"), &claims, func(token *jwt.Token) (interface{}, error) {
+					return &key.PublicKey, nil
+				})
+				assert.NoError(t, err)
+				assert.True(t, claims.VerifyAudience(serverURL+"/realms/admins/protocol/openid-connect/token", true))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			newAccessToken := makeTestAccessToken(t, time.Now().Add(time.Hour))
+
+			var server *httptest.Server
+
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch r.URL.Path {
+				case "/admin/realms", "/admin/realms/":
+					_, _ = w.Write([]byte(`[]`))
+				case "/realms/admins/protocol/openid-connect/token":
+					assert.NoError(t, r.ParseForm())
+					assert.Equal(t, "refresh_token", r.Form.Get("grant_type"))
+					assert.Equal(t, "refresh", r.Form.Get("refresh_token"))
+					tt.checkForm(t, server.URL, r)
+
+					_, _ = w.Write([]byte(`{"access_token":"` + newAccessToken + `"}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			token, err := json.Marshal(gocloak.JWT{
+				AccessToken:  makeTestAccessToken(t, time.Now().Add(time.Minute)),
+				RefreshToken: "refresh",
+			})
+			require.NoError(t, err)
+
+			a, err := MakeFromToken(server.URL, "", token, tt.creds, mock.NewLogr(), nil)
+			require.NoError(t, err)
+
+			refreshed, err := a.RefreshToken(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, newAccessToken, refreshed.token.AccessToken)
+		})
+	}
+}
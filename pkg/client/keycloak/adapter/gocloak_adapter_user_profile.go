@@ -0,0 +1,75 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// UserProfileConfig is a declarative user profile configuration of the realm.
+type UserProfileConfig struct {
+	Attributes               []UserProfileAttribute `json:"attributes"`
+	Groups                   []UserProfileGroup     `json:"groups,omitempty"`
+	UnmanagedAttributePolicy string                 `json:"unmanagedAttributePolicy,omitempty"`
+}
+
+// UserProfileAttribute is a user profile attribute.
+type UserProfileAttribute struct {
+	Name        string                            `json:"name"`
+	DisplayName string                            `json:"displayName,omitempty"`
+	Group       string                            `json:"group,omitempty"`
+	Multivalued bool                              `json:"multivalued,omitempty"`
+	Validations map[string]map[string]interface{} `json:"validations,omitempty"`
+	Annotations map[string]interface{}            `json:"annotations,omitempty"`
+	Required    *UserProfileAttributeRequired     `json:"required,omitempty"`
+	Permissions *UserProfileAttributePermissions  `json:"permissions,omitempty"`
+	Selector    *UserProfileAttributeSelector     `json:"selector,omitempty"`
+}
+
+type UserProfileAttributeRequired struct {
+	Roles  []string `json:"roles,omitempty"`
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+type UserProfileAttributePermissions struct {
+	View []string `json:"view,omitempty"`
+	Edit []string `json:"edit,omitempty"`
+}
+
+type UserProfileAttributeSelector struct {
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// UserProfileGroup is a user profile attribute group.
+type UserProfileGroup struct {
+	Name               string                 `json:"name"`
+	DisplayHeader      string                 `json:"displayHeader,omitempty"`
+	DisplayDescription string                 `json:"displayDescription,omitempty"`
+	Annotations        map[string]interface{} `json:"annotations,omitempty"`
+}
+
+// GetUserProfile returns user profile configuration of the realm.
+func (a GoCloakAdapter) GetUserProfile(ctx context.Context, realmName string) (*UserProfileConfig, error) {
+	profile := &UserProfileConfig{}
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+	}).SetResult(profile).Get(a.buildPath(realmUserProfile))
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, errors.Wrap(err, "unable to get user profile")
+	}
+
+	return profile, nil
+}
+
+// UpdateUserProfile replaces user profile configuration of the realm.
+func (a GoCloakAdapter) UpdateUserProfile(ctx context.Context, realmName string, profile *UserProfileConfig) error {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+	}).SetBody(profile).Put(a.buildPath(realmUserProfile))
+	if err = a.checkError(err, rsp); err != nil {
+		return errors.Wrap(err, "unable to update user profile")
+	}
+
+	return nil
+}
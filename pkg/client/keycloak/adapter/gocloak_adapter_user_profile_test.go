@@ -0,0 +1,55 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoCloakAdapter_UserProfile(t *testing.T) {
+	a, _, _ := initAdapter()
+
+	profile := &UserProfileConfig{
+		Attributes: []UserProfileAttribute{
+			{
+				Name:        "email",
+				Validations: map[string]map[string]interface{}{"email": {}},
+				Required:    &UserProfileAttributeRequired{Roles: []string{"user"}},
+			},
+		},
+		UnmanagedAttributePolicy: "ADMIN_EDIT",
+	}
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/users/profile",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, profile))
+
+	var updated UserProfileConfig
+
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm1/users/profile",
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&updated); err != nil {
+				return nil, err
+			}
+
+			return httpmock.NewJsonResponse(http.StatusOK, updated)
+		})
+
+	got, err := a.GetUserProfile(context.Background(), "realm1")
+	require.NoError(t, err)
+	assert.Equal(t, profile, got)
+
+	require.NoError(t, a.UpdateUserProfile(context.Background(), "realm1", profile))
+	assert.Equal(t, *profile, updated)
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm2/users/profile",
+		httpmock.NewStringResponder(http.StatusNotFound, "not found"))
+
+	_, err = a.GetUserProfile(context.Background(), "realm2")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to get user profile")
+}
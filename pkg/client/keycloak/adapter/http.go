@@ -3,4 +3,5 @@ package adapter
 const (
 	contentTypeHeader = "Content-Type"
 	contentTypeJson   = "application/json"
+	contentTypeText   = "text/plain"
 )
@@ -252,6 +252,69 @@ func (m *Mock) SetRealmEventConfig(realmName string, eventConfig *RealmEventConf
 	return m.Called(realmName, eventConfig).Error(0)
 }
 
+func (m *Mock) UpdateRealmSMTPServer(ctx context.Context, realmName string, smtp *SMTPServer) error {
+	return m.Called(realmName, smtp).Error(0)
+}
+
+func (m *Mock) SyncRealmLocalizationTexts(ctx context.Context, realmName, locale string, texts map[string]string, addOnly bool) error {
+	return m.Called(realmName, locale, texts, addOnly).Error(0)
+}
+
+func (m *Mock) DeleteRealmLocalizationTextsExcept(ctx context.Context, realmName string, locales []string) error {
+	return m.Called(realmName, locales).Error(0)
+}
+
+func (m *Mock) SyncRealmRequiredActions(ctx context.Context, realmName string, actions []RequiredAction) error {
+	return m.Called(realmName, actions).Error(0)
+}
+
+func (m *Mock) GetUserProfile(ctx context.Context, realmName string) (*UserProfileConfig, error) {
+	called := m.Called(realmName)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*UserProfileConfig), nil
+}
+
+func (m *Mock) UpdateUserProfile(ctx context.Context, realmName string, profile *UserProfileConfig) error {
+	return m.Called(realmName, profile).Error(0)
+}
+
+func (m *Mock) SyncRealmClientProfiles(ctx context.Context, realmName string, profiles []ClientProfile) error {
+	return m.Called(realmName, profiles).Error(0)
+}
+
+func (m *Mock) SyncRealmClientPolicies(ctx context.Context, realmName string, policies []ClientPolicy) error {
+	return m.Called(realmName, policies).Error(0)
+}
+
+func (m *Mock) SyncRealmDefaultGroups(ctx context.Context, realmName string, groups []string, addOnly bool) error {
+	return m.Called(realmName, groups, addOnly).Error(0)
+}
+
+func (m *Mock) SyncRealmDefaultRoles(ctx context.Context, realmName string, roles *DefaultRoles, addOnly bool) error {
+	return m.Called(realmName, roles, addOnly).Error(0)
+}
+
+func (m *Mock) PartialImport(ctx context.Context, realmName string, representation []byte, ifResourceExists string) (*PartialImportResult, error) {
+	called := m.Called(realmName, representation, ifResourceExists)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*PartialImportResult), nil
+}
+
+func (m *Mock) PartialExport(ctx context.Context, realmName string, opts PartialExportOptions) ([]byte, error) {
+	called := m.Called(realmName, opts)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]byte), nil
+}
+
 func (m *Mock) ExportToken() ([]byte, error) {
 	return m.ExportTokenResult, m.ExportTokenErr
 }
@@ -356,3 +419,12 @@ func (m *Mock) GetClientScopesByNames(ctx context.Context, realmName string, sco
 
 	return called.Get(0).([]ClientScope), nil
 }
+
+func (m *Mock) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	called := m.Called()
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*ServerInfo), nil
+}
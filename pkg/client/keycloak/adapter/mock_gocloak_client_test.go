@@ -26,6 +26,24 @@ func (m *MockGoCloakClient) LoginAdmin(ctx context.Context, username, password,
 	return args.Get(0).(*gocloak.JWT), args.Error(1)
 }
 
+func (m *MockGoCloakClient) RefreshToken(ctx context.Context, refreshToken, clientID, clientSecret, realm string) (*gocloak.JWT, error) {
+	args := m.Called(refreshToken, clientID, clientSecret, realm)
+	if err := args.Error(1); err != nil {
+		return nil, err
+	}
+
+	return args.Get(0).(*gocloak.JWT), nil
+}
+
+func (m *MockGoCloakClient) GetToken(ctx context.Context, realm string, options gocloak.TokenOptions) (*gocloak.JWT, error) {
+	args := m.Called(realm, options)
+	if err := args.Error(1); err != nil {
+		return nil, err
+	}
+
+	return args.Get(0).(*gocloak.JWT), nil
+}
+
 func (m *MockGoCloakClient) GetRealm(ctx context.Context, token, realm string) (*gocloak.RealmRepresentation, error) {
 	args := m.Called(token, realm)
 	res := args.Get(0)
@@ -30,6 +30,7 @@ type Client interface {
 		clientRoles map[string][]string, addOnly bool) error
 	SetServiceAccountAttributes(realm, clientID string, attributes map[string]string, addOnly bool) error
 	ExportToken() ([]byte, error)
+	GetServerInfo(ctx context.Context) (*adapter.ServerInfo, error)
 }
 
 type KIdentityProvider interface {
@@ -70,6 +71,18 @@ type KCloakRealms interface {
 	SyncRealmIdentityProviderMappers(realmName string, mappers []dto.IdentityProviderMapper) error
 	UpdateRealmSettings(realmName string, realmSettings *adapter.RealmSettings) error
 	SetRealmEventConfig(realmName string, eventConfig *adapter.RealmEventConfig) error
+	UpdateRealmSMTPServer(ctx context.Context, realmName string, smtp *adapter.SMTPServer) error
+	SyncRealmLocalizationTexts(ctx context.Context, realmName, locale string, texts map[string]string, addOnly bool) error
+	DeleteRealmLocalizationTextsExcept(ctx context.Context, realmName string, locales []string) error
+	SyncRealmRequiredActions(ctx context.Context, realmName string, actions []adapter.RequiredAction) error
+	GetUserProfile(ctx context.Context, realmName string) (*adapter.UserProfileConfig, error)
+	UpdateUserProfile(ctx context.Context, realmName string, profile *adapter.UserProfileConfig) error
+	SyncRealmClientProfiles(ctx context.Context, realmName string, profiles []adapter.ClientProfile) error
+	SyncRealmClientPolicies(ctx context.Context, realmName string, policies []adapter.ClientPolicy) error
+	SyncRealmDefaultGroups(ctx context.Context, realmName string, groups []string, addOnly bool) error
+	SyncRealmDefaultRoles(ctx context.Context, realmName string, roles *adapter.DefaultRoles, addOnly bool) error
+	PartialImport(ctx context.Context, realmName string, representation []byte, ifResourceExists string) (*adapter.PartialImportResult, error)
+	PartialExport(ctx context.Context, realmName string, opts adapter.PartialExportOptions) ([]byte, error)
 }
 
 type KCloakClients interface {
@@ -0,0 +1,605 @@
+// Package realmexport converts Keycloak realm representation into custom resources of the operator.
+// It is used to bring existing realms under the operator management.
+package realmexport
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/secretref"
+)
+
+// maskedValue is a value which Keycloak returns instead of secrets.
+const maskedValue = "**********"
+
+const maxNameLength = 63
+
+var (
+	builtInClients = map[string]bool{
+		"account":                true,
+		"account-console":        true,
+		"admin-cli":              true,
+		"broker":                 true,
+		"realm-management":       true,
+		"security-admin-console": true,
+	}
+
+	builtInRealmRoles = map[string]bool{
+		"offline_access":    true,
+		"uma_authorization": true,
+	}
+
+	builtInClientScopes = map[string]bool{
+		"acr":               true,
+		"address":           true,
+		"basic":             true,
+		"email":             true,
+		"microprofile-jwt":  true,
+		"offline_access":    true,
+		"phone":             true,
+		"profile":           true,
+		"role_list":         true,
+		"roles":             true,
+		"saml_organization": true,
+		"web-origins":       true,
+	}
+
+	// skippedComponentTypes are components which Keycloak creates for every realm
+	// or which are managed by KeycloakRealm and KeycloakRealmKey resources.
+	skippedComponentTypes = map[string]bool{
+		"org.keycloak.keys.KeyProvider":                                            true,
+		"org.keycloak.services.clientregistration.policy.ClientRegistrationPolicy": true,
+		"org.keycloak.userprofile.UserProfileProvider":                             true,
+	}
+
+	invalidNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+)
+
+// Options configures conversion of realm representation.
+type Options struct {
+	// Namespace is a namespace of custom resources.
+	Namespace string
+
+	// KeycloakRef is a name of Keycloak custom resource which owns the realm.
+	KeycloakRef string
+
+	// ClusterKeycloakRef is a name of ClusterKeycloak custom resource which owns the realm.
+	// If set, it takes precedence over KeycloakRef.
+	ClusterKeycloakRef string
+}
+
+// RequiredSecret is a Secret which should be created before applying custom resources.
+type RequiredSecret struct {
+	Name string
+	Keys []string
+}
+
+// Manifests contains custom resources converted from realm representation.
+type Manifests struct {
+	Objects []client.Object
+	Secrets []RequiredSecret
+}
+
+type converter struct {
+	opts      Options
+	realm     *realmRepresentation
+	realmName string
+	names     map[string]map[string]bool
+	secrets   map[string]map[string]bool
+	objects   []client.Object
+}
+
+// Convert converts realm representation, e.g. result of partial export, into custom resources.
+// Built-in Keycloak resources are skipped. Secrets are not exported, resources reference Secrets instead.
+func Convert(representation []byte, opts Options) (*Manifests, error) {
+	var realm realmRepresentation
+	if err := json.Unmarshal(representation, &realm); err != nil {
+		return nil, fmt.Errorf("unable to decode realm representation: %w", err)
+	}
+
+	if realm.Realm == "" {
+		return nil, fmt.Errorf("realm name is empty in realm representation")
+	}
+
+	c := converter{
+		opts:    opts,
+		realm:   &realm,
+		names:   make(map[string]map[string]bool),
+		secrets: make(map[string]map[string]bool),
+	}
+
+	c.convertRealm()
+	c.convertRealmRoles()
+	c.convertGroups(realm.Groups)
+	c.convertClientScopes()
+	c.convertClients()
+	c.convertAuthFlows()
+	c.convertIdentityProviders()
+	c.convertComponents("", realm.Components)
+
+	return &Manifests{
+		Objects: c.objects,
+		Secrets: c.requiredSecrets(),
+	}, nil
+}
+
+// Write writes manifests as multi-document YAML.
+// Status and server-side fields are omitted.
+func (m *Manifests) Write(w io.Writer) error {
+	if len(m.Secrets) > 0 {
+		if _, err := fmt.Fprintln(w, "# The following Secrets must be created before applying the manifests:"); err != nil {
+			return fmt.Errorf("unable to write manifests: %w", err)
+		}
+
+		for _, s := range m.Secrets {
+			if _, err := fmt.Fprintf(w, "#   %s: %s\n", s.Name, strings.Join(s.Keys, ", ")); err != nil {
+				return fmt.Errorf("unable to write manifests: %w", err)
+			}
+		}
+	}
+
+	for _, obj := range m.Objects {
+		data, err := marshalObject(obj)
+		if err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintf(w, "---\n%s", data); err != nil {
+			return fmt.Errorf("unable to write manifests: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func marshalObject(obj client.Object) ([]byte, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal %s: %w", obj.GetName(), err)
+	}
+
+	var raw map[string]interface{}
+	if err = json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal %s: %w", obj.GetName(), err)
+	}
+
+	delete(raw, "status")
+
+	if meta, ok := raw["metadata"].(map[string]interface{}); ok {
+		delete(meta, "creationTimestamp")
+	}
+
+	data, err = yaml.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal %s to yaml: %w", obj.GetName(), err)
+	}
+
+	return data, nil
+}
+
+func (c *converter) add(kind string, obj client.Object) {
+	obj.GetObjectKind().SetGroupVersionKind(keycloakApi.GroupVersion.WithKind(kind))
+	obj.SetNamespace(c.opts.Namespace)
+	c.objects = append(c.objects, obj)
+}
+
+// name returns unique for the kind resource name which is valid for Kubernetes.
+func (c *converter) name(kind, value string) string {
+	n := invalidNameChars.ReplaceAllString(strings.ToLower(value), "-")
+	if c.realmName != "" {
+		n = c.realmName + "-" + n
+	}
+
+	if len(n) > maxNameLength {
+		n = n[:maxNameLength]
+	}
+
+	n = strings.Trim(n, "-.")
+	if n == "" {
+		n = strings.ToLower(kind)
+	}
+
+	if c.names[kind] == nil {
+		c.names[kind] = make(map[string]bool)
+	}
+
+	res := n
+	for i := 2; c.names[kind][res]; i++ {
+		res = fmt.Sprintf("%s-%d", n, i)
+	}
+
+	c.names[kind][res] = true
+
+	return res
+}
+
+// secretRef registers a key of the Secret and returns reference to it.
+func (c *converter) secretRef(secretName, key string) string {
+	if c.secrets[secretName] == nil {
+		c.secrets[secretName] = make(map[string]bool)
+	}
+
+	c.secrets[secretName][key] = true
+
+	return secretref.GenerateSecretRef(secretName, key)
+}
+
+func (c *converter) requiredSecrets() []RequiredSecret {
+	secrets := make([]RequiredSecret, 0, len(c.secrets))
+
+	for name, keys := range c.secrets {
+		s := RequiredSecret{Name: name}
+		for k := range keys {
+			s.Keys = append(s.Keys, k)
+		}
+
+		sort.Strings(s.Keys)
+		secrets = append(secrets, s)
+	}
+
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+
+	return secrets
+}
+
+func (c *converter) meta(kind, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: c.name(kind, name)}
+}
+
+func (c *converter) convertRealm() {
+	realm := &keycloakApi.KeycloakRealm{
+		ObjectMeta: c.meta("KeycloakRealm", c.realm.Realm),
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName:          c.realm.Realm,
+			KeycloakOwner:      c.opts.KeycloakRef,
+			ClusterKeycloakRef: c.opts.ClusterKeycloakRef,
+		},
+	}
+
+	if c.opts.ClusterKeycloakRef != "" {
+		realm.Spec.KeycloakOwner = ""
+	}
+
+	c.realmName = realm.Name
+	c.add("KeycloakRealm", realm)
+}
+
+func (c *converter) convertRealmRoles() {
+	defaultRolesName := "default-roles-" + strings.ToLower(c.realm.Realm)
+	defaultRoles := make(map[string]bool)
+
+	for _, r := range c.realm.Roles.Realm {
+		if r.Name == defaultRolesName && r.Composites != nil {
+			for _, name := range r.Composites.Realm {
+				defaultRoles[name] = true
+			}
+		}
+	}
+
+	for _, r := range c.realm.Roles.Realm {
+		if r.Name == defaultRolesName || builtInRealmRoles[r.Name] {
+			continue
+		}
+
+		role := &keycloakApi.KeycloakRealmRole{
+			ObjectMeta: c.meta("KeycloakRealmRole", r.Name),
+			Spec: keycloakApi.KeycloakRealmRoleSpec{
+				Name:        r.Name,
+				Realm:       c.realmName,
+				Description: r.Description,
+				Attributes:  r.Attributes,
+				Composite:   r.Composite,
+				IsDefault:   defaultRoles[r.Name],
+			},
+		}
+
+		if r.Composites != nil {
+			for _, name := range r.Composites.Realm {
+				role.Spec.Composites = append(role.Spec.Composites, keycloakApi.Composite{Name: name})
+			}
+		}
+
+		c.add("KeycloakRealmRole", role)
+	}
+}
+
+func (c *converter) convertGroups(groups []groupRepresentation) {
+	for i := range groups {
+		g := &groups[i]
+
+		group := &keycloakApi.KeycloakRealmGroup{
+			ObjectMeta: c.meta("KeycloakRealmGroup", g.Name),
+			Spec: keycloakApi.KeycloakRealmGroupSpec{
+				Name:       g.Name,
+				Realm:      c.realmName,
+				Attributes: g.Attributes,
+				RealmRoles: g.RealmRoles,
+			},
+		}
+
+		for _, sg := range g.SubGroups {
+			group.Spec.SubGroups = append(group.Spec.SubGroups, sg.Name)
+		}
+
+		clientIDs := make([]string, 0, len(g.ClientRoles))
+		for clientID := range g.ClientRoles {
+			clientIDs = append(clientIDs, clientID)
+		}
+
+		sort.Strings(clientIDs)
+
+		for _, clientID := range clientIDs {
+			group.Spec.ClientRoles = append(group.Spec.ClientRoles, keycloakApi.ClientRole{
+				ClientID: clientID,
+				Roles:    g.ClientRoles[clientID],
+			})
+		}
+
+		c.add("KeycloakRealmGroup", group)
+		c.convertGroups(g.SubGroups)
+	}
+}
+
+func (c *converter) convertClientScopes() {
+	defaultScopes := make(map[string]bool, len(c.realm.DefaultDefaultClientScopes))
+	for _, s := range c.realm.DefaultDefaultClientScopes {
+		defaultScopes[s] = true
+	}
+
+	for _, s := range c.realm.ClientScopes {
+		if builtInClientScopes[s.Name] {
+			continue
+		}
+
+		scope := &keycloakApi.KeycloakClientScope{
+			ObjectMeta: c.meta("KeycloakClientScope", s.Name),
+			Spec: keycloakApi.KeycloakClientScopeSpec{
+				Name:            s.Name,
+				Realm:           c.realmName,
+				Protocol:        s.Protocol,
+				Description:     s.Description,
+				Attributes:      s.Attributes,
+				Default:         defaultScopes[s.Name],
+				ProtocolMappers: convertProtocolMappers(s.ProtocolMappers),
+			},
+		}
+
+		if scope.Spec.Protocol == "" {
+			scope.Spec.Protocol = "openid-connect"
+		}
+
+		c.add("KeycloakClientScope", scope)
+	}
+}
+
+func (c *converter) convertClients() {
+	for i := range c.realm.Clients {
+		cl := &c.realm.Clients[i]
+		if builtInClients[cl.ClientID] {
+			continue
+		}
+
+		kClient := &keycloakApi.KeycloakClient{
+			ObjectMeta: c.meta("KeycloakClient", cl.ClientID),
+			Spec: keycloakApi.KeycloakClientSpec{
+				ClientId:            cl.ClientID,
+				TargetRealm:         c.realm.Realm,
+				Public:              cl.PublicClient,
+				WebUrl:              cl.RootURL,
+				Attributes:          cl.Attributes,
+				DirectAccess:        cl.DirectAccessGrantsEnabled,
+				FrontChannelLogout:  cl.FrontchannelLogout,
+				DefaultClientScopes: cl.DefaultClientScopes,
+			},
+		}
+
+		if cl.Protocol != "" {
+			protocol := cl.Protocol
+			kClient.Spec.Protocol = &protocol
+		}
+
+		if mappers := convertProtocolMappers(cl.ProtocolMappers); len(mappers) > 0 {
+			kClient.Spec.ProtocolMappers = &mappers
+		}
+
+		for _, r := range c.realm.Roles.Client[cl.ClientID] {
+			kClient.Spec.ClientRoles = append(kClient.Spec.ClientRoles, r.Name)
+		}
+
+		if cl.ServiceAccountsEnabled {
+			kClient.Spec.ServiceAccount = &keycloakApi.ServiceAccount{Enabled: true, RealmRoles: []string{}}
+		}
+
+		if !cl.PublicClient && !cl.BearerOnly {
+			kClient.Spec.Secret = fmt.Sprintf("keycloak-client-%s-secret", kClient.Name)
+			c.secretRef(kClient.Spec.Secret, keycloakApi.ClientSecretKey)
+		}
+
+		c.add("KeycloakClient", kClient)
+	}
+}
+
+func convertProtocolMappers(mappers []protocolMapperRepresentation) []keycloakApi.ProtocolMapper {
+	if len(mappers) == 0 {
+		return nil
+	}
+
+	res := make([]keycloakApi.ProtocolMapper, 0, len(mappers))
+	for _, m := range mappers {
+		res = append(res, keycloakApi.ProtocolMapper{
+			Name:           m.Name,
+			Protocol:       m.Protocol,
+			ProtocolMapper: m.ProtocolMapper,
+			Config:         m.Config,
+		})
+	}
+
+	return res
+}
+
+func (c *converter) convertAuthFlows() {
+	authConfigs := make(map[string]map[string]string, len(c.realm.AuthenticatorConfig))
+	for _, cfg := range c.realm.AuthenticatorConfig {
+		authConfigs[cfg.Alias] = cfg.Config
+	}
+
+	parents := make(map[string]string)
+
+	for _, f := range c.realm.AuthenticationFlows {
+		for _, e := range f.AuthenticationExecutions {
+			if e.AuthenticatorFlow {
+				parents[e.FlowAlias] = f.Alias
+			}
+		}
+	}
+
+	for _, f := range c.realm.AuthenticationFlows {
+		if f.BuiltIn {
+			continue
+		}
+
+		flow := &keycloakApi.KeycloakAuthFlow{
+			ObjectMeta: c.meta("KeycloakAuthFlow", f.Alias),
+			Spec: keycloakApi.KeycloakAuthFlowSpec{
+				Realm:       c.realmName,
+				Alias:       f.Alias,
+				Description: f.Description,
+				ProviderID:  f.ProviderID,
+				TopLevel:    f.TopLevel,
+			},
+		}
+
+		if !f.TopLevel {
+			flow.Spec.ParentName = parents[f.Alias]
+			flow.Spec.ChildType = f.ProviderID
+		}
+
+		for _, e := range f.AuthenticationExecutions {
+			exec := keycloakApi.AuthenticationExecution{
+				Authenticator:     e.Authenticator,
+				AuthenticatorFlow: e.AuthenticatorFlow,
+				Priority:          e.Priority,
+				Requirement:       e.Requirement,
+			}
+
+			if e.AuthenticatorFlow {
+				exec.Alias = e.FlowAlias
+			}
+
+			if e.AuthenticatorConfig != "" {
+				exec.AuthenticatorConfig = &keycloakApi.AuthenticatorConfig{
+					Alias:  e.AuthenticatorConfig,
+					Config: authConfigs[e.AuthenticatorConfig],
+				}
+			}
+
+			flow.Spec.AuthenticationExecutions = append(flow.Spec.AuthenticationExecutions, exec)
+		}
+
+		c.add("KeycloakAuthFlow", flow)
+	}
+}
+
+func (c *converter) convertIdentityProviders() {
+	mappers := make(map[string][]keycloakApi.IdentityProviderMapper)
+	for _, m := range c.realm.IdentityProviderMappers {
+		mappers[m.IdentityProviderAlias] = append(mappers[m.IdentityProviderAlias], keycloakApi.IdentityProviderMapper{
+			IdentityProviderAlias:  m.IdentityProviderAlias,
+			IdentityProviderMapper: m.IdentityProviderMapper,
+			Name:                   m.Name,
+			Config:                 m.Config,
+		})
+	}
+
+	for _, p := range c.realm.IdentityProviders {
+		idp := &keycloakApi.KeycloakRealmIdentityProvider{
+			ObjectMeta: c.meta("KeycloakRealmIdentityProvider", p.Alias),
+			Spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				Realm:                     c.realmName,
+				ProviderID:                p.ProviderID,
+				Alias:                     p.Alias,
+				Config:                    make(map[string]string, len(p.Config)),
+				Enabled:                   p.Enabled,
+				AddReadTokenRoleOnCreate:  p.AddReadTokenRoleOnCreate,
+				AuthenticateByDefault:     p.AuthenticateByDefault,
+				DisplayName:               p.DisplayName,
+				FirstBrokerLoginFlowAlias: p.FirstBrokerLoginFlowAlias,
+				LinkOnly:                  p.LinkOnly,
+				StoreToken:                p.StoreToken,
+				TrustEmail:                p.TrustEmail,
+				Mappers:                   mappers[p.Alias],
+			},
+		}
+
+		secretName := fmt.Sprintf("keycloak-idp-%s-secret", idp.Name)
+
+		for k, v := range p.Config {
+			if v == maskedValue {
+				v = c.secretRef(secretName, k)
+			}
+
+			idp.Spec.Config[k] = v
+		}
+
+		c.add("KeycloakRealmIdentityProvider", idp)
+	}
+}
+
+func (c *converter) convertComponents(parent string, components map[string][]componentRepresentation) {
+	providerTypes := make([]string, 0, len(components))
+	for t := range components {
+		providerTypes = append(providerTypes, t)
+	}
+
+	sort.Strings(providerTypes)
+
+	for _, providerType := range providerTypes {
+		if skippedComponentTypes[providerType] {
+			continue
+		}
+
+		for _, cmp := range components[providerType] {
+			component := &keycloakApi.KeycloakRealmComponent{
+				ObjectMeta: c.meta("KeycloakRealmComponent", cmp.Name),
+				Spec: keycloakApi.KeycloakComponentSpec{
+					Name:         cmp.Name,
+					Realm:        c.realmName,
+					ProviderID:   cmp.ProviderID,
+					ProviderType: providerType,
+					Parent:       parent,
+					Config:       make(map[string][]string, len(cmp.Config)),
+				},
+			}
+
+			secretName := fmt.Sprintf("keycloak-component-%s-secret", component.Name)
+
+			for k, values := range cmp.Config {
+				config := make([]string, 0, len(values))
+
+				for _, v := range values {
+					if v == maskedValue {
+						v = c.secretRef(secretName, k)
+					}
+
+					config = append(config, v)
+				}
+
+				component.Spec.Config[k] = config
+			}
+
+			c.add("KeycloakRealmComponent", component)
+			c.convertComponents(component.Name, cmp.SubComponents)
+		}
+	}
+}
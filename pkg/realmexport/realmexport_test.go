@@ -0,0 +1,233 @@
+package realmexport
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+)
+
+const representation = `{
+  "realm": "Sales",
+  "roles": {
+    "realm": [
+      {"name": "default-roles-sales", "composite": true, "composites": {"realm": ["offline_access", "viewer"]}},
+      {"name": "offline_access"},
+      {"name": "viewer", "description": "Read only"},
+      {"name": "editor", "composite": true, "composites": {"realm": ["viewer"]}}
+    ],
+    "client": {
+      "crm": [{"name": "crm-admin"}],
+      "account": [{"name": "manage-account"}]
+    }
+  },
+  "groups": [
+    {
+      "name": "Managers",
+      "realmRoles": ["editor"],
+      "clientRoles": {"crm": ["crm-admin"]},
+      "subGroups": [{"name": "EU Managers", "realmRoles": ["viewer"]}]
+    }
+  ],
+  "clients": [
+    {"clientId": "account", "publicClient": true},
+    {
+      "clientId": "crm",
+      "rootUrl": "https://crm.example.com",
+      "protocol": "openid-connect",
+      "serviceAccountsEnabled": true,
+      "secret": "**********",
+      "defaultClientScopes": ["profile", "crm-scope"],
+      "protocolMappers": [{"name": "aud", "protocol": "openid-connect", "protocolMapper": "oidc-audience-mapper", "config": {"included.client.audience": "crm"}}]
+    },
+    {"clientId": "spa", "publicClient": true}
+  ],
+  "clientScopes": [
+    {"name": "profile", "protocol": "openid-connect"},
+    {"name": "crm-scope", "protocol": "openid-connect", "description": "CRM"}
+  ],
+  "defaultDefaultClientScopes": ["profile", "crm-scope"],
+  "authenticationFlows": [
+    {"alias": "browser", "providerId": "basic-flow", "topLevel": true, "builtIn": true},
+    {
+      "alias": "custom-browser",
+      "providerId": "basic-flow",
+      "topLevel": true,
+      "authenticationExecutions": [
+        {"authenticator": "auth-cookie", "requirement": "ALTERNATIVE", "priority": 10},
+        {"authenticatorFlow": true, "flowAlias": "custom-forms", "requirement": "ALTERNATIVE", "priority": 20}
+      ]
+    },
+    {
+      "alias": "custom-forms",
+      "providerId": "basic-flow",
+      "authenticationExecutions": [
+        {"authenticator": "auth-otp-form", "authenticatorConfig": "otp", "requirement": "REQUIRED", "priority": 10}
+      ]
+    }
+  ],
+  "authenticatorConfig": [{"alias": "otp", "config": {"otp.length": "6"}}],
+  "identityProviders": [
+    {"alias": "github", "providerId": "github", "enabled": true, "config": {"clientId": "gh", "clientSecret": "**********"}}
+  ],
+  "identityProviderMappers": [
+    {"name": "email", "identityProviderAlias": "github", "identityProviderMapper": "hardcoded-attribute-idp-mapper", "config": {"attribute": "email"}}
+  ],
+  "components": {
+    "org.keycloak.keys.KeyProvider": [{"name": "rsa-generated", "providerId": "rsa-generated"}],
+    "org.keycloak.storage.UserStorageProvider": [
+      {
+        "name": "ldap",
+        "providerId": "ldap",
+        "config": {"bindDn": ["cn=admin"], "bindCredential": ["**********"]},
+        "subComponents": {
+          "org.keycloak.storage.ldap.mappers.LDAPStorageMapper": [
+            {"name": "username", "providerId": "user-attribute-ldap-mapper", "config": {"ldap.attribute": ["uid"]}}
+          ]
+        }
+      }
+    ]
+  }
+}`
+
+func find[T client.Object](t *testing.T, m *Manifests, name string) T {
+	t.Helper()
+
+	for _, obj := range m.Objects {
+		if res, ok := obj.(T); ok && obj.GetName() == name {
+			return res
+		}
+	}
+
+	require.Failf(t, "object not found", "name: %s", name)
+
+	var empty T
+
+	return empty
+}
+
+func TestConvert(t *testing.T) {
+	m, err := Convert([]byte(representation), Options{Namespace: "ns", KeycloakRef: "keycloak"})
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(m.Objects))
+	for _, obj := range m.Objects {
+		assert.Equal(t, "ns", obj.GetNamespace())
+		names = append(names, obj.GetObjectKind().GroupVersionKind().Kind+"/"+obj.GetName())
+	}
+
+	assert.Equal(t, []string{
+		"KeycloakRealm/sales",
+		"KeycloakRealmRole/sales-viewer",
+		"KeycloakRealmRole/sales-editor",
+		"KeycloakRealmGroup/sales-managers",
+		"KeycloakRealmGroup/sales-eu-managers",
+		"KeycloakClientScope/sales-crm-scope",
+		"KeycloakClient/sales-crm",
+		"KeycloakClient/sales-spa",
+		"KeycloakAuthFlow/sales-custom-browser",
+		"KeycloakAuthFlow/sales-custom-forms",
+		"KeycloakRealmIdentityProvider/sales-github",
+		"KeycloakRealmComponent/sales-ldap",
+		"KeycloakRealmComponent/sales-username",
+	}, names)
+
+	realm := find[*keycloakApi.KeycloakRealm](t, m, "sales")
+	assert.Equal(t, "Sales", realm.Spec.RealmName)
+	assert.Equal(t, "keycloak", realm.Spec.KeycloakOwner)
+
+	viewer := find[*keycloakApi.KeycloakRealmRole](t, m, "sales-viewer")
+	assert.True(t, viewer.Spec.IsDefault)
+	assert.Equal(t, "sales", viewer.Spec.Realm)
+
+	editor := find[*keycloakApi.KeycloakRealmRole](t, m, "sales-editor")
+	assert.False(t, editor.Spec.IsDefault)
+	assert.Equal(t, []keycloakApi.Composite{{Name: "viewer"}}, editor.Spec.Composites)
+
+	group := find[*keycloakApi.KeycloakRealmGroup](t, m, "sales-managers")
+	assert.Equal(t, []string{"EU Managers"}, group.Spec.SubGroups)
+	assert.Equal(t, []keycloakApi.ClientRole{{ClientID: "crm", Roles: []string{"crm-admin"}}}, group.Spec.ClientRoles)
+
+	scope := find[*keycloakApi.KeycloakClientScope](t, m, "sales-crm-scope")
+	assert.True(t, scope.Spec.Default)
+
+	crm := find[*keycloakApi.KeycloakClient](t, m, "sales-crm")
+	assert.Equal(t, "Sales", crm.Spec.TargetRealm)
+	assert.Equal(t, "keycloak-client-sales-crm-secret", crm.Spec.Secret)
+	assert.Equal(t, []string{"crm-admin"}, crm.Spec.ClientRoles)
+	assert.True(t, crm.Spec.ServiceAccount.Enabled)
+	require.NotNil(t, crm.Spec.ProtocolMappers)
+	assert.Len(t, *crm.Spec.ProtocolMappers, 1)
+
+	spa := find[*keycloakApi.KeycloakClient](t, m, "sales-spa")
+	assert.Empty(t, spa.Spec.Secret)
+
+	forms := find[*keycloakApi.KeycloakAuthFlow](t, m, "sales-custom-forms")
+	assert.Equal(t, "custom-browser", forms.Spec.ParentName)
+	assert.Equal(t, "basic-flow", forms.Spec.ChildType)
+	assert.Equal(t, &keycloakApi.AuthenticatorConfig{Alias: "otp", Config: map[string]string{"otp.length": "6"}},
+		forms.Spec.AuthenticationExecutions[0].AuthenticatorConfig)
+
+	browser := find[*keycloakApi.KeycloakAuthFlow](t, m, "sales-custom-browser")
+	assert.Equal(t, "custom-forms", browser.Spec.AuthenticationExecutions[1].Alias)
+
+	idp := find[*keycloakApi.KeycloakRealmIdentityProvider](t, m, "sales-github")
+	assert.Equal(t, "$keycloak-idp-sales-github-secret:clientSecret", idp.Spec.Config["clientSecret"])
+	assert.Len(t, idp.Spec.Mappers, 1)
+
+	ldap := find[*keycloakApi.KeycloakRealmComponent](t, m, "sales-ldap")
+	assert.Equal(t, []string{"$keycloak-component-sales-ldap-secret:bindCredential"}, ldap.Spec.Config["bindCredential"])
+
+	mapper := find[*keycloakApi.KeycloakRealmComponent](t, m, "sales-username")
+	assert.Equal(t, "sales-ldap", mapper.Spec.Parent)
+	assert.Equal(t, "org.keycloak.storage.ldap.mappers.LDAPStorageMapper", mapper.Spec.ProviderType)
+
+	assert.Equal(t, []RequiredSecret{
+		{Name: "keycloak-client-sales-crm-secret", Keys: []string{"clientSecret"}},
+		{Name: "keycloak-component-sales-ldap-secret", Keys: []string{"bindCredential"}},
+		{Name: "keycloak-idp-sales-github-secret", Keys: []string{"clientSecret"}},
+	}, m.Secrets)
+}
+
+func TestConvert_uniqueNames(t *testing.T) {
+	m, err := Convert([]byte(`{"realm": "r", "clients": [{"clientId": "App", "publicClient": true}, {"clientId": "app", "publicClient": true}]}`),
+		Options{ClusterKeycloakRef: "keycloak"})
+	require.NoError(t, err)
+	require.Len(t, m.Objects, 3)
+
+	assert.Equal(t, "r-app", m.Objects[1].GetName())
+	assert.Equal(t, "r-app-2", m.Objects[2].GetName())
+	assert.Equal(t, "keycloak", m.Objects[0].(*keycloakApi.KeycloakRealm).Spec.ClusterKeycloakRef)
+}
+
+func TestConvert_invalidRepresentation(t *testing.T) {
+	_, err := Convert([]byte(`{}`), Options{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "realm name is empty")
+
+	_, err = Convert([]byte(`[`), Options{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to decode realm representation")
+}
+
+func TestManifests_Write(t *testing.T) {
+	m, err := Convert([]byte(representation), Options{Namespace: "ns", KeycloakRef: "keycloak"})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, m.Write(&buf))
+
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, "# The following Secrets must be created before applying the manifests:\n"))
+	assert.Contains(t, out, "#   keycloak-client-sales-crm-secret: clientSecret\n")
+	assert.Equal(t, len(m.Objects), strings.Count(out, "\n---\n"))
+	assert.Contains(t, out, "apiVersion: v1.edp.epam.com/v1\nkind: KeycloakRealm\nmetadata:\n  name: sales\n  namespace: ns\n")
+	assert.NotContains(t, out, "status:")
+	assert.NotContains(t, out, "creationTimestamp")
+	assert.NotContains(t, out, "**********")
+}
@@ -0,0 +1,123 @@
+package realmexport
+
+// realmRepresentation is a part of Keycloak realm representation which is converted to custom resources.
+type realmRepresentation struct {
+	Realm                      string                               `json:"realm"`
+	Roles                      rolesRepresentation                  `json:"roles"`
+	Groups                     []groupRepresentation                `json:"groups"`
+	Clients                    []clientRepresentation               `json:"clients"`
+	ClientScopes               []clientScopeRepresentation          `json:"clientScopes"`
+	DefaultDefaultClientScopes []string                             `json:"defaultDefaultClientScopes"`
+	AuthenticationFlows        []authFlowRepresentation             `json:"authenticationFlows"`
+	AuthenticatorConfig        []authConfigRepresentation           `json:"authenticatorConfig"`
+	IdentityProviders          []idpRepresentation                  `json:"identityProviders"`
+	IdentityProviderMappers    []idpMapperRepresentation            `json:"identityProviderMappers"`
+	Components                 map[string][]componentRepresentation `json:"components"`
+}
+
+type rolesRepresentation struct {
+	Realm  []roleRepresentation            `json:"realm"`
+	Client map[string][]roleRepresentation `json:"client"`
+}
+
+type roleRepresentation struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Composite   bool                `json:"composite"`
+	Composites  *roleComposites     `json:"composites"`
+	Attributes  map[string][]string `json:"attributes"`
+}
+
+type roleComposites struct {
+	Realm []string `json:"realm"`
+}
+
+type groupRepresentation struct {
+	Name        string                `json:"name"`
+	Path        string                `json:"path"`
+	Attributes  map[string][]string   `json:"attributes"`
+	RealmRoles  []string              `json:"realmRoles"`
+	ClientRoles map[string][]string   `json:"clientRoles"`
+	SubGroups   []groupRepresentation `json:"subGroups"`
+}
+
+type clientRepresentation struct {
+	ClientID                  string                         `json:"clientId"`
+	RootURL                   string                         `json:"rootUrl"`
+	BaseURL                   string                         `json:"baseUrl"`
+	Protocol                  string                         `json:"protocol"`
+	PublicClient              bool                           `json:"publicClient"`
+	BearerOnly                bool                           `json:"bearerOnly"`
+	DirectAccessGrantsEnabled bool                           `json:"directAccessGrantsEnabled"`
+	ServiceAccountsEnabled    bool                           `json:"serviceAccountsEnabled"`
+	FrontchannelLogout        bool                           `json:"frontchannelLogout"`
+	Attributes                map[string]string              `json:"attributes"`
+	DefaultClientScopes       []string                       `json:"defaultClientScopes"`
+	ProtocolMappers           []protocolMapperRepresentation `json:"protocolMappers"`
+}
+
+type protocolMapperRepresentation struct {
+	Name           string            `json:"name"`
+	Protocol       string            `json:"protocol"`
+	ProtocolMapper string            `json:"protocolMapper"`
+	Config         map[string]string `json:"config"`
+}
+
+type clientScopeRepresentation struct {
+	Name            string                         `json:"name"`
+	Description     string                         `json:"description"`
+	Protocol        string                         `json:"protocol"`
+	Attributes      map[string]string              `json:"attributes"`
+	ProtocolMappers []protocolMapperRepresentation `json:"protocolMappers"`
+}
+
+type authFlowRepresentation struct {
+	Alias                    string                        `json:"alias"`
+	Description              string                        `json:"description"`
+	ProviderID               string                        `json:"providerId"`
+	TopLevel                 bool                          `json:"topLevel"`
+	BuiltIn                  bool                          `json:"builtIn"`
+	AuthenticationExecutions []authExecutionRepresentation `json:"authenticationExecutions"`
+}
+
+type authExecutionRepresentation struct {
+	Authenticator       string `json:"authenticator"`
+	AuthenticatorConfig string `json:"authenticatorConfig"`
+	AuthenticatorFlow   bool   `json:"authenticatorFlow"`
+	FlowAlias           string `json:"flowAlias"`
+	Requirement         string `json:"requirement"`
+	Priority            int    `json:"priority"`
+}
+
+type authConfigRepresentation struct {
+	Alias  string            `json:"alias"`
+	Config map[string]string `json:"config"`
+}
+
+type idpRepresentation struct {
+	Alias                     string            `json:"alias"`
+	DisplayName               string            `json:"displayName"`
+	ProviderID                string            `json:"providerId"`
+	Enabled                   bool              `json:"enabled"`
+	TrustEmail                bool              `json:"trustEmail"`
+	StoreToken                bool              `json:"storeToken"`
+	AddReadTokenRoleOnCreate  bool              `json:"addReadTokenRoleOnCreate"`
+	AuthenticateByDefault     bool              `json:"authenticateByDefault"`
+	LinkOnly                  bool              `json:"linkOnly"`
+	FirstBrokerLoginFlowAlias string            `json:"firstBrokerLoginFlowAlias"`
+	Config                    map[string]string `json:"config"`
+}
+
+type idpMapperRepresentation struct {
+	Name                   string            `json:"name"`
+	IdentityProviderAlias  string            `json:"identityProviderAlias"`
+	IdentityProviderMapper string            `json:"identityProviderMapper"`
+	Config                 map[string]string `json:"config"`
+}
+
+type componentRepresentation struct {
+	Name          string                               `json:"name"`
+	ProviderID    string                               `json:"providerId"`
+	SubComponents map[string][]componentRepresentation `json:"subComponents"`
+	Config        map[string][]string                  `json:"config"`
+}
@@ -0,0 +1,93 @@
+// Package secretref resolves references to Kubernetes Secrets used in configuration values.
+// Reference has the format $secretName:secretKey.
+package secretref
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var secretRefRegexp = regexp.MustCompile(`^\$([a-z0-9]([-a-z0-9.]*[a-z0-9])?):([-._a-zA-Z0-9]+)$`)
+
+// GenerateSecretRef returns reference to the key of the Secret.
+func GenerateSecretRef(secretName, secretKey string) string {
+	return fmt.Sprintf("$%s:%s", secretName, secretKey)
+}
+
+// HasSecretRef checks if the value is a reference to the Secret.
+func HasSecretRef(val string) bool {
+	return secretRefRegexp.MatchString(val)
+}
+
+func parseSecretRef(val string) (secretName, secretKey string, ok bool) {
+	m := secretRefRegexp.FindStringSubmatch(val)
+	if m == nil {
+		return "", "", false
+	}
+
+	return m[1], m[3], true
+}
+
+type SecretRef struct {
+	client client.Client
+}
+
+func NewSecretRef(k8sClient client.Client) *SecretRef {
+	return &SecretRef{client: k8sClient}
+}
+
+// MapConfigSecretsRefs replaces secret references in config values with values from Secrets.
+func (s *SecretRef) MapConfigSecretsRefs(ctx context.Context, config map[string]string, namespace string) error {
+	for k, v := range config {
+		val, err := s.resolve(ctx, v, namespace)
+		if err != nil {
+			return err
+		}
+
+		config[k] = val
+	}
+
+	return nil
+}
+
+// MapComponentConfigSecretsRefs replaces secret references in component config values with values from Secrets.
+func (s *SecretRef) MapComponentConfigSecretsRefs(ctx context.Context, config map[string][]string, namespace string) error {
+	for k, values := range config {
+		for i, v := range values {
+			val, err := s.resolve(ctx, v, namespace)
+			if err != nil {
+				return err
+			}
+
+			values[i] = val
+		}
+
+		config[k] = values
+	}
+
+	return nil
+}
+
+func (s *SecretRef) resolve(ctx context.Context, val, namespace string) (string, error) {
+	secretName, secretKey, ok := parseSecretRef(val)
+	if !ok {
+		return val, nil
+	}
+
+	var secret corev1.Secret
+	if err := s.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: secretName}, &secret); err != nil {
+		return "", fmt.Errorf("unable to get secret %s: %w", secretName, err)
+	}
+
+	data, ok := secret.Data[secretKey]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in secret %s", secretKey, secretName)
+	}
+
+	return string(data), nil
+}
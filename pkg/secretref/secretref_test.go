@@ -0,0 +1,50 @@
+package secretref
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestHasSecretRef(t *testing.T) {
+	assert.True(t, HasSecretRef(GenerateSecretRef("idp-secret", "clientSecret")))
+	assert.True(t, HasSecretRef("$ldap.bind:bind_credential"))
+	assert.False(t, HasSecretRef("plain-value"))
+	assert.False(t, HasSecretRef("$not a ref"))
+	assert.False(t, HasSecretRef("${vault.secret}"))
+}
+
+func TestSecretRef_MapConfigSecretsRefs(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	k8sClient := fake.NewClientBuilder().WithScheme(sch).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "idp-secret", Namespace: "ns"},
+		Data:       map[string][]byte{"clientSecret": []byte("secret-value")},
+	}).Build()
+
+	s := NewSecretRef(k8sClient)
+
+	config := map[string]string{"clientId": "app", "clientSecret": "$idp-secret:clientSecret"}
+	require.NoError(t, s.MapConfigSecretsRefs(context.Background(), config, "ns"))
+	assert.Equal(t, map[string]string{"clientId": "app", "clientSecret": "secret-value"}, config)
+
+	componentConfig := map[string][]string{"bindCredential": {"$idp-secret:clientSecret"}, "enabled": {"true"}}
+	require.NoError(t, s.MapComponentConfigSecretsRefs(context.Background(), componentConfig, "ns"))
+	assert.Equal(t, map[string][]string{"bindCredential": {"secret-value"}, "enabled": {"true"}}, componentConfig)
+
+	err := s.MapConfigSecretsRefs(context.Background(), map[string]string{"clientSecret": "$idp-secret:missing"}, "ns")
+	require.Error(t, err)
+	assert.Equal(t, "key missing not found in secret idp-secret", err.Error())
+
+	err = s.MapConfigSecretsRefs(context.Background(), map[string]string{"clientSecret": "$other:key"}, "ns")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to get secret other")
+}
@@ -8,6 +8,7 @@ import (
 
 const (
 	watchNamespaceEnvVar   = "WATCH_NAMESPACE"
+	operatorNamespaceEnv   = "OPERATOR_NAMESPACE"
 	debugModeEnvVar        = "DEBUG_MODE"
 	inClusterNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
 )
@@ -22,6 +23,17 @@ func GetWatchNamespace() (string, error) {
 	return ns, nil
 }
 
+// GetOperatorNamespace returns the namespace the operator is running in.
+// Cluster scoped resources keep their secrets in this namespace.
+func GetOperatorNamespace() (string, error) {
+	ns, found := os.LookupEnv(operatorNamespaceEnv)
+	if !found {
+		return "", fmt.Errorf("%s must be set", operatorNamespaceEnv)
+	}
+
+	return ns, nil
+}
+
 // GetDebugMode returns the debug mode value.
 func GetDebugMode() (bool, error) {
 	mode, found := os.LookupEnv(debugModeEnvVar)